@@ -12,6 +12,36 @@ type CacheRepository interface {
 	GetBookingStatus(bookingID string) (*model.BookingStatusUpdate, error)
 	SetBookingStatus(bookingID string, status *model.BookingStatusUpdate, ttl time.Duration) error
 	InvalidateBookingStatus(bookingID string) error
+	GetBookingStatuses(bookingIDs []string) (map[string]*model.BookingStatusUpdate, error)
+
+	// Per-user booking counts by status for dashboards
+	GetBookingCounts(userID string) (map[string]int, error)
+	SetBookingCounts(userID string, counts map[string]int, ttl time.Duration) error
+	InvalidateBookingCounts(userID string) error
+
+	// Users who deleted their account, whose unexpired tokens must be refused
+	MarkUserDeleted(userID string, ttl time.Duration) error
+	IsUserDeleted(userID string) (bool, error)
+
+	// Confirmation resend rate limiting
+	AcquireResendCooldown(bookingID string, ttl time.Duration) (bool, time.Duration, error)
+	ClearResendCooldown(bookingID string) error
+
+	// Consumer-side dedupe of booking messages. A lease marks a booking as being
+	// processed and is replaced by a longer-lived marker once processing finishes.
+	AcquireProcessingLease(bookingID string, ttl time.Duration) (bool, error)
+	MarkBookingProcessed(bookingID string, ttl time.Duration) error
+	// ResetBookingProcessed forgets that a booking was processed so a message
+	// queued again for it isn't skipped. A live processing lease is kept and
+	// the time until it expires returned instead.
+	ResetBookingProcessed(bookingID string) (leaseRemaining time.Duration, err error)
+
+	// Rolling window of booking processing durations for completion estimates
+	RecordProcessingDuration(duration time.Duration) error
+	GetAverageProcessingDuration() (time.Duration, error)
+
+	// Runtime maintenance switch, set through the event service's admin API
+	MaintenanceMode() (bool, error)
 
 	// Health check
 	Ping() error
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/arunvm123/eventbooking/booking-service/config"
 	"github.com/arunvm123/eventbooking/booking-service/model"
 	"github.com/redis/go-redis/v9"
 )
@@ -15,11 +17,16 @@ type RedisCacheRepository struct {
 	ctx    context.Context
 }
 
-func NewRedisCacheRepository(redisURL, password string, db int) (*RedisCacheRepository, error) {
+func NewRedisCacheRepository(cfg *config.Redis) (*RedisCacheRepository, error) {
 	client := redis.NewClient(&redis.Options{
-		Addr:     redisURL,
-		Password: password,
-		DB:       db,
+		Addr:         cfg.GetRedisURL(),
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		DialTimeout:  time.Duration(cfg.DialTimeoutMillis) * time.Millisecond,
+		ReadTimeout:  time.Duration(cfg.ReadTimeoutMillis) * time.Millisecond,
+		WriteTimeout: time.Duration(cfg.WriteTimeoutMillis) * time.Millisecond,
+		MaxRetries:   cfg.MaxRetries,
 	})
 
 	ctx := context.Background()
@@ -35,11 +42,33 @@ func NewRedisCacheRepository(redisURL, password string, db int) (*RedisCacheRepo
 	}, nil
 }
 
-// Cache key generator
+// Cache key generators
 func (r *RedisCacheRepository) bookingStatusKey(bookingID string) string {
 	return fmt.Sprintf("booking_status:%s", bookingID)
 }
 
+// processingDurationsKey holds the most recent booking processing durations
+const processingDurationsKey = "booking_processing_durations"
+
+// processingDurationSamples is the size of the rolling window
+const processingDurationSamples = 100
+
+func (r *RedisCacheRepository) bookingProcessedKey(bookingID string) string {
+	return fmt.Sprintf("booking_processed:%s", bookingID)
+}
+
+func (r *RedisCacheRepository) bookingCountsKey(userID string) string {
+	return fmt.Sprintf("booking_counts:%s", userID)
+}
+
+func (r *RedisCacheRepository) deletedUserKey(userID string) string {
+	return fmt.Sprintf("deleted_user:%s", userID)
+}
+
+func (r *RedisCacheRepository) resendCooldownKey(bookingID string) string {
+	return fmt.Sprintf("booking_resend:%s", bookingID)
+}
+
 // GetBookingStatus retrieves booking status update from cache
 func (r *RedisCacheRepository) GetBookingStatus(bookingID string) (*model.BookingStatusUpdate, error) {
 	key := r.bookingStatusKey(bookingID)
@@ -70,12 +99,192 @@ func (r *RedisCacheRepository) SetBookingStatus(bookingID string, status *model.
 	return r.client.Set(r.ctx, key, statusData, ttl).Err()
 }
 
+// GetBookingStatuses retrieves several booking statuses in one round trip.
+// Cache misses are simply absent from the returned map.
+func (r *RedisCacheRepository) GetBookingStatuses(bookingIDs []string) (map[string]*model.BookingStatusUpdate, error) {
+	keys := make([]string, len(bookingIDs))
+	for i, bookingID := range bookingIDs {
+		keys[i] = r.bookingStatusKey(bookingID)
+	}
+
+	values, err := r.client.MGet(r.ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]*model.BookingStatusUpdate, len(bookingIDs))
+	for i, value := range values {
+		statusData, ok := value.(string)
+		if !ok {
+			continue // Cache miss
+		}
+
+		var status model.BookingStatusUpdate
+		if err := json.Unmarshal([]byte(statusData), &status); err != nil {
+			continue
+		}
+		statuses[bookingIDs[i]] = &status
+	}
+
+	return statuses, nil
+}
+
 // InvalidateBookingStatus removes booking status from cache
 func (r *RedisCacheRepository) InvalidateBookingStatus(bookingID string) error {
 	key := r.bookingStatusKey(bookingID)
 	return r.client.Del(r.ctx, key).Err()
 }
 
+// GetBookingCounts retrieves a user's booking counts by status from cache
+func (r *RedisCacheRepository) GetBookingCounts(userID string) (map[string]int, error) {
+	countsData, err := r.client.Get(r.ctx, r.bookingCountsKey(userID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil // Cache miss
+		}
+		return nil, err
+	}
+
+	var counts map[string]int
+	if err := json.Unmarshal([]byte(countsData), &counts); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// SetBookingCounts stores a user's booking counts by status in cache
+func (r *RedisCacheRepository) SetBookingCounts(userID string, counts map[string]int, ttl time.Duration) error {
+	countsData, err := json.Marshal(counts)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(r.ctx, r.bookingCountsKey(userID), countsData, ttl).Err()
+}
+
+// InvalidateBookingCounts removes a user's booking counts from cache
+func (r *RedisCacheRepository) InvalidateBookingCounts(userID string) error {
+	return r.client.Del(r.ctx, r.bookingCountsKey(userID)).Err()
+}
+
+// MarkUserDeleted records that a user deleted their account
+func (r *RedisCacheRepository) MarkUserDeleted(userID string, ttl time.Duration) error {
+	return r.client.Set(r.ctx, r.deletedUserKey(userID), time.Now().Unix(), ttl).Err()
+}
+
+// IsUserDeleted reports whether a user deleted their account
+func (r *RedisCacheRepository) IsUserDeleted(userID string) (bool, error) {
+	count, err := r.client.Exists(r.ctx, r.deletedUserKey(userID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// maintenanceKey is the runtime maintenance switch the event service sets.
+// Both services must share the Redis instance for it to apply here.
+const maintenanceKey = "maintenance:writes"
+
+// MaintenanceMode reports whether writes were switched off at runtime
+func (r *RedisCacheRepository) MaintenanceMode() (bool, error) {
+	count, err := r.client.Exists(r.ctx, maintenanceKey).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// AcquireResendCooldown starts the resend cooldown for a booking. It returns false
+// and the remaining cooldown if a resend already happened within the window.
+func (r *RedisCacheRepository) AcquireResendCooldown(bookingID string, ttl time.Duration) (bool, time.Duration, error) {
+	key := r.resendCooldownKey(bookingID)
+	acquired, err := r.client.SetNX(r.ctx, key, time.Now().Unix(), ttl).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	if acquired {
+		return true, 0, nil
+	}
+
+	remaining, err := r.client.TTL(r.ctx, key).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	return false, remaining, nil
+}
+
+// ClearResendCooldown removes the resend cooldown for a booking
+func (r *RedisCacheRepository) ClearResendCooldown(bookingID string) error {
+	key := r.resendCooldownKey(bookingID)
+	return r.client.Del(r.ctx, key).Err()
+}
+
+// AcquireProcessingLease claims a booking for processing. It returns false if the
+// booking is already being processed or has been processed. The lease expires
+// so a booking whose worker crashed can be picked up again on redelivery.
+func (r *RedisCacheRepository) AcquireProcessingLease(bookingID string, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(r.ctx, r.bookingProcessedKey(bookingID), "processing", ttl).Result()
+}
+
+// MarkBookingProcessed replaces the processing lease with a processed marker
+func (r *RedisCacheRepository) MarkBookingProcessed(bookingID string, ttl time.Duration) error {
+	return r.client.Set(r.ctx, r.bookingProcessedKey(bookingID), "processed", ttl).Err()
+}
+
+// resetProcessedScript deletes a booking's processed marker unless it is a
+// processing lease, whose remaining milliseconds it returns
+var resetProcessedScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == 'processing' then
+	return redis.call('PTTL', KEYS[1])
+end
+redis.call('DEL', KEYS[1])
+return 0
+`)
+
+// ResetBookingProcessed drops the processed marker so the booking can be re-driven
+func (r *RedisCacheRepository) ResetBookingProcessed(bookingID string) (time.Duration, error) {
+	remaining, err := resetProcessedScript.Run(r.ctx, r.client, []string{r.bookingProcessedKey(bookingID)}).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(remaining) * time.Millisecond, nil
+}
+
+// RecordProcessingDuration adds a processing duration to the rolling window,
+// discarding the oldest samples beyond the window size
+func (r *RedisCacheRepository) RecordProcessingDuration(duration time.Duration) error {
+	pipe := r.client.TxPipeline()
+	pipe.LPush(r.ctx, processingDurationsKey, duration.Milliseconds())
+	pipe.LTrim(r.ctx, processingDurationsKey, 0, processingDurationSamples-1)
+	_, err := pipe.Exec(r.ctx)
+	return err
+}
+
+// GetAverageProcessingDuration returns the mean of the rolling window, or zero
+// when no durations have been recorded yet
+func (r *RedisCacheRepository) GetAverageProcessingDuration() (time.Duration, error) {
+	samples, err := r.client.LRange(r.ctx, processingDurationsKey, 0, -1).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	var total, count int64
+	for _, sample := range samples {
+		millis, err := strconv.ParseInt(sample, 10, 64)
+		if err != nil {
+			continue
+		}
+		total += millis
+		count++
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+	return time.Duration(total/count) * time.Millisecond, nil
+}
+
 // Ping checks if Redis is healthy
 func (r *RedisCacheRepository) Ping() error {
 	return r.client.Ping(r.ctx).Err()
@@ -7,18 +7,25 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/arunvm123/eventbooking/booking-service/cache/redis"
 	"github.com/arunvm123/eventbooking/booking-service/config"
+	"github.com/arunvm123/eventbooking/booking-service/messaging"
 	"github.com/arunvm123/eventbooking/booking-service/repository/postgres"
 	"github.com/arunvm123/eventbooking/booking-service/service/http"
 	"github.com/arunvm123/eventbooking/booking-service/worker"
-	"github.com/segmentio/kafka-go"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func main() {
 	fmt.Println("Starting Booking Service Worker")
 
+	// Status updates and webhooks carry database times; keep them in UTC
+	time.Local = time.UTC
+
 	// Initialize configuration
 	// Try to load from config.yaml first, fallback to environment variables
 	cfg, err := config.Initialise("config.yaml", false)
@@ -31,14 +38,26 @@ func main() {
 		}
 	}
 
+	// The API processes bookings itself when they never leave its process
+	if cfg.Queue.Memory() {
+		log.Fatal("The booking worker needs Kafka; with MESSAGE_QUEUE=memory the API runs it in-process")
+	}
+
+	// Dependencies may still be starting, e.g. during ordered Kubernetes startup
+	retry := cfg.Startup.Retry()
+
 	// Initialize repository
-	repo, err := postgres.NewBookingRepository(&cfg.Database)
+	repo, err := postgres.NewBookingRepository(&cfg.Database, retry)
 	if err != nil {
 		log.Fatal("Failed to initialize repository:", err)
 	}
 
 	// Initialize cache
-	cache, err := redis.NewRedisCacheRepository(cfg.Redis.GetRedisURL(), cfg.Redis.Password, cfg.Redis.DB)
+	var cache *redis.RedisCacheRepository
+	err = retry.Connect("redis", func() (err error) {
+		cache, err = redis.NewRedisCacheRepository(&cfg.Redis)
+		return err
+	})
 	if err != nil {
 		log.Fatal("Failed to initialize cache:", err)
 	}
@@ -46,24 +65,55 @@ func main() {
 	// Initialize Event Service client with connection pooling
 	eventService := http.NewHTTPEventServiceWithConfig(&cfg.EventService, cfg.JWTSecret)
 
-	// Initialize Kafka writer for notifications
-	kafkaWriter := &kafka.Writer{
-		Addr:     kafka.TCP(cfg.Kafka.Brokers...),
-		Topic:    cfg.Kafka.NotificationTopic,
-		Balancer: &kafka.LeastBytes{},
+	// Create missing topics on local/dev clusters
+	if cfg.Kafka.AutoCreateTopics {
+		topics := append(cfg.Topics(), cfg.Kafka.DLQTopic, cfg.Kafka.AuditTopic)
+		settings := messaging.TopicSettings{
+			Partitions:        cfg.Kafka.TopicPartitions,
+			ReplicationFactor: cfg.Kafka.TopicReplicationFactor,
+		}
+		err := retry.Connect("kafka", func() error {
+			return messaging.EnsureTopics(cfg.Kafka.Brokers, topics, settings)
+		})
+		if err != nil {
+			log.Fatal("Failed to create Kafka topics:", err)
+		}
+	}
+
+	// Initialize message queue for consuming bookings and publishing
+	// notifications and dead letters
+	mq, err := queue.New(cfg.Queue.Driver, cfg.Kafka.Brokers, cfg.Kafka.StartOffset, cfg.Queue.MemorySize)
+	if err != nil {
+		log.Fatal("Failed to initialize message queue:", err)
 	}
-	defer kafkaWriter.Close()
+	defer mq.Close()
 
-	// Setup Kafka consumer
-	consumer := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: cfg.Kafka.Brokers,
-		Topic:   cfg.Kafka.BookingTopic,
-		GroupID: cfg.Kafka.ConsumerGroup,
-	})
+	// An empty notification topic skips notifications
+	var notificationTopic string
+	if cfg.NotificationsEnabled {
+		notificationTopic = cfg.Kafka.NotificationTopic
+	} else {
+		log.Println("Notifications disabled, booking emails will not be sent")
+	}
+
+	// Setup booking consumer
+	consumer := mq.Consume(cfg.Kafka.BookingTopic, cfg.Kafka.ConsumerGroup)
 	defer consumer.Close()
 
 	// Create booking processor
-	processor := worker.NewBookingProcessor(repo, cache, eventService, kafkaWriter, consumer)
+	// Webhook callbacks to integrators on confirm/fail
+	webhooks := worker.NewWebhookDispatcher(repo, cfg.Webhook.Secret, cfg.Webhook.MaxAttempts,
+		time.Duration(cfg.Webhook.TimeoutSeconds)*time.Second, cfg.Webhook.AllowPrivateTargets)
+	defer webhooks.Shutdown()
+	webhooks.ResumePending()
+
+	// Undecodable booking requests are parked on the DLQ topic for inspection
+	// Refunds are published to the audit trail
+	auditor := audit.NewRecorder("booking-service", mq, cfg.Kafka.AuditTopic)
+	defer auditor.Close()
+
+	processor := worker.NewBookingProcessor(repo, cache, eventService, mq, consumer,
+		notificationTopic, cfg.Kafka.DLQTopic, webhooks, auditor, cfg.EventService.MaxRetries)
 
 	// Graceful shutdown context
 	ctx, cancel := context.WithCancel(context.Background())
@@ -79,6 +129,31 @@ func main() {
 		cancel()
 	}()
 
+	// Register worker metrics
+	if err := processor.RegisterMetrics(prometheus.DefaultRegisterer); err != nil {
+		log.Fatal("Failed to register worker metrics:", err)
+	}
+
+	// Start health and metrics server alongside the consumer
+	healthServer := worker.NewHealthServer(cfg.Worker.HealthPort, map[string]worker.HealthCheck{
+		"database": func(ctx context.Context) error {
+			sqlDB, err := repo.GetDB().DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+		"redis": func(ctx context.Context) error {
+			return cache.Ping()
+		},
+		"kafka": worker.KafkaHealthCheck(cfg.Kafka.Brokers),
+	})
+	go func() {
+		if err := healthServer.Run(ctx); err != nil {
+			log.Printf("Worker health server error: %v", err)
+		}
+	}()
+
 	// Start worker
 	fmt.Println("Booking processor worker started")
 	if err := processor.Start(ctx); err != nil && err != context.Canceled {
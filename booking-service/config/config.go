@@ -3,22 +3,138 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/shared/pagination"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/arunvm123/eventbooking/shared/startup"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	Port         string       `yaml:"port" env:"PORT" env-default:"8083"`
-	JWTSecret    string       `yaml:"jwt_secret" env:"JWT_SECRET" env-required:"true"`
+	Port      string `yaml:"port" env:"PORT" env-default:"8083"`
+	JWTSecret string `yaml:"jwt_secret" env:"JWT_SECRET" env-required:"true"`
+
+	// Prefix for all API routes; the legacy /api prefix is kept as an alias
+	APIBasePath string `yaml:"api_base_path" env:"API_BASE_PATH" env-default:"/api/v1"`
+
+	// Publish notification emails to the notification topic. Disable for
+	// deployments and tests that run without the notification pipeline.
+	NotificationsEnabled bool `yaml:"notifications_enabled" env:"NOTIFICATIONS_ENABLED" env-default:"true"`
+
 	Database     Database     `yaml:"database"`
 	Redis        Redis        `yaml:"redis"`
 	Kafka        Kafka        `yaml:"kafka"`
+	Queue        Queue        `yaml:"queue"`
 	EventService EventService `yaml:"event_service"`
 	Worker       Worker       `yaml:"worker"`
+	Booking      Booking      `yaml:"booking"`
+	Webhook      Webhook      `yaml:"webhook"`
+	Stream       Stream       `yaml:"stream"`
+	Startup      Startup      `yaml:"startup"`
+
+	// Reject new bookings and other writes with 503. Maintenance can also be
+	// switched on at runtime through the event service's admin API.
+	MaintenanceMode bool `yaml:"maintenance_mode" env:"MAINTENANCE_MODE" env-default:"false"`
+}
+
+// Startup bounds how long the API and worker wait at boot for Postgres, Redis
+// and, when creating topics, Kafka to accept connections before exiting
+type Startup struct {
+	ConnectAttempts int `yaml:"connect_attempts" env:"STARTUP_CONNECT_ATTEMPTS" env-default:"10"`
+	// Wait after the first failed attempt, doubling per attempt up to the max
+	ConnectBackoffMillis    int `yaml:"connect_backoff_millis" env:"STARTUP_CONNECT_BACKOFF_MILLIS" env-default:"1000"`
+	ConnectMaxBackoffMillis int `yaml:"connect_max_backoff_millis" env:"STARTUP_CONNECT_MAX_BACKOFF_MILLIS" env-default:"15000"`
+}
+
+// Retry returns the connection budget for the shared startup helper
+func (s *Startup) Retry() startup.Retry {
+	return startup.Retry{
+		Attempts:   s.ConnectAttempts,
+		Backoff:    time.Duration(s.ConnectBackoffMillis) * time.Millisecond,
+		MaxBackoff: time.Duration(s.ConnectMaxBackoffMillis) * time.Millisecond,
+	}
+}
+
+// Stream limits concurrent status streams so connection floods can't exhaust
+// goroutines and database polling
+type Stream struct {
+	// Maximum open SSE connections per instance; 0 disables the limit
+	MaxConnections int `yaml:"max_connections" env:"STREAM_MAX_CONNECTIONS" env-default:"1000"`
+
+	// Retry-After sent to clients turned away at the cap
+	RetryAfterSeconds int `yaml:"retry_after_seconds" env:"STREAM_RETRY_AFTER_SECONDS" env-default:"5"`
+
+	// How often open streams re-read the booking for status changes
+	PollIntervalMillis int `yaml:"poll_interval_millis" env:"STREAM_POLL_INTERVAL_MILLIS" env-default:"2000"`
+}
+
+// Webhook configures callbacks to integrators when bookings finish processing
+type Webhook struct {
+	// Shared secret used to sign callback payloads; callback_url is rejected when empty
+	Secret string `yaml:"secret" env:"WEBHOOK_SECRET"`
+
+	// Only accept https callback URLs. Disable for local development only.
+	RequireHTTPS bool `yaml:"require_https" env:"WEBHOOK_REQUIRE_HTTPS" env-default:"true"`
+
+	// Allow callbacks to loopback, private and link-local addresses. Enable for
+	// local development only, since callback URLs come from API callers.
+	AllowPrivateTargets bool `yaml:"allow_private_targets" env:"WEBHOOK_ALLOW_PRIVATE_TARGETS" env-default:"false"`
+
+	// Delivery attempts per booking, with exponential backoff between them
+	MaxAttempts    int `yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" env-default:"5"`
+	TimeoutSeconds int `yaml:"timeout_seconds" env:"WEBHOOK_TIMEOUT_SECONDS" env-default:"10"`
+}
+
+// Enabled reports whether a signing secret has been configured
+func (w *Webhook) Enabled() bool {
+	return w.Secret != ""
+}
+
+type Booking struct {
+	// Minimum time between confirmation email resends for the same booking
+	ResendCooldownSeconds int `yaml:"resend_cooldown_seconds" env:"BOOKING_RESEND_COOLDOWN_SECONDS" env-default:"300"`
+
+	// Maximum number of booking IDs accepted by the batch status endpoint
+	MaxStatusBatchSize int `yaml:"max_status_batch_size" env:"BOOKING_MAX_STATUS_BATCH_SIZE" env-default:"50"`
+
+	// Completion estimate used until enough bookings have been processed to compute one
+	DefaultEstimatedSeconds int `yaml:"default_estimated_seconds" env:"BOOKING_DEFAULT_ESTIMATED_SECONDS" env-default:"150"`
+
+	// Outbox relay tuning for publishing booking requests to Kafka
+	OutboxPollIntervalMillis int `yaml:"outbox_poll_interval_millis" env:"BOOKING_OUTBOX_POLL_INTERVAL_MILLIS" env-default:"1000"`
+	OutboxBatchSize          int `yaml:"outbox_batch_size" env:"BOOKING_OUTBOX_BATCH_SIZE" env-default:"100"`
+
+	// Allowed difference in minor units between the submitted payment and the hold total
+	PriceToleranceCents int64 `yaml:"price_tolerance_cents" env:"BOOKING_PRICE_TOLERANCE_CENTS" env-default:"1"`
+
+	// Service fee added to the seat prices: a flat amount per seat in minor
+	// units plus a rate in basis points (250 = 2.5%) of the seat prices
+	FeePerSeatCents    int64 `yaml:"fee_per_seat_cents" env:"BOOKING_FEE_PER_SEAT_CENTS" env-default:"0"`
+	FeeRateBasisPoints int64 `yaml:"fee_rate_basis_points" env:"BOOKING_FEE_RATE_BPS" env-default:"0"`
+
+	// Tax rate in basis points, charged on the seat prices plus fees
+	TaxRateBasisPoints int64 `yaml:"tax_rate_basis_points" env:"BOOKING_TAX_RATE_BPS" env-default:"0"`
+
+	// How long a user's booking counts are cached for the count endpoint
+	CountCacheSeconds int `yaml:"count_cache_seconds" env:"BOOKING_COUNT_CACHE_SECONDS" env-default:"30"`
+
+	// Page sizes for the bookings list endpoint
+	DefaultPageSize int `yaml:"default_page_size" env:"DEFAULT_PAGE_SIZE" env-default:"50"`
+	MaxPageSize     int `yaml:"max_page_size" env:"MAX_PAGE_SIZE" env-default:"100"`
+}
+
+// PageLimits returns the bookings list page sizes for the shared pagination helper
+func (b *Booking) PageLimits() pagination.Limits {
+	return pagination.Limits{Default: b.DefaultPageSize, Max: b.MaxPageSize}
 }
 
 type Worker struct {
 	MaxWorkers int `yaml:"max_workers" env:"WORKER_MAX_WORKERS" env-default:"20"`
+
+	// Port for the worker's /livez, /readyz and /metrics endpoints
+	HealthPort string `yaml:"health_port" env:"WORKER_HEALTH_PORT" env-default:"9083"`
 }
 
 type Database struct {
@@ -35,8 +151,9 @@ type Database struct {
 	ConnMaxLifetime int `yaml:"conn_max_lifetime_minutes" env:"DB_CONN_MAX_LIFETIME" env-default:"30"`
 }
 
+// GetDatabaseURL constructs the PostgreSQL connection string with sessions in UTC
 func (d *Database) GetDatabaseURL() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s&TimeZone=UTC",
 		d.User, d.Password, d.Host, d.Port, d.DatabaseName, d.SSLMode)
 }
 
@@ -45,21 +162,70 @@ type Redis struct {
 	Port     string `yaml:"port" env:"REDIS_PORT" env-default:"6379"`
 	Password string `yaml:"password" env:"REDIS_PASSWORD" env-default:""`
 	DB       int    `yaml:"db" env:"REDIS_DB" env-default:"0"`
+
+	// Connection pool and timeouts, so a struggling Redis fails fast instead
+	// of stalling request handlers
+	PoolSize           int `yaml:"pool_size" env:"REDIS_POOL_SIZE" env-default:"20"`
+	DialTimeoutMillis  int `yaml:"dial_timeout_millis" env:"REDIS_DIAL_TIMEOUT_MILLIS" env-default:"2000"`
+	ReadTimeoutMillis  int `yaml:"read_timeout_millis" env:"REDIS_READ_TIMEOUT_MILLIS" env-default:"500"`
+	WriteTimeoutMillis int `yaml:"write_timeout_millis" env:"REDIS_WRITE_TIMEOUT_MILLIS" env-default:"500"`
+	// Retries per command after a network error; -1 disables retries
+	MaxRetries int `yaml:"max_retries" env:"REDIS_MAX_RETRIES" env-default:"2"`
 }
 
 func (r *Redis) GetRedisURL() string {
 	return fmt.Sprintf("%s:%s", r.Host, r.Port)
 }
 
+// Topics returns the topics the booking service writes to
+func (c *Config) Topics() []string {
+	if !c.NotificationsEnabled {
+		return []string{c.Kafka.BookingTopic}
+	}
+	return []string{c.Kafka.BookingTopic, c.Kafka.NotificationTopic}
+}
+
 type Kafka struct {
 	Brokers           []string `yaml:"brokers" env:"KAFKA_BROKERS" env-default:"localhost:9092" env-separator:","`
 	BookingTopic      string   `yaml:"booking_topic" env:"KAFKA_BOOKING_TOPIC" env-default:"booking-requests"`
 	NotificationTopic string   `yaml:"notification_topic" env:"KAFKA_NOTIFICATION_TOPIC" env-default:"notification-requests"`
 	ConsumerGroup     string   `yaml:"consumer_group" env:"KAFKA_CONSUMER_GROUP" env-default:"booking-service"`
+
+	// Where a consumer group with no committed offsets starts: "earliest" so a
+	// fresh deploy or new group processes bookings already queued, or "latest"
+	StartOffset string `yaml:"start_offset" env:"KAFKA_START_OFFSET" env-default:"earliest"`
+
+	// Booking requests the worker can't decode are published here
+	DLQTopic string `yaml:"dlq_topic" env:"KAFKA_BOOKING_DLQ_TOPIC" env-default:"booking-requests-dlq"`
+
+	// Refunds are published here for the audit trail
+	AuditTopic string `yaml:"audit_topic" env:"AUDIT_TOPIC" env-default:"audit-events"`
+
+	// Dev-only: create missing topics at startup. Production topics are provisioned separately.
+	AutoCreateTopics       bool `yaml:"auto_create_topics" env:"KAFKA_AUTO_CREATE_TOPICS" env-default:"false"`
+	TopicPartitions        int  `yaml:"topic_partitions" env:"KAFKA_TOPIC_PARTITIONS" env-default:"3"`
+	TopicReplicationFactor int  `yaml:"topic_replication_factor" env:"KAFKA_TOPIC_REPLICATION_FACTOR" env-default:"1"`
+}
+
+// Queue selects the message queue carrying booking and notification messages
+type Queue struct {
+	// "kafka", or "memory" to run without a broker. In memory mode the API runs
+	// the booking worker in-process, since messages can't leave the process.
+	Driver string `yaml:"driver" env:"MESSAGE_QUEUE" env-default:"kafka"`
+
+	// Messages buffered per topic by the in-memory queue
+	MemorySize int `yaml:"memory_size" env:"MEMORY_QUEUE_SIZE" env-default:"1000"`
+}
+
+// Memory reports whether messages stay in-process instead of going through Kafka
+func (q *Queue) Memory() bool {
+	return q.Driver == queue.DriverMemory
 }
 
 type EventService struct {
 	BaseURL string `yaml:"base_url" env:"EVENT_SERVICE_URL" env-default:"http://event-service:8082"`
+	// API prefix of the event service, appended to BaseURL
+	APIBasePath string `yaml:"api_base_path" env:"EVENT_SERVICE_API_BASE_PATH" env-default:"/api/v1"`
 
 	// HTTP Connection Pool Settings
 	MaxIdleConns        int `yaml:"max_idle_conns" env:"HTTP_MAX_IDLE_CONNS" env-default:"20"`
@@ -67,9 +233,84 @@ type EventService struct {
 	MaxConnsPerHost     int `yaml:"max_conns_per_host" env:"HTTP_MAX_CONNS_PER_HOST" env-default:"20"`
 	IdleConnTimeout     int `yaml:"idle_conn_timeout_seconds" env:"HTTP_IDLE_CONN_TIMEOUT" env-default:"90"`
 	RequestTimeout      int `yaml:"request_timeout_seconds" env:"HTTP_REQUEST_TIMEOUT" env-default:"30"`
+
+	// Backoff when event-service throttles a request without a Retry-After header
+	RetryBackoffSeconds int `yaml:"retry_backoff_seconds" env:"EVENT_SERVICE_RETRY_BACKOFF_SECONDS" env-default:"1"`
+	// Number of retries the worker makes after a throttled response
+	MaxRetries int `yaml:"max_retries" env:"EVENT_SERVICE_MAX_RETRIES" env-default:"3"`
+	// Retries of read-only requests that failed with a network or server error
+	GetRetries int `yaml:"get_retries" env:"EVENT_SERVICE_GET_RETRIES" env-default:"2"`
 }
 
 func Initialise(configPath string, useEnv bool) (*Config, error) {
+	cfg, err := load(configPath, useEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// validate checks settings that depend on each other
+func (c *Config) validate() error {
+	if c.Booking.DefaultPageSize > c.Booking.MaxPageSize {
+		return fmt.Errorf("default page size %d exceeds max page size %d",
+			c.Booking.DefaultPageSize, c.Booking.MaxPageSize)
+	}
+	if c.Webhook.MaxAttempts < 1 {
+		return fmt.Errorf("webhook max attempts must be at least 1, got %d", c.Webhook.MaxAttempts)
+	}
+
+	if c.Booking.PriceToleranceCents < 0 {
+		return fmt.Errorf("price tolerance must not be negative, got %d", c.Booking.PriceToleranceCents)
+	}
+	if c.Booking.FeePerSeatCents < 0 {
+		return fmt.Errorf("fee per seat must not be negative, got %d", c.Booking.FeePerSeatCents)
+	}
+	if c.Booking.FeeRateBasisPoints < 0 || c.Booking.FeeRateBasisPoints > 10000 {
+		return fmt.Errorf("fee rate must be between 0 and 10000 basis points, got %d", c.Booking.FeeRateBasisPoints)
+	}
+	if c.Booking.TaxRateBasisPoints < 0 || c.Booking.TaxRateBasisPoints > 10000 {
+		return fmt.Errorf("tax rate must be between 0 and 10000 basis points, got %d", c.Booking.TaxRateBasisPoints)
+	}
+	if c.Booking.CountCacheSeconds < 0 {
+		return fmt.Errorf("booking count cache duration must not be negative, got %ds", c.Booking.CountCacheSeconds)
+	}
+	if c.EventService.GetRetries < 0 {
+		return fmt.Errorf("event service GET retries must not be negative, got %d", c.EventService.GetRetries)
+	}
+	if c.Stream.PollIntervalMillis < 1 {
+		return fmt.Errorf("stream poll interval must be positive, got %dms", c.Stream.PollIntervalMillis)
+	}
+	if c.Redis.PoolSize < 1 {
+		return fmt.Errorf("redis pool size must be at least 1, got %d", c.Redis.PoolSize)
+	}
+	if c.Redis.DialTimeoutMillis < 1 || c.Redis.ReadTimeoutMillis < 1 || c.Redis.WriteTimeoutMillis < 1 {
+		return fmt.Errorf("redis timeouts must be positive")
+	}
+
+	if c.Startup.ConnectAttempts < 1 {
+		return fmt.Errorf("startup connect attempts must be at least 1, got %d", c.Startup.ConnectAttempts)
+	}
+	if c.Startup.ConnectBackoffMillis < 0 || c.Startup.ConnectMaxBackoffMillis < 0 {
+		return fmt.Errorf("startup connect backoff must not be negative")
+	}
+
+	var err error
+	if c.APIBasePath, err = middleware.NormalizeBasePath(c.APIBasePath); err != nil {
+		return fmt.Errorf("invalid API base path: %w", err)
+	}
+	if c.EventService.APIBasePath, err = middleware.NormalizeBasePath(c.EventService.APIBasePath); err != nil {
+		return fmt.Errorf("invalid event service API base path: %w", err)
+	}
+	return nil
+}
+
+func load(configPath string, useEnv bool) (*Config, error) {
 	cfg := &Config{}
 
 	if useEnv {
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+
+	"github.com/arunvm123/eventbooking/booking-service/model"
+	"github.com/arunvm123/eventbooking/booking-service/repository"
+	"github.com/arunvm123/eventbooking/booking-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// fakeBookingRepository keeps bookings in memory. Methods a test doesn't set
+// up panic through the embedded nil interface.
+type fakeBookingRepository struct {
+	repository.BookingRepository
+	bookings map[string]*model.Booking
+	reads    int
+
+	// getErr, when set, is returned by every booking lookup
+	getErr error
+}
+
+func newFakeBookingRepository(bookings ...*model.Booking) *fakeBookingRepository {
+	repo := &fakeBookingRepository{bookings: make(map[string]*model.Booking)}
+	for _, booking := range bookings {
+		repo.bookings[booking.ID] = booking
+	}
+	return repo
+}
+
+func (r *fakeBookingRepository) GetBookingByID(id string) (*model.Booking, error) {
+	r.reads++
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	booking, ok := r.bookings[id]
+	if !ok {
+		return nil, repository.ErrBookingNotFound
+	}
+	return booking, nil
+}
+
+func (r *fakeBookingRepository) GetBookingByHoldID(holdID string) (*model.Booking, error) {
+	for _, booking := range r.bookings {
+		if booking.HoldID == holdID {
+			return booking, nil
+		}
+	}
+	return nil, repository.ErrBookingNotFound
+}
+
+// fakeEventService answers hold lookups with fixed details
+type fakeEventService struct {
+	service.EventService
+	holds map[string]*service.HoldDetails
+	err   error
+}
+
+func (s *fakeEventService) GetHoldDetails(holdID, userID, userEmail string) (*service.HoldDetails, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	hold, ok := s.holds[holdID]
+	if !ok {
+		return nil, service.ErrHoldNotFound
+	}
+	return hold, nil
+}
+
+// serve runs one request through handler on route, as the given user
+func serve(route, method, target, userID, body string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Handle(method, route, func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("user_email", userID+"@example.com")
+	}, handler)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
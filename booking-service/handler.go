@@ -2,32 +2,53 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/arunvm123/eventbooking/booking-service/cache"
+	"github.com/arunvm123/eventbooking/booking-service/config"
+	"github.com/arunvm123/eventbooking/booking-service/messaging"
 	"github.com/arunvm123/eventbooking/booking-service/model"
 	"github.com/arunvm123/eventbooking/booking-service/repository"
 	"github.com/arunvm123/eventbooking/booking-service/service"
+	"github.com/arunvm123/eventbooking/booking-service/worker"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/shared/pagination"
+	"github.com/arunvm123/eventbooking/shared/queue"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
 )
 
 type BookingHandler struct {
+	cfg          *config.Config
 	repo         repository.BookingRepository
 	cache        cache.CacheRepository
-	kafkaWriter  *kafka.Writer
+	outbox       *messaging.OutboxRelay
+	queue        queue.MessageQueue
 	eventService service.EventService
+	audit        *audit.Recorder
 }
 
-func NewBookingHandler(repo repository.BookingRepository, cache cache.CacheRepository, kafkaWriter *kafka.Writer, eventService service.EventService) *BookingHandler {
+func NewBookingHandler(cfg *config.Config, repo repository.BookingRepository, cache cache.CacheRepository, outbox *messaging.OutboxRelay, queue queue.MessageQueue, eventService service.EventService, auditor *audit.Recorder) *BookingHandler {
 	return &BookingHandler{
+		cfg:          cfg,
 		repo:         repo,
 		cache:        cache,
-		kafkaWriter:  kafkaWriter,
+		outbox:       outbox,
+		queue:        queue,
 		eventService: eventService,
+		audit:        auditor,
 	}
 }
 
@@ -35,46 +56,65 @@ func NewBookingHandler(repo repository.BookingRepository, cache cache.CacheRepos
 func (h *BookingHandler) SubmitBooking(c *gin.Context) {
 	var req model.SubmitBookingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{
-			Error:   "validation_failed",
-			Message: err.Error(),
-		})
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	paymentAmount := req.PaymentInfo.Money()
+	if !model.IsSupportedCurrency(paymentAmount.Currency) {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed",
+			fmt.Sprintf("payment_info.currency must be one of %s", strings.Join(model.SupportedCurrencies, ", ")))
 		return
 	}
 
+	if req.CallbackURL != "" {
+		if err := h.validateCallbackURL(req.CallbackURL); err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "invalid_callback_url", err.Error())
+			return
+		}
+	}
+
 	// Get user info from context
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User ID not found in token",
-		})
+		middleware.RespondError(c, http.StatusUnauthorized, "unauthorized", "User ID not found in token")
 		return
 	}
 
 	userUUID, ok := userID.(string)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Invalid user ID format",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Invalid user ID format")
 		return
 	}
 
 	userEmail, _ := c.Get("user_email")
 	userEmailStr, _ := userEmail.(string)
+	userLocale := c.GetString(middleware.ContextUserLocale)
+
+	// Guest tokens are only good for the hold they were issued with
+	if c.GetString(middleware.ContextUserRole) == auth.RoleGuest && c.GetString(middleware.ContextGuestHoldID) != req.HoldID {
+		middleware.RespondError(c, http.StatusForbidden, "hold_not_owned", "Guest token is not valid for this hold")
+		return
+	}
 
 	// Check if booking already exists for this hold
 	existingBooking, err := h.repo.GetBookingByHoldID(req.HoldID)
 	if err == nil && existingBooking != nil {
+		// Don't reveal another user's booking to someone replaying their hold ID
+		if existingBooking.UserID != userUUID {
+			middleware.RespondError(c, http.StatusForbidden, "hold_not_owned", "Hold belongs to another user")
+			return
+		}
+
 		// Return existing booking
 		response := model.BookingResponse{
-			BookingID:     existingBooking.ID,
-			Status:        existingBooking.Status,
-			Message:       "Booking already exists for this hold",
-			EstimatedTime: "Already processed",
-			StatusURL:     fmt.Sprintf("/api/booking/%s/status", existingBooking.ID),
-			StreamURL:     fmt.Sprintf("/api/booking/%s/stream", existingBooking.ID),
+			BookingID:        existingBooking.ID,
+			ConfirmationCode: existingBooking.ConfirmationCode,
+			Status:           existingBooking.Status,
+			Message:          "Booking already exists for this hold",
+			EstimatedTime:    "Already processed",
+			StatusURL:        fmt.Sprintf("%s/booking/%s/status", h.cfg.APIBasePath, existingBooking.ID),
+			StreamURL:        fmt.Sprintf("%s/booking/%s/stream", h.cfg.APIBasePath, existingBooking.ID),
 		}
 		c.JSON(http.StatusAccepted, response)
 		return
@@ -83,116 +123,318 @@ func (h *BookingHandler) SubmitBooking(c *gin.Context) {
 	// Get hold details from event service (pass user context)
 	holdDetails, err := h.eventService.GetHoldDetails(req.HoldID, userUUID, userEmailStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{
-			Error:   "invalid_hold",
-			Message: "Failed to validate hold: " + err.Error(),
-		})
+		// Pass event-service backpressure on to the client instead of blaming the hold
+		var throttled *service.ThrottledError
+		if errors.As(err, &throttled) {
+			c.Header("Retry-After", retryAfterSeconds(throttled.RetryAfter))
+			middleware.RespondError(c, http.StatusServiceUnavailable, "event_service_unavailable", "Event service is busy, please retry later")
+			return
+		}
+		middleware.RespondError(c, http.StatusBadRequest, "invalid_hold", "Failed to validate hold: "+err.Error())
 		return
 	}
 
-	// Parse event date
+	// Only the user who placed the hold may book it
+	if holdDetails.UserID != userUUID {
+		middleware.RespondError(c, http.StatusForbidden, "hold_not_owned", "Hold belongs to another user")
+		return
+	}
+
+	// Recompute the authoritative total from the hold rather than trusting the client
+	breakdown := h.priceBreakdown(holdDetails)
+	expectedAmount := breakdown.Total
+	if paymentAmount.Currency != expectedAmount.Currency {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "currency_mismatch",
+			fmt.Sprintf("Payment currency %s does not match the event currency %s", paymentAmount.Currency, expectedAmount.Currency),
+			model.CurrencyMismatchDetails{
+				ExpectedCurrency:  expectedAmount.Currency,
+				SubmittedCurrency: paymentAmount.Currency,
+			})
+		return
+	}
+	if !h.paymentMatches(paymentAmount, expectedAmount) {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "amount_mismatch",
+			fmt.Sprintf("Payment amount %s does not match the booking total %s", paymentAmount, expectedAmount),
+			model.AmountMismatchDetails{
+				ExpectedAmount:  expectedAmount.Decimal(),
+				SubmittedAmount: paymentAmount.Decimal(),
+				Currency:        expectedAmount.Currency,
+				Breakdown:       breakdown.ToPriceBreakdown(),
+			})
+		return
+	}
+
+	// Parse event date. A malformed date is the event service's fault, not the client's.
 	eventDate, err := time.Parse(time.RFC3339, holdDetails.EventDate)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Invalid event date format",
-		})
+		log.Printf("Event service returned malformed event_date %q for hold %s: %v", holdDetails.EventDate, req.HoldID, err)
+		middleware.RespondError(c, http.StatusBadGateway, "invalid_upstream_response", "Event service returned an invalid event date")
 		return
 	}
+	eventDate = eventDate.UTC()
 
 	// Create booking record with processing status
 	createReq := model.CreateBookingRequest{
 		UserID:        userUUID,
 		UserEmail:     userEmailStr,
 		UserName:      holdDetails.UserName,
+		Locale:        userLocale,
 		EventID:       holdDetails.EventID,
 		EventName:     holdDetails.EventName,
 		Venue:         holdDetails.Venue,
 		EventDate:     eventDate,
 		Seats:         holdDetails.Seats,
-		TotalAmount:   req.PaymentInfo.Amount,
+		Amount:        breakdown,
 		HoldID:        req.HoldID,
 		PaymentMethod: req.PaymentInfo.PaymentMethod,
+		CallbackURL:   req.CallbackURL,
 	}
 
-	booking, err := h.repo.CreateBooking(createReq)
+	// Record the booking and its Kafka message together; the outbox relay publishes it
+	booking, err := h.repo.CreateBookingWithOutbox(createReq, func(booking *model.Booking) (*model.OutboxMessage, error) {
+		msgBytes, err := json.Marshal(booking.ToBookingRequest())
+		if err != nil {
+			return nil, err
+		}
+		return &model.OutboxMessage{
+			Topic:   h.cfg.Kafka.BookingTopic,
+			Key:     booking.ID,
+			Payload: msgBytes,
+		}, nil
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create booking",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to create booking")
 		return
 	}
-
-	// Send to Kafka for async processing
-	kafkaMsg := model.BookingRequest{
-		BookingID:   booking.ID,
-		UserID:      userUUID,
-		UserEmail:   userEmailStr,
-		UserName:    holdDetails.UserName,
-		HoldID:      req.HoldID,
-		EventID:     holdDetails.EventID,
-		EventName:   holdDetails.EventName,
-		Venue:       holdDetails.Venue,
-		EventDate:   eventDate,
-		Seats:       holdDetails.Seats,
-		PaymentInfo: req.PaymentInfo,
-		Timestamp:   time.Now(),
-	}
-
-	msgBytes, _ := json.Marshal(kafkaMsg)
-	h.kafkaWriter.WriteMessages(c.Request.Context(),
-		kafka.Message{
-			Key:   []byte(booking.ID),
-			Value: msgBytes,
-		})
+	h.outbox.Notify()
 
 	// Cache initial status
 	statusUpdate := &model.BookingStatusUpdate{
-		BookingID: booking.ID,
-		Status:    "PROCESSING",
-		Message:   "Booking submitted for processing",
-		UpdatedAt: time.Now(),
+		BookingID:     booking.ID,
+		UserID:        userUUID,
+		Status:        model.BookingStatusProcessing,
+		PaymentStatus: model.PaymentStatusPending,
+		Message:       "Booking submitted for processing",
+		UpdatedAt:     time.Now().UTC(),
 	}
 	h.cache.SetBookingStatus(booking.ID, statusUpdate, 24*time.Hour)
+	h.cache.InvalidateBookingCounts(userUUID)
 
 	// Return immediate response
+	estimate := h.estimatedProcessingDuration()
 	response := model.BookingResponse{
-		BookingID:     booking.ID,
-		Status:        "PROCESSING",
-		Message:       "Booking is being processed",
-		EstimatedTime: "2-3 minutes",
-		StatusURL:     fmt.Sprintf("/api/booking/%s/status", booking.ID),
-		StreamURL:     fmt.Sprintf("/api/booking/%s/stream", booking.ID),
+		BookingID:        booking.ID,
+		ConfirmationCode: booking.ConfirmationCode,
+		Status:           model.BookingStatusProcessing,
+		Message:          "Booking is being processed",
+		EstimatedTime:    formatEstimate(estimate),
+		EstimatedSeconds: int(estimate.Seconds()),
+		StatusURL:        fmt.Sprintf("%s/booking/%s/status", h.cfg.APIBasePath, booking.ID),
+		StreamURL:        fmt.Sprintf("%s/booking/%s/stream", h.cfg.APIBasePath, booking.ID),
 	}
 
 	c.JSON(http.StatusAccepted, response)
 }
 
+// estimatedProcessingDuration returns the rolling average processing time
+// recorded by the worker, or the configured default when none is available
+func (h *BookingHandler) estimatedProcessingDuration() time.Duration {
+	average, err := h.cache.GetAverageProcessingDuration()
+	if err != nil || average <= 0 {
+		return time.Duration(h.cfg.Booking.DefaultEstimatedSeconds) * time.Second
+	}
+	return average
+}
+
+// setEstimatedCompletion fills in when a still-processing booking is expected to finish
+func (h *BookingHandler) setEstimatedCompletion(response *model.BookingStatusResponse, booking *model.Booking) {
+	if booking.Status != model.BookingStatusProcessing {
+		return
+	}
+	estimatedAt := booking.CreatedAt.Add(h.estimatedProcessingDuration())
+	response.EstimatedCompletion = &estimatedAt
+}
+
+// formatEstimate renders a wait time for display, e.g. "about 45 seconds" or "about 3 minutes"
+func formatEstimate(d time.Duration) string {
+	if d < time.Minute {
+		seconds := int(d.Round(time.Second).Seconds())
+		if seconds < 1 {
+			seconds = 1
+		}
+		return fmt.Sprintf("about %d seconds", seconds)
+	}
+	minutes := int(math.Ceil(d.Minutes()))
+	if minutes == 1 {
+		return "about 1 minute"
+	}
+	return fmt.Sprintf("about %d minutes", minutes)
+}
+
+// holdTotal returns the price of a hold as computed by the event service
+func holdTotal(hold *service.HoldDetails) model.Money {
+	amount := hold.TotalPriceCents
+	if amount == 0 {
+		amount = model.ToMinorUnits(hold.TotalPrice)
+	}
+	return model.Money{Amount: amount, Currency: model.NormalizeCurrency(hold.Currency)}
+}
+
+// priceBreakdown adds the configured fees and tax to the hold's seat prices
+func (h *BookingHandler) priceBreakdown(hold *service.HoldDetails) model.AmountBreakdown {
+	rules := model.PriceRules{
+		FeePerSeatCents:    h.cfg.Booking.FeePerSeatCents,
+		FeeRateBasisPoints: h.cfg.Booking.FeeRateBasisPoints,
+		TaxRateBasisPoints: h.cfg.Booking.TaxRateBasisPoints,
+	}
+	return rules.Apply(holdTotal(hold), len(hold.Seats))
+}
+
+// retryAfterSeconds formats a Retry-After delay in whole seconds, rounded up
+// so clients never come back before it has passed
+func retryAfterSeconds(delay time.Duration) string {
+	return strconv.Itoa(max(1, int(math.Ceil(delay.Seconds()))))
+}
+
+// paymentMatches reports whether the submitted payment covers the expected total
+// within the configured tolerance. Both amounts must be in the same currency.
+func (h *BookingHandler) paymentMatches(submitted, expected model.Money) bool {
+	diff := submitted.Amount - expected.Amount
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= h.cfg.Booking.PriceToleranceCents
+}
+
+// validateCallbackURL checks that a webhook URL is absolute and, unless disabled
+// for development, uses https. Callbacks are refused when no signing secret is set.
+func (h *BookingHandler) validateCallbackURL(raw string) error {
+	if !h.cfg.Webhook.Enabled() {
+		return errors.New("callback_url is not supported by this deployment")
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return errors.New("callback_url must be an absolute URL")
+	}
+
+	// Hostnames are checked again once resolved, when delivering
+	if ip := net.ParseIP(parsed.Hostname()); ip != nil && !h.cfg.Webhook.AllowPrivateTargets && worker.IsPrivateAddress(ip) {
+		return errors.New("callback_url must be a public address")
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if !h.cfg.Webhook.RequireHTTPS {
+			return nil
+		}
+	}
+	return errors.New("callback_url must use https")
+}
+
 // GetBookingStatus returns the current status of a booking
 func (h *BookingHandler) GetBookingStatus(c *gin.Context) {
 	bookingIDStr := c.Param("bookingId")
 
 	booking, err := h.repo.GetBookingByID(bookingIDStr)
 	if err != nil {
-		if err.Error() == "booking not found" {
-			c.JSON(http.StatusNotFound, model.ErrorResponse{
-				Error:   "not_found",
-				Message: "Booking not found",
-			})
+		if errors.Is(err, repository.ErrBookingNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Booking not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to retrieve booking",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve booking")
 		return
 	}
 
 	response := booking.ToBookingStatusResponse()
+	h.setEstimatedCompletion(response, booking)
 	c.JSON(http.StatusOK, response)
 }
 
+// GetBookingByCode looks up a booking by its human-friendly confirmation code.
+// Only the booking's owner or an admin may view it; anyone else gets a 404 so
+// codes can't be probed for existence.
+func (h *BookingHandler) GetBookingByCode(c *gin.Context) {
+	code := model.NormalizeConfirmationCode(c.Param("code"))
+	if len(code) != model.ConfirmationCodeLength {
+		middleware.RespondError(c, http.StatusBadRequest, "invalid_confirmation_code",
+			fmt.Sprintf("Confirmation code must be %d characters", model.ConfirmationCodeLength))
+		return
+	}
+
+	booking, err := h.repo.GetBookingByConfirmationCode(code)
+	if err != nil {
+		if errors.Is(err, repository.ErrBookingNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Booking not found")
+			return
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve booking")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if booking.UserID != userID && !isAdmin(c) {
+		middleware.RespondError(c, http.StatusNotFound, "not_found", "Booking not found")
+		return
+	}
+
+	response := booking.ToBookingStatusResponse()
+	h.setEstimatedCompletion(response, booking)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBookingStatuses returns the status of several bookings owned by the user,
+// preferring the status cache and falling back to the database for misses
+func (h *BookingHandler) GetBookingStatuses(c *gin.Context) {
+	var req model.BatchBookingStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	if len(req.BookingIDs) > h.cfg.Booking.MaxStatusBatchSize {
+		middleware.RespondError(c, http.StatusBadRequest, "batch_too_large",
+			fmt.Sprintf("At most %d booking IDs can be requested at once", h.cfg.Booking.MaxStatusBatchSize))
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userUUID, _ := userID.(string)
+
+	statuses := make(map[string]model.BookingStatusUpdate, len(req.BookingIDs))
+
+	cached, err := h.cache.GetBookingStatuses(req.BookingIDs)
+	if err != nil {
+		cached = nil // Fall back to the database for everything
+	}
+
+	var misses []string
+	for _, bookingID := range req.BookingIDs {
+		if status, ok := cached[bookingID]; ok && status.UserID != "" {
+			if status.UserID == userUUID {
+				statuses[bookingID] = *status
+			}
+			continue
+		}
+		misses = append(misses, bookingID)
+	}
+
+	if len(misses) > 0 {
+		bookings, err := h.repo.GetUserBookingsByIDs(userUUID, misses)
+		if err != nil {
+			middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve bookings")
+			return
+		}
+		for _, booking := range bookings {
+			statuses[booking.ID] = booking.ToBookingStatusUpdate()
+		}
+	}
+
+	c.JSON(http.StatusOK, model.BatchBookingStatusResponse{Statuses: statuses})
+}
+
 // StreamBookingStatus provides Server-Sent Events for real-time booking updates
 func (h *BookingHandler) StreamBookingStatus(c *gin.Context) {
 	bookingIDStr := c.Param("bookingId")
@@ -200,10 +442,11 @@ func (h *BookingHandler) StreamBookingStatus(c *gin.Context) {
 	// Verify booking exists
 	booking, err := h.repo.GetBookingByID(bookingIDStr)
 	if err != nil {
-		c.JSON(http.StatusNotFound, model.ErrorResponse{
-			Error:   "not_found",
-			Message: "Booking not found",
-		})
+		if errors.Is(err, repository.ErrBookingNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Booking not found")
+			return
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve booking")
 		return
 	}
 
@@ -213,30 +456,14 @@ func (h *BookingHandler) StreamBookingStatus(c *gin.Context) {
 	c.Header("Connection", "keep-alive")
 	c.Header("Access-Control-Allow-Origin", "*")
 
-	// Send initial status
-	statusUpdate := &model.BookingStatusUpdate{
-		BookingID: booking.ID,
-		Status:    booking.Status,
-		Message:   fmt.Sprintf("Current status: %s", booking.Status),
-		UpdatedAt: time.Now(),
-	}
-
-	eventData, _ := json.Marshal(statusUpdate)
-	c.SSEvent("status", string(eventData))
-	c.Writer.Flush()
-
-	// If booking is final, close stream
-	if booking.Status == "confirmed" || booking.Status == "failed" {
-		finalData, _ := json.Marshal(map[string]interface{}{
-			"booking_id":   booking.ID,
-			"final_status": booking.Status,
-		})
-		c.SSEvent("complete", string(finalData))
+	// Send initial status, closing straight away if the booking already finished
+	writeStatusEvent(c, booking, fmt.Sprintf("Current status: %s", booking.Status))
+	if completeIfTerminal(c, booking) {
 		return
 	}
 
 	// Keep connection alive and poll for updates
-	ticker := time.NewTicker(2 * time.Second)
+	ticker := time.NewTicker(time.Duration(h.cfg.Stream.PollIntervalMillis) * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
@@ -247,29 +474,14 @@ func (h *BookingHandler) StreamBookingStatus(c *gin.Context) {
 			if err != nil {
 				continue
 			}
+			if updated.Status == booking.Status && updated.PaymentStatus == booking.PaymentStatus {
+				continue
+			}
 
-			if updated.Status != booking.Status {
-				booking = updated
-				statusUpdate := &model.BookingStatusUpdate{
-					BookingID: booking.ID,
-					Status:    booking.Status,
-					Message:   fmt.Sprintf("Status updated to: %s", booking.Status),
-					UpdatedAt: time.Now(),
-				}
-
-				eventData, _ := json.Marshal(statusUpdate)
-				c.SSEvent("status", string(eventData))
-				c.Writer.Flush()
-
-				// Close stream if final status
-				if booking.Status == "confirmed" || booking.Status == "failed" {
-					finalData, _ := json.Marshal(map[string]interface{}{
-						"booking_id":   booking.ID,
-						"final_status": booking.Status,
-					})
-					c.SSEvent("complete", string(finalData))
-					return
-				}
+			booking = updated
+			writeStatusEvent(c, booking, fmt.Sprintf("Status updated to: %s", booking.Status))
+			if completeIfTerminal(c, booking) {
+				return
 			}
 
 		case <-c.Request.Context().Done():
@@ -278,38 +490,201 @@ func (h *BookingHandler) StreamBookingStatus(c *gin.Context) {
 	}
 }
 
+// writeStatusEvent sends the booking's current status as an SSE status event
+func writeStatusEvent(c *gin.Context, booking *model.Booking, message string) {
+	eventData, _ := json.Marshal(&model.BookingStatusUpdate{
+		BookingID:     booking.ID,
+		Status:        booking.Status,
+		PaymentStatus: booking.PaymentStatus,
+		Message:       message,
+		UpdatedAt:     time.Now().UTC(),
+	})
+	c.SSEvent("status", string(eventData))
+	c.Writer.Flush()
+}
+
+// completeIfTerminal sends the complete event when the booking has reached a
+// final status, reporting whether the stream should close
+func completeIfTerminal(c *gin.Context, booking *model.Booking) bool {
+	if !booking.IsTerminal() {
+		return false
+	}
+	finalData, _ := json.Marshal(map[string]interface{}{
+		"booking_id":   booking.ID,
+		"final_status": booking.Status,
+	})
+	c.SSEvent("complete", string(finalData))
+	c.Writer.Flush()
+	return true
+}
+
+// ResendConfirmation re-emits the confirmation notification for a confirmed booking
+func (h *BookingHandler) ResendConfirmation(c *gin.Context) {
+	bookingIDStr := c.Param("bookingId")
+
+	userID, _ := c.Get("user_id")
+	userUUID, _ := userID.(string)
+
+	booking, err := h.repo.GetBookingByID(bookingIDStr)
+	if err != nil {
+		if errors.Is(err, repository.ErrBookingNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Booking not found")
+			return
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve booking")
+		return
+	}
+
+	if booking.UserID != userUUID {
+		middleware.RespondError(c, http.StatusForbidden, "forbidden", "You do not have access to this booking")
+		return
+	}
+
+	if booking.Status != model.BookingStatusConfirmed {
+		middleware.RespondError(c, http.StatusConflict, "booking_not_confirmed", "Confirmation can only be resent for confirmed bookings")
+		return
+	}
+
+	if !h.cfg.NotificationsEnabled {
+		middleware.RespondError(c, http.StatusServiceUnavailable, "notifications_disabled", "Confirmation emails are disabled")
+		return
+	}
+
+	// Rate-limit resends per booking
+	cooldown := time.Duration(h.cfg.Booking.ResendCooldownSeconds) * time.Second
+	acquired, remaining, err := h.cache.AcquireResendCooldown(booking.ID, cooldown)
+	if err != nil {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to check resend limit")
+		return
+	}
+	if !acquired {
+		c.Header("Retry-After", retryAfterSeconds(remaining))
+		middleware.RespondError(c, http.StatusTooManyRequests, "resend_rate_limited", "Confirmation was resent recently, please try again later")
+		return
+	}
+
+	msgBytes, _ := json.Marshal(booking.ToNotificationRequest("booking_confirmed"))
+	if err := h.queue.Produce(c.Request.Context(),
+		kafka.Message{
+			Topic: h.cfg.Kafka.NotificationTopic,
+			Key:   []byte(booking.ID),
+			Value: msgBytes,
+		}); err != nil {
+		// Allow the user to retry immediately since nothing was sent
+		h.cache.ClearResendCooldown(booking.ID)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to queue confirmation email")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"booking_id": booking.ID,
+		"message":    "Confirmation email has been queued for delivery",
+	})
+}
+
+// ReprocessBooking re-drives a booking stuck in processing, e.g. after its
+// worker died, by queuing its request for the worker again. Bookings that
+// already finished are left alone, so repeating the call is harmless.
+func (h *BookingHandler) ReprocessBooking(c *gin.Context) {
+	bookingID := c.Param("bookingId")
+
+	booking, err := h.repo.GetBookingByID(bookingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrBookingNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Booking not found")
+			return
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve booking")
+		return
+	}
+
+	if booking.IsTerminal() {
+		c.JSON(http.StatusOK, model.ReprocessBookingResponse{
+			BookingID: booking.ID,
+			Status:    booking.Status,
+			Message:   "Booking already finished processing",
+		})
+		return
+	}
+
+	// The worker rejects requests without a payment method, which would fail
+	// the booking instead of completing it
+	if booking.PaymentMethod == "" {
+		middleware.RespondError(c, http.StatusConflict, "payment_method_unknown",
+			"The booking predates stored payment methods and can't be rebuilt")
+		return
+	}
+
+	// A worker holding the lease may still be processing the booking
+	leaseRemaining, err := h.cache.ResetBookingProcessed(booking.ID)
+	if err != nil {
+		log.Printf("Failed to reset processing state of booking %s: %v", booking.ID, err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to check processing state")
+		return
+	}
+	if leaseRemaining > 0 {
+		c.Header("Retry-After", retryAfterSeconds(leaseRemaining))
+		middleware.RespondError(c, http.StatusConflict, "booking_in_progress",
+			"A worker is still processing this booking, retry once its lease expires")
+		return
+	}
+
+	msgBytes, err := json.Marshal(booking.ToBookingRequest())
+	if err != nil {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to rebuild booking request")
+		return
+	}
+	if err := h.queue.Produce(c.Request.Context(), kafka.Message{
+		Topic: h.cfg.Kafka.BookingTopic,
+		Key:   []byte(booking.ID),
+		Value: msgBytes,
+	}); err != nil {
+		log.Printf("Failed to requeue booking %s: %v", booking.ID, err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to queue booking for processing")
+		return
+	}
+
+	log.Printf("Admin %s requeued booking %s for processing", c.GetString(middleware.ContextUserID), booking.ID)
+	h.audit.RecordRequest(c, audit.ActionBookingReprocessed, audit.Target("booking", booking.ID),
+		map[string]string{"user_id": booking.UserID, "hold_id": booking.HoldID})
+
+	c.JSON(http.StatusAccepted, model.ReprocessBookingResponse{
+		BookingID: booking.ID,
+		Status:    booking.Status,
+		Requeued:  true,
+		Message:   "Booking has been queued for processing again",
+	})
+}
+
 // ListUserBookings returns all bookings for the authenticated user
 func (h *BookingHandler) ListUserBookings(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User ID not found in token",
-		})
+		middleware.RespondError(c, http.StatusUnauthorized, "unauthorized", "User ID not found in token")
 		return
 	}
 
 	userUUID, ok := userID.(string)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Invalid user ID format",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Invalid user ID format")
+		return
+	}
+
+	page, err := pagination.FromQuery(c, h.cfg.Booking.PageLimits())
+	if err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", "cursor must be a next_cursor from a previous page")
 		return
 	}
 
 	filter := model.BookingFilter{
 		UserID: userUUID,
-		Limit:  50,
-		Offset: 0,
+		Limit:  page.Limit,
+		Offset: page.Offset,
 	}
 
 	bookings, total, err := h.repo.ListUserBookings(filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to retrieve bookings",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve bookings")
 		return
 	}
 
@@ -319,37 +694,98 @@ func (h *BookingHandler) ListUserBookings(c *gin.Context) {
 	}
 
 	response := model.UserBookingsResponse{
-		Bookings: bookingSummaries,
-		Total:    total,
+		Bookings:   bookingSummaries,
+		Total:      total,
+		Pagination: page.Paginate(total),
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// CountUserBookings returns the authenticated user's booking counts by status
+// without fetching any bookings. Counts are cached briefly and dropped whenever
+// one of the user's bookings is created or changes status.
+func (h *BookingHandler) CountUserBookings(c *gin.Context) {
+	userID := c.GetString(middleware.ContextUserID)
+	if userID == "" {
+		middleware.RespondError(c, http.StatusUnauthorized, "unauthorized", "User ID not found in token")
+		return
+	}
+
+	counts, err := h.cache.GetBookingCounts(userID)
+	if err != nil || counts == nil {
+		counts, err = h.repo.CountUserBookingsByStatus(userID)
+		if err != nil {
+			log.Printf("Failed to count bookings for user %s: %v", userID, err)
+			middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to count bookings")
+			return
+		}
+		if ttl := time.Duration(h.cfg.Booking.CountCacheSeconds) * time.Second; ttl > 0 {
+			h.cache.SetBookingCounts(userID, counts, ttl)
+		}
+	}
+
+	// Report every status so dashboards don't have to treat missing keys as zero
+	response := model.BookingCountsResponse{
+		Counts: map[string]int{
+			model.BookingStatusProcessing: 0,
+			model.BookingStatusConfirmed:  0,
+			model.BookingStatusFailed:     0,
+		},
+	}
+	for status, count := range counts {
+		response.Counts[status] = count
+		response.Total += count
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// deletedUserMarkerTTL is how long a deleted user's tokens are refused. It
+// comfortably outlives any access token issued before the deletion.
+const deletedUserMarkerTTL = 24 * time.Hour
+
+// AnonymizeUserBookings handles the user service's request to strip a deleted
+// user's email and name from their bookings. The user is marked deleted first
+// so a booking submitted meanwhile can't slip in with their details.
+func (h *BookingHandler) AnonymizeUserBookings(c *gin.Context) {
+	userID := c.Param("userId")
+
+	if err := h.cache.MarkUserDeleted(userID, deletedUserMarkerTTL); err != nil {
+		log.Printf("Failed to mark user %s deleted: %v", userID, err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to anonymize bookings")
+		return
+	}
+
+	anonymized, err := h.repo.AnonymizeUserBookings(userID)
+	if err != nil {
+		log.Printf("Failed to anonymize bookings of user %s: %v", userID, err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to anonymize bookings")
+		return
+	}
+	h.cache.InvalidateBookingCounts(userID)
+
+	c.JSON(http.StatusOK, model.AnonymizeUserBookingsResponse{AnonymizedBookings: anonymized})
+}
+
 // HealthCheck handles health check endpoint
 func (h *BookingHandler) HealthCheck(c *gin.Context) {
 	// Check database connection
 	sqlDB, err := h.repo.GetDB().DB()
 	if err != nil {
-		c.JSON(http.StatusServiceUnavailable, model.ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Database connection failed",
-		})
+		middleware.RespondError(c, http.StatusServiceUnavailable, "service_unavailable", "Database connection failed")
 		return
 	}
 
 	if err := sqlDB.Ping(); err != nil {
-		c.JSON(http.StatusServiceUnavailable, model.ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Database ping failed",
-		})
+		middleware.RespondError(c, http.StatusServiceUnavailable, "service_unavailable", "Database ping failed")
 		return
 	}
 
 	response := model.HealthResponse{
 		Status:    "healthy",
 		Service:   "booking-service",
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -364,7 +800,7 @@ func (h *BookingHandler) getHoldDetails(holdID uuid.UUID) (*HoldData, error) {
 		EventID:   uuid.New(),
 		EventName: "Rock Concert 2025",
 		Venue:     "Madison Square Garden",
-		EventDate: time.Now().Add(30 * 24 * time.Hour),
+		EventDate: time.Now().UTC().Add(30 * 24 * time.Hour),
 		Seats:     []string{"A1", "A2", "A3"},
 	}, nil
 }
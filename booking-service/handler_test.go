@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/config"
+	"github.com/arunvm123/eventbooking/booking-service/model"
+	"github.com/arunvm123/eventbooking/booking-service/repository"
+	"github.com/arunvm123/eventbooking/booking-service/service"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+)
+
+func TestSubmitBookingRejectsMalformedUpstreamEventDate(t *testing.T) {
+	events := &fakeEventService{holds: map[string]*service.HoldDetails{
+		"hold-1": {
+			HoldID:          "hold-1",
+			UserID:          "user-1",
+			EventID:         "event-1",
+			EventDate:       "2026-07-01 19:00:00",
+			Seats:           []string{"A1"},
+			TotalPriceCents: 5000,
+			Currency:        "USD",
+		},
+	}}
+	handler := NewBookingHandler(&config.Config{}, newFakeBookingRepository(), nil, nil, nil, events, nil)
+
+	body := `{"hold_id":"hold-1","payment_info":{"payment_method":"card","amount":50,"currency":"USD"}}`
+	w := serve("/bookings", http.MethodPost, "/bookings", "user-1", body, handler.SubmitBooking)
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("status = %d, want 502: %s", w.Code, w.Body)
+	}
+
+	var resp middleware.ErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Error != "invalid_upstream_response" {
+		t.Errorf("error = %q, want invalid_upstream_response", resp.Error)
+	}
+}
+
+func TestHoldDetailsEventDateParses(t *testing.T) {
+	// As the event service sends it: a UTC time marshalled by encoding/json
+	sent := time.Date(2026, 7, 1, 19, 0, 0, 0, time.UTC)
+	wire, err := json.Marshal(map[string]time.Time{"event_date": sent})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var details service.HoldDetails
+	if err := json.Unmarshal(wire, &details); err != nil {
+		t.Fatal(err)
+	}
+	got, err := time.Parse(time.RFC3339, details.EventDate)
+	if err != nil {
+		t.Fatalf("event_date %q doesn't parse as RFC3339: %v", details.EventDate, err)
+	}
+	if !got.Equal(sent) {
+		t.Errorf("event_date = %s, want %s", got, sent)
+	}
+}
+
+func TestSubmitBookingRejectsAnotherUsersHold(t *testing.T) {
+	events := &fakeEventService{holds: map[string]*service.HoldDetails{
+		"hold-1": {
+			HoldID:          "hold-1",
+			UserID:          "owner",
+			EventID:         "event-1",
+			EventDate:       "2026-07-01T19:00:00Z",
+			Seats:           []string{"A1"},
+			TotalPriceCents: 5000,
+			Currency:        "USD",
+		},
+	}}
+	handler := NewBookingHandler(&config.Config{}, newFakeBookingRepository(), nil, nil, nil, events, nil)
+
+	body := `{"hold_id":"hold-1","payment_info":{"payment_method":"card","amount":50,"currency":"USD"}}`
+	w := serve("/bookings", http.MethodPost, "/bookings", "someone-else", body, handler.SubmitBooking)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403: %s", w.Code, w.Body)
+	}
+
+	var resp middleware.ErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Error != "hold_not_owned" {
+		t.Errorf("error = %q, want hold_not_owned", resp.Error)
+	}
+}
+
+func TestStreamBookingStatusCompletesFinishedBookingImmediately(t *testing.T) {
+	repo := newFakeBookingRepository(&model.Booking{
+		ID:            "booking-1",
+		Status:        model.BookingStatusConfirmed,
+		PaymentStatus: model.PaymentStatusCaptured,
+	})
+	cfg := &config.Config{}
+	cfg.Stream.PollIntervalMillis = int(time.Hour / time.Millisecond)
+	handler := NewBookingHandler(cfg, repo, nil, nil, nil, nil, nil)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- serve("/booking/:bookingId/stream", http.MethodGet, "/booking/booking-1/stream", "user-1", "", handler.StreamBookingStatus)
+	}()
+
+	var w *httptest.ResponseRecorder
+	select {
+	case w = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream of a confirmed booking didn't close")
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, "event:complete") || !strings.Contains(body, `"final_status":"confirmed"`) {
+		t.Errorf("stream = %q, want a complete event with the confirmed status", body)
+	}
+	if repo.reads != 1 {
+		t.Errorf("booking read %d times, want only the initial read", repo.reads)
+	}
+}
+
+func TestGetBookingStatusMapsRepositoryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", repository.ErrBookingNotFound, http.StatusNotFound},
+		{"wrapped not found", fmt.Errorf("lookup booking-1: %w", repository.ErrBookingNotFound), http.StatusNotFound},
+		{"same message, different error", errors.New("booking not found"), http.StatusInternalServerError},
+		{"database down", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeBookingRepository()
+			repo.getErr = tt.err
+			handler := NewBookingHandler(&config.Config{}, repo, nil, nil, nil, nil, nil)
+
+			w := serve("/booking/:bookingId/status", http.MethodGet, "/booking/booking-1/status", "user-1", "", handler.GetBookingStatus)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubmitBookingRejectsMismatchedCurrency(t *testing.T) {
+	events := &fakeEventService{holds: map[string]*service.HoldDetails{
+		"hold-1": {
+			HoldID:          "hold-1",
+			UserID:          "user-1",
+			EventID:         "event-1",
+			EventDate:       "2026-07-01T19:00:00Z",
+			Seats:           []string{"A1"},
+			TotalPriceCents: 5000,
+			Currency:        "EUR",
+		},
+	}}
+	handler := NewBookingHandler(&config.Config{}, newFakeBookingRepository(), nil, nil, nil, events, nil)
+
+	body := `{"hold_id":"hold-1","payment_info":{"payment_method":"card","amount":50,"currency":"USD"}}`
+	w := serve("/bookings", http.MethodPost, "/bookings", "user-1", body, handler.SubmitBooking)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+
+	var resp struct {
+		Error   string                        `json:"error"`
+		Details model.CurrencyMismatchDetails `json:"details"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Error != "currency_mismatch" {
+		t.Errorf("error = %q, want currency_mismatch", resp.Error)
+	}
+	if resp.Details.ExpectedCurrency != "EUR" || resp.Details.SubmittedCurrency != "USD" {
+		t.Errorf("details = %+v, want EUR expected and USD submitted", resp.Details)
+	}
+}
+
+func TestSubmitBookingPassesOnThrottling(t *testing.T) {
+	tests := []struct {
+		retryAfter time.Duration
+		want       string
+	}{
+		{300 * time.Millisecond, "1"},
+		{time.Second, "1"},
+		{1500 * time.Millisecond, "2"},
+		{0, "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.retryAfter.String(), func(t *testing.T) {
+			events := &fakeEventService{err: &service.ThrottledError{StatusCode: http.StatusTooManyRequests, RetryAfter: tt.retryAfter}}
+			handler := NewBookingHandler(&config.Config{}, newFakeBookingRepository(), nil, nil, nil, events, nil)
+
+			body := `{"hold_id":"hold-1","payment_info":{"payment_method":"card","amount":50,"currency":"USD"}}`
+			w := serve("/bookings", http.MethodPost, "/bookings", "user-1", body, handler.SubmitBooking)
+			if w.Code != http.StatusServiceUnavailable {
+				t.Fatalf("status = %d, want 503: %s", w.Code, w.Body)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.want {
+				t.Errorf("Retry-After = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/cache"
+	"github.com/arunvm123/eventbooking/booking-service/config"
+	"github.com/arunvm123/eventbooking/booking-service/repository"
+	"github.com/arunvm123/eventbooking/booking-service/service"
+	"github.com/arunvm123/eventbooking/booking-service/worker"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/queue"
+)
+
+// inProcessLogGroup consumes the topics whose consumers run out of process
+const inProcessLogGroup = "booking-service-log"
+
+// startInProcessWorker runs the booking processor alongside the API, consuming
+// booking requests from the same queue the outbox relay publishes to. The
+// notification worker and audit consumer can't reach the queue either, so
+// what would reach them is logged here instead.
+func startInProcessWorker(cfg *config.Config, repo repository.BookingRepository, cacheRepo cache.CacheRepository, eventService service.EventService, mq queue.MessageQueue) {
+	var notificationTopic string
+	if cfg.NotificationsEnabled {
+		notificationTopic = cfg.Kafka.NotificationTopic
+	}
+
+	webhooks := worker.NewWebhookDispatcher(repo, cfg.Webhook.Secret, cfg.Webhook.MaxAttempts,
+		time.Duration(cfg.Webhook.TimeoutSeconds)*time.Second, cfg.Webhook.AllowPrivateTargets)
+	webhooks.ResumePending()
+	consumer := mq.Consume(cfg.Kafka.BookingTopic, cfg.Kafka.ConsumerGroup)
+
+	auditor := audit.NewRecorder("booking-service", mq, cfg.Kafka.AuditTopic)
+	processor := worker.NewBookingProcessor(repo, cacheRepo, eventService, mq, consumer,
+		notificationTopic, cfg.Kafka.DLQTopic, webhooks, auditor, cfg.EventService.MaxRetries)
+
+	if notificationTopic != "" {
+		go queue.LogTopic(context.Background(), mq, notificationTopic, inProcessLogGroup)
+	}
+	go queue.LogTopic(context.Background(), mq, cfg.Kafka.AuditTopic, inProcessLogGroup)
+
+	go func() {
+		if err := processor.Start(context.Background()); err != nil {
+			log.Printf("In-process booking worker stopped: %v", err)
+		}
+	}()
+}
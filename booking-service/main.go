@@ -3,11 +3,15 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/arunvm123/eventbooking/booking-service/config"
 )
 
 func main() {
+	// Database times are scanned in the local zone, so make that UTC
+	time.Local = time.UTC
+
 	// Initialize configuration
 	// Try to load from config.yaml first, fallback to environment variables
 	cfg, err := config.Initialise("config.yaml", false)
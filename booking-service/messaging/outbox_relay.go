@@ -0,0 +1,96 @@
+package messaging
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/model"
+	"github.com/arunvm123/eventbooking/booking-service/repository"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/segmentio/kafka-go"
+)
+
+// outboxRetention is how long published outbox rows are kept before cleanup
+const outboxRetention = 24 * time.Hour
+
+// OutboxRelay publishes messages recorded in the booking outbox to the queue.
+// Delivery is at-least-once: a crash after publishing but before marking a
+// row sent causes it to be published again, so consumers must be idempotent.
+type OutboxRelay struct {
+	repo         repository.BookingRepository
+	producer     queue.MessageQueue
+	pollInterval time.Duration
+	batchSize    int
+	wake         chan struct{}
+}
+
+func NewOutboxRelay(repo repository.BookingRepository, producer queue.MessageQueue, pollInterval time.Duration, batchSize int) *OutboxRelay {
+	return &OutboxRelay{
+		repo:         repo,
+		producer:     producer,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Notify asks the relay to publish immediately instead of waiting for the next poll
+func (r *OutboxRelay) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run relays outbox messages until the context is cancelled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	log.Printf("Starting outbox relay (poll interval %s, batch size %d)", r.pollInterval, r.batchSize)
+
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	cleanup := time.NewTicker(time.Hour)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cleanup.C:
+			if deleted, err := r.repo.DeleteSentOutboxMessages(time.Now().UTC().Add(-outboxRetention)); err != nil {
+				log.Printf("Outbox cleanup failed: %v", err)
+			} else if deleted > 0 {
+				log.Printf("Outbox cleanup removed %d sent messages", deleted)
+			}
+		case <-ticker.C:
+			r.drain(ctx)
+		case <-r.wake:
+			r.drain(ctx)
+		}
+	}
+}
+
+// drain relays batches until the outbox is empty or publishing fails
+func (r *OutboxRelay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		relayed, err := r.repo.RelayOutbox(r.batchSize, func(messages []model.OutboxMessage) error {
+			kafkaMessages := make([]kafka.Message, len(messages))
+			for i, message := range messages {
+				kafkaMessages[i] = kafka.Message{
+					Topic: message.Topic,
+					Key:   []byte(message.Key),
+					Value: message.Payload,
+				}
+			}
+			return r.producer.Produce(ctx, kafkaMessages...)
+		})
+		if err != nil {
+			log.Printf("Outbox relay error: %v", err)
+			return
+		}
+		if relayed < r.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package messaging
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/config"
+	"github.com/arunvm123/eventbooking/booking-service/model"
+	"github.com/arunvm123/eventbooking/booking-service/repository/postgres"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/arunvm123/eventbooking/shared/startup"
+	"github.com/google/uuid"
+)
+
+// newTestRepository connects to the database in TEST_DATABASE_URL, skipping the
+// test when it isn't set
+func newTestRepository(t *testing.T) *postgres.PostgresBookingRepository {
+	t.Helper()
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	parsed, err := url.Parse(databaseURL)
+	if err != nil {
+		t.Fatalf("invalid TEST_DATABASE_URL: %v", err)
+	}
+
+	password, _ := parsed.User.Password()
+	sslMode := parsed.Query().Get("sslmode")
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	repo, err := postgres.NewBookingRepository(&config.Database{
+		User:         parsed.User.Username(),
+		Password:     password,
+		DatabaseName: parsed.Path[1:],
+		Host:         parsed.Hostname(),
+		Port:         parsed.Port(),
+		SSLMode:      sslMode,
+		MaxOpenConns: 5,
+		MaxIdleConns: 1,
+	}, startup.Retry{Attempts: 1})
+	if err != nil {
+		t.Fatalf("NewBookingRepository() error = %v", err)
+	}
+	return repo
+}
+
+// A crash between committing a booking and publishing its message leaves the
+// outbox row unsent; the relay must publish it on its next pass
+func TestRelayPublishesMessagesLeftUnsent(t *testing.T) {
+	repo := newTestRepository(t)
+	topic := "booking-requests-test-" + uuid.NewString()[:8]
+
+	var message *model.OutboxMessage
+	booking, err := repo.CreateBookingWithOutbox(model.CreateBookingRequest{
+		UserID:        uuid.NewString(),
+		UserEmail:     "ana@example.com",
+		UserName:      "Ana",
+		EventID:       uuid.NewString(),
+		EventName:     "Jazz Night",
+		Venue:         "Blue Note",
+		EventDate:     time.Now().Add(24 * time.Hour),
+		Seats:         []string{"A1"},
+		HoldID:        uuid.NewString(),
+		PaymentMethod: "card",
+	}, func(booking *model.Booking) (*model.OutboxMessage, error) {
+		message = &model.OutboxMessage{ID: uuid.NewString(), Topic: topic, Key: booking.ID, Payload: []byte(`{"booking_id":"` + booking.ID + `"}`)}
+		return message, nil
+	})
+	if err != nil {
+		t.Fatalf("CreateBookingWithOutbox() error = %v", err)
+	}
+	t.Cleanup(func() {
+		repo.GetDB().Delete(&model.OutboxMessage{}, "id = ?", message.ID)
+		repo.GetDB().Unscoped().Delete(&model.Booking{}, "id = ?", booking.ID)
+	})
+
+	// The process died here, before the handler's publish
+	mq := queue.NewMemoryQueue(100)
+	defer mq.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	NewOutboxRelay(repo, mq, time.Minute, 100).drain(ctx)
+
+	got, err := mq.Consume(topic, "test").Fetch(ctx)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v, want the booking's message", err)
+	}
+	if string(got.Key) != booking.ID || string(got.Value) != string(message.Payload) {
+		t.Errorf("published %s: %s, want %s: %s", got.Key, got.Value, booking.ID, message.Payload)
+	}
+
+	var sent model.OutboxMessage
+	if err := repo.GetDB().Where("id = ?", message.ID).First(&sent).Error; err != nil {
+		t.Fatal(err)
+	}
+	if sent.SentAt == nil {
+		t.Error("outbox row is still unsent after relaying")
+	}
+}
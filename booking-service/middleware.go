@@ -1,161 +1,80 @@
 package main
 
 import (
+	"log"
 	"net/http"
-	"strings"
-	"time"
 
+	"github.com/arunvm123/eventbooking/booking-service/cache"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-// JWT service for token validation
-type JWTService struct {
-	secretKey string
+// authErrorCodes keeps the booking API's existing authentication error codes
+var authErrorCodes = middleware.AuthErrorCodes{
+	MissingHeader: "authorization_required",
+	InvalidFormat: "invalid_token_format",
+	InvalidToken:  "invalid_token",
 }
 
-func NewJWTService(secretKey string) *JWTService {
-	return &JWTService{
-		secretKey: secretKey,
-	}
+// AuthMiddleware validates JWT tokens with the shared middleware, reporting
+// failures in this service's error format
+func AuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
+	return middleware.Auth(jwtService, middleware.RespondError, authErrorCodes)
 }
 
-// Claims represents the JWT claims
-type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	jwt.RegisteredClaims
+// GuestAuthMiddleware is AuthMiddleware for the routes a guest needs to book
+// their hold and follow its status; it also accepts guest tokens
+func GuestAuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
+	return middleware.AuthAllowGuests(jwtService, middleware.RespondError, authErrorCodes)
 }
 
-// GenerateServiceToken generates a JWT token for service-to-service communication
-func (j *JWTService) GenerateServiceToken() (string, error) {
-	// Create claims for service-to-service communication
-	claims := Claims{
-		UserID: "booking-service",
-		Email:  "booking-service@internal",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(1 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "booking-service",
-			Subject:   "service-auth",
-		},
-	}
-
-	// Create token with claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// Generate encoded token and send it as response
-	tokenString, err := token.SignedString([]byte(j.secretKey))
-	if err != nil {
-		return "", err
-	}
+// roleAdmin is the JWT role allowed to access any user's bookings
+const roleAdmin = "admin"
 
-	return tokenString, nil
+// isAdmin reports whether the authenticated caller has the admin role
+func isAdmin(c *gin.Context) bool {
+	return c.GetString(middleware.ContextUserRole) == roleAdmin
 }
 
-// ValidateToken validates a JWT token and returns the claims
-func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.secretKey), nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
-	}
-
-	return nil, jwt.ErrSignatureInvalid
-}
-
-// AuthMiddleware validates JWT tokens
-func AuthMiddleware(jwtService *JWTService) gin.HandlerFunc {
+// RequireAdmin rejects callers without the admin role
+func RequireAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "authorization_required",
-				"message": "Authorization header is required",
-			})
+		if !isAdmin(c) {
+			middleware.RespondError(c, http.StatusForbidden, "forbidden", "Admin role required")
 			c.Abort()
 			return
 		}
-
-		// Check for Bearer token
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "invalid_token_format",
-				"message": "Authorization header must be Bearer token",
-			})
-			c.Abort()
-			return
-		}
-
-		// Validate token
-		claims, err := jwtService.ValidateToken(tokenParts[1])
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "invalid_token",
-				"message": "Invalid or expired token",
-			})
-			c.Abort()
-			return
-		}
-
-		// Set user info in context
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
 		c.Next()
 	}
 }
 
-// CORSMiddleware handles Cross-Origin Resource Sharing
-func CORSMiddleware() gin.HandlerFunc {
+// RequireService rejects callers that aren't another service
+func RequireService() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		if c.GetString(middleware.ContextUserRole) != auth.RoleService {
+			middleware.RespondError(c, http.StatusForbidden, "forbidden", "Service role required")
+			c.Abort()
 			return
 		}
-
 		c.Next()
 	}
 }
 
-// LoggingMiddleware logs HTTP requests
-func LoggingMiddleware() gin.HandlerFunc {
+// RejectDeletedUsers refuses the still-valid tokens of users who have deleted
+// their account. If the cache can't be reached the request is let through
+// rather than locking everyone out.
+func RejectDeletedUsers(cache cache.CacheRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		start := time.Now()
-		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
-
-		// Process request
-		c.Next()
-
-		// Calculate latency
-		latency := time.Since(start)
-
-		// Get status code
-		statusCode := c.Writer.Status()
-
-		// Build log message
-		if raw != "" {
-			path = path + "?" + raw
+		deleted, err := cache.IsUserDeleted(c.GetString(middleware.ContextUserID))
+		if err != nil {
+			log.Printf("Failed to check whether user is deleted: %v", err)
 		}
-
-		// Log the request
-		gin.Logger()(c)
-
-		// You can add custom logging here
-		_ = latency
-		_ = statusCode
-		_ = path
+		if deleted {
+			middleware.RespondError(c, http.StatusUnauthorized, "account_deleted", "This account has been deleted")
+			c.Abort()
+			return
+		}
+		c.Next()
 	}
 }
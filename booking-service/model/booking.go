@@ -1,8 +1,10 @@
 package model
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/arunvm123/eventbooking/shared/pagination"
 	"github.com/lib/pq"
 )
 
@@ -12,23 +14,37 @@ import (
 
 // Booking represents the database model for bookings
 type Booking struct {
-	ID            string         `gorm:"primary_key;default:gen_random_uuid()"`
-	UserID        string         `gorm:"not null;index"`
-	UserEmail     string         `gorm:"type:varchar(255);not null"`
-	UserName      string         `gorm:"type:varchar(255);not null"`
-	EventID       string         `gorm:"not null;index"`
-	EventName     string         `gorm:"type:varchar(255);not null"`
-	Venue         string         `gorm:"type:varchar(255);not null"`
-	EventDate     time.Time      `gorm:"not null"`
-	Seats         pq.StringArray `gorm:"type:text[];not null"`
-	TotalAmount   float64        `gorm:"type:decimal(10,2);not null"`
-	Status        string         `gorm:"type:varchar(20);not null;default:'processing'"`
-	PaymentStatus string         `gorm:"type:varchar(20);not null;default:'pending'"`
-	HoldID        string         `gorm:"not null;index"`
-	ErrorMessage  *string        `gorm:"type:text"`
-	CreatedAt     time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
-	ConfirmedAt   *time.Time
-	FailedAt      *time.Time
+	ID               string         `gorm:"primary_key;default:gen_random_uuid()"`
+	ConfirmationCode string         `gorm:"type:varchar(16);uniqueIndex"`
+	UserID           string         `gorm:"not null;index"`
+	UserEmail        string         `gorm:"type:varchar(255);not null"`
+	UserName         string         `gorm:"type:varchar(255);not null"`
+	Locale           string         `gorm:"type:varchar(16)"` // Language for notification emails
+	EventID          string         `gorm:"not null;index"`
+	EventName        string         `gorm:"type:varchar(255);not null"`
+	Venue            string         `gorm:"type:varchar(255);not null"`
+	EventDate        time.Time      `gorm:"not null"`
+	Seats            pq.StringArray `gorm:"type:text[];not null"`
+	SubtotalCents    int64          `gorm:"not null;default:0"` // Seat prices, in minor units of Currency
+	FeesCents        int64          `gorm:"not null;default:0"`
+	TaxCents         int64          `gorm:"not null;default:0"`
+	TotalAmountCents int64          `gorm:"not null;default:0"` // Subtotal plus fees and tax
+	Currency         string         `gorm:"type:varchar(3);not null;default:'USD'"`
+	Status           string         `gorm:"type:varchar(20);not null;default:'processing'"`
+	PaymentStatus    string         `gorm:"type:varchar(20);not null;default:'pending'"`
+	HoldID           string         `gorm:"not null;index"`
+	PaymentMethod    string         `gorm:"type:varchar(50)"` // Empty on bookings made before it was stored
+	ErrorMessage     *string        `gorm:"type:text"`
+	CreatedAt        time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+	ConfirmedAt      *time.Time
+	FailedAt         *time.Time
+
+	// Optional integrator webhook notified when the booking is confirmed or fails
+	CallbackURL         string  `gorm:"type:text"`
+	CallbackStatus      string  `gorm:"type:varchar(20)"` // pending, delivered, failed
+	CallbackAttempts    int     `gorm:"not null;default:0"`
+	CallbackError       *string `gorm:"type:text"`
+	CallbackDeliveredAt *time.Time
 }
 
 // TableName sets the table name for GORM
@@ -36,6 +52,78 @@ func (Booking) TableName() string {
 	return "bookings"
 }
 
+// Placeholders written over a user's personal data when they delete their account
+const (
+	AnonymizedUserEmail = "deleted-user@anonymized.invalid"
+	AnonymizedUserName  = "Deleted User"
+)
+
+// Booking statuses. They are always lower case.
+const (
+	BookingStatusProcessing = "processing"
+	BookingStatusConfirmed  = "confirmed"
+	BookingStatusFailed     = "failed"
+)
+
+// bookingStatusTransitions lists the statuses each status may move to.
+// Re-entering processing is allowed so redelivered messages can restart work.
+var bookingStatusTransitions = map[string][]string{
+	BookingStatusProcessing: {BookingStatusProcessing, BookingStatusConfirmed, BookingStatusFailed},
+	BookingStatusConfirmed:  {},
+	BookingStatusFailed:     {},
+}
+
+// Payment statuses
+const (
+	PaymentStatusPending    = "pending"    // Not charged yet
+	PaymentStatusAuthorized = "authorized" // Funds reserved while the seats are confirmed
+	PaymentStatusCaptured   = "captured"   // Charged for a confirmed booking
+	PaymentStatusFailed     = "failed"     // Declined by the payment gateway
+	PaymentStatusRefunded   = "refunded"   // Returned after the seats couldn't be confirmed
+)
+
+// paymentStatusTransitions lists the payment statuses each payment status may move to
+var paymentStatusTransitions = map[string][]string{
+	PaymentStatusPending:    {PaymentStatusPending, PaymentStatusAuthorized, PaymentStatusFailed},
+	PaymentStatusAuthorized: {PaymentStatusAuthorized, PaymentStatusCaptured, PaymentStatusRefunded},
+	PaymentStatusCaptured:   {PaymentStatusCaptured, PaymentStatusRefunded},
+	PaymentStatusFailed:     {},
+	PaymentStatusRefunded:   {},
+}
+
+// CanTransitionPaymentStatus reports whether a payment may move from one status to another
+func CanTransitionPaymentStatus(from, to string) bool {
+	for _, allowed := range paymentStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TerminalBookingStatuses are the statuses a booking never leaves once reached
+var TerminalBookingStatuses = []string{BookingStatusConfirmed, BookingStatusFailed}
+
+// CanTransitionBookingStatus reports whether a booking may move from one status to another
+func CanTransitionBookingStatus(from, to string) bool {
+	for _, allowed := range bookingStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether the booking has finished processing
+func (b *Booking) IsTerminal() bool {
+	for _, status := range TerminalBookingStatuses {
+		if b.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
 // ============================================================================
 // REPOSITORY DATA TRANSFER OBJECTS (Internal - no JSON tags)
 // ============================================================================
@@ -45,26 +133,37 @@ type CreateBookingRequest struct {
 	UserID        string
 	UserEmail     string
 	UserName      string
+	Locale        string
 	EventID       string
 	EventName     string
 	Venue         string
 	EventDate     time.Time
 	Seats         []string
-	TotalAmount   float64
+	Amount        AmountBreakdown
 	HoldID        string
 	PaymentMethod string
+	CallbackURL   string
 }
 
 // UpdateBookingStatusRequest represents a booking status update
 type UpdateBookingStatusRequest struct {
 	BookingID     string
 	Status        string
-	PaymentStatus string
+	PaymentStatus string // Left unchanged when empty
 	ErrorMessage  *string
 	ConfirmedAt   *time.Time
 	FailedAt      *time.Time
 }
 
+// UpdateCallbackStatusRequest records the outcome of a webhook delivery attempt
+type UpdateCallbackStatusRequest struct {
+	BookingID   string
+	Status      string
+	Attempts    int
+	LastError   *string
+	DeliveredAt *time.Time
+}
+
 // BookingFilter represents filtering options for booking queries
 type BookingFilter struct {
 	UserID string
@@ -81,36 +180,63 @@ type BookingFilter struct {
 type SubmitBookingRequest struct {
 	HoldID      string      `json:"hold_id" binding:"required"`
 	PaymentInfo PaymentInfo `json:"payment_info" binding:"required"`
+
+	// Optional URL that receives a signed POST when the booking is confirmed or fails
+	CallbackURL string `json:"callback_url"`
 }
 
 // PaymentInfo represents payment information in booking request
 type PaymentInfo struct {
 	PaymentMethod string  `json:"payment_method" binding:"required"`
 	Amount        float64 `json:"amount" binding:"required,gt=0"`
+	Currency      string  `json:"currency"` // ISO 4217 code, defaults to USD
+}
+
+// Money returns the payment amount in minor units
+func (p PaymentInfo) Money() Money {
+	return NewMoney(p.Amount, p.Currency)
 }
 
 // BookingResponse represents the API response after booking submission
 type BookingResponse struct {
-	BookingID     string `json:"booking_id"`
-	Status        string `json:"status"`
-	Message       string `json:"message"`
-	EstimatedTime string `json:"estimated_time"`
-	StatusURL     string `json:"status_url"`
-	StreamURL     string `json:"stream_url"`
+	BookingID        string `json:"booking_id"`
+	ConfirmationCode string `json:"confirmation_code"`
+	Status           string `json:"status"`
+	Message          string `json:"message"`
+	EstimatedTime    string `json:"estimated_time"`
+	EstimatedSeconds int    `json:"estimated_seconds,omitempty"`
+	StatusURL        string `json:"status_url"`
+	StreamURL        string `json:"stream_url"`
 }
 
 // BookingStatusResponse represents the detailed booking status response
 type BookingStatusResponse struct {
-	BookingID     string               `json:"booking_id"`
-	Status        string               `json:"status"`
-	Event         *BookingEventDetails `json:"event,omitempty"`
-	Seats         []string             `json:"seats,omitempty"`
-	TotalAmount   float64              `json:"total_amount,omitempty"`
-	PaymentStatus string               `json:"payment_status,omitempty"`
-	ErrorMessage  *string              `json:"error_message,omitempty"`
-	CreatedAt     time.Time            `json:"created_at"`
-	ConfirmedAt   *time.Time           `json:"confirmed_at,omitempty"`
-	FailedAt      *time.Time           `json:"failed_at,omitempty"`
+	BookingID        string               `json:"booking_id"`
+	ConfirmationCode string               `json:"confirmation_code,omitempty"`
+	Status           string               `json:"status"`
+	Event            *BookingEventDetails `json:"event,omitempty"`
+	Seats            []string             `json:"seats,omitempty"`
+	TotalAmount      float64              `json:"total_amount,omitempty"`
+	Currency         string               `json:"currency,omitempty"`
+	AmountBreakdown  *PriceBreakdown      `json:"amount_breakdown,omitempty"`
+	PaymentStatus    string               `json:"payment_status"`
+	ErrorMessage     *string              `json:"error_message,omitempty"`
+	CreatedAt        time.Time            `json:"created_at"`
+	ConfirmedAt      *time.Time           `json:"confirmed_at,omitempty"`
+	FailedAt         *time.Time           `json:"failed_at,omitempty"`
+	CallbackStatus   string               `json:"callback_status,omitempty"`
+
+	// Only set while the booking is still processing
+	EstimatedCompletion *time.Time `json:"estimated_completion,omitempty"`
+}
+
+// PriceBreakdown itemizes a booking's total in major units of its currency
+type PriceBreakdown struct {
+	Subtotal float64 `json:"subtotal"`
+	Fees     float64 `json:"fees"`
+	Tax      float64 `json:"tax"`
+	Total    float64 `json:"total"`
+	Currency string  `json:"currency"`
 }
 
 // BookingEventDetails represents event information in booking status
@@ -123,28 +249,64 @@ type BookingEventDetails struct {
 
 // UserBookingsResponse represents the list of user bookings
 type UserBookingsResponse struct {
-	Bookings []UserBookingSummary `json:"bookings"`
-	Total    int                  `json:"total"`
+	Bookings   []UserBookingSummary  `json:"bookings"`
+	Total      int                   `json:"total"` // Also in pagination, kept for older clients
+	Pagination pagination.Pagination `json:"pagination"`
+}
+
+// ReprocessBookingResponse reports whether a booking's request was queued
+// again for the worker
+type ReprocessBookingResponse struct {
+	BookingID string `json:"booking_id"`
+	Status    string `json:"status"`
+	Requeued  bool   `json:"requeued"`
+	Message   string `json:"message"`
+}
+
+// AnonymizeUserBookingsResponse reports how many bookings had personal data removed
+type AnonymizeUserBookingsResponse struct {
+	AnonymizedBookings int `json:"anonymized_bookings"`
+}
+
+// BookingCountsResponse reports how many bookings a user has in each status
+type BookingCountsResponse struct {
+	Counts map[string]int `json:"counts"`
+	Total  int            `json:"total"`
 }
 
 // UserBookingSummary represents a summary of user booking for listing
 type UserBookingSummary struct {
-	BookingID   string    `json:"booking_id"`
-	Status      string    `json:"status"`
-	EventName   string    `json:"event_name"`
-	Venue       string    `json:"venue"`
-	EventDate   time.Time `json:"event_date"`
-	Seats       []string  `json:"seats"`
-	TotalAmount float64   `json:"total_amount"`
-	CreatedAt   time.Time `json:"created_at"`
+	BookingID        string    `json:"booking_id"`
+	ConfirmationCode string    `json:"confirmation_code,omitempty"`
+	Status           string    `json:"status"`
+	EventName        string    `json:"event_name"`
+	Venue            string    `json:"venue"`
+	EventDate        time.Time `json:"event_date"`
+	Seats            []string  `json:"seats"`
+	TotalAmount      float64   `json:"total_amount"`
+	Currency         string    `json:"currency"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// BatchBookingStatusRequest represents a request for the status of several bookings
+type BatchBookingStatusRequest struct {
+	BookingIDs []string `json:"booking_ids" binding:"required,min=1"`
+}
+
+// BatchBookingStatusResponse maps booking IDs to their current status.
+// Bookings that don't exist or aren't owned by the caller are omitted.
+type BatchBookingStatusResponse struct {
+	Statuses map[string]BookingStatusUpdate `json:"statuses"`
 }
 
 // BookingStatusUpdate represents real-time status updates for SSE
 type BookingStatusUpdate struct {
-	BookingID string    `json:"booking_id"`
-	Status    string    `json:"status"`
-	Message   string    `json:"message"`
-	UpdatedAt time.Time `json:"updated_at"`
+	BookingID     string    `json:"booking_id"`
+	UserID        string    `json:"user_id,omitempty"` // Owner, used to authorize cached status reads
+	Status        string    `json:"status"`
+	PaymentStatus string    `json:"payment_status,omitempty"`
+	Message       string    `json:"message"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 // HealthResponse represents the health check response
@@ -154,10 +316,18 @@ type HealthResponse struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
-// ErrorResponse represents error response structure
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
+// AmountMismatchDetails reports the authoritative price when a submitted payment doesn't match the hold
+type AmountMismatchDetails struct {
+	ExpectedAmount  float64         `json:"expected_amount"`
+	SubmittedAmount float64         `json:"submitted_amount"`
+	Currency        string          `json:"currency"`
+	Breakdown       *PriceBreakdown `json:"breakdown"`
+}
+
+// CurrencyMismatchDetails reports the event's currency when a payment is submitted in another one
+type CurrencyMismatchDetails struct {
+	ExpectedCurrency  string `json:"expected_currency"`
+	SubmittedCurrency string `json:"submitted_currency"`
 }
 
 // ============================================================================
@@ -166,18 +336,23 @@ type ErrorResponse struct {
 
 // BookingRequest represents the message sent to Kafka booking topic
 type BookingRequest struct {
-	BookingID   string      `json:"booking_id"`
-	UserID      string      `json:"user_id"`
-	UserEmail   string      `json:"user_email"`
-	UserName    string      `json:"user_name"`
-	HoldID      string      `json:"hold_id"`
-	EventID     string      `json:"event_id"`
-	EventName   string      `json:"event_name"`
-	Venue       string      `json:"venue"`
-	EventDate   time.Time   `json:"event_date"`
-	Seats       []string    `json:"seats"`
-	PaymentInfo PaymentInfo `json:"payment_info"`
-	Timestamp   time.Time   `json:"timestamp"`
+	BookingID        string      `json:"booking_id"`
+	ConfirmationCode string      `json:"confirmation_code"`
+	UserID           string      `json:"user_id"`
+	UserEmail        string      `json:"user_email"`
+	UserName         string      `json:"user_name"`
+	Locale           string      `json:"locale,omitempty"`
+	HoldID           string      `json:"hold_id"`
+	EventID          string      `json:"event_id"`
+	EventName        string      `json:"event_name"`
+	Venue            string      `json:"venue"`
+	EventDate        time.Time   `json:"event_date"`
+	Seats            []string    `json:"seats"`
+	PaymentInfo      PaymentInfo `json:"payment_info"` // Amount is the total
+	Timestamp        time.Time   `json:"timestamp"`
+
+	// Missing from messages queued before fees and tax were itemized
+	AmountBreakdown *PriceBreakdown `json:"amount_breakdown,omitempty"`
 }
 
 // NotificationRequest represents the message sent to notification topic
@@ -186,17 +361,25 @@ type NotificationRequest struct {
 	RecipientEmail string                  `json:"recipient_email"`
 	BookingData    NotificationBookingData `json:"booking_data"`
 	Timestamp      time.Time               `json:"timestamp"`
+	Locale         string                  `json:"locale,omitempty"`
 }
 
 // NotificationBookingData represents booking data for notifications
 type NotificationBookingData struct {
-	BookingID   string    `json:"booking_id"`
-	EventName   string    `json:"event_name"`
-	Venue       string    `json:"venue"`
-	EventDate   time.Time `json:"event_date"`
-	Seats       []string  `json:"seats"`
-	TotalAmount float64   `json:"total_amount"`
-	UserName    string    `json:"user_name"`
+	BookingID        string    `json:"booking_id"`
+	ConfirmationCode string    `json:"confirmation_code"`
+	EventName        string    `json:"event_name"`
+	Venue            string    `json:"venue"`
+	EventDate        time.Time `json:"event_date"`
+	Seats            []string  `json:"seats"`
+	TotalAmount      float64   `json:"total_amount"`
+	Currency         string    `json:"currency"`
+	UserName         string    `json:"user_name"`
+
+	// Parts of TotalAmount, left out when the booking has no breakdown
+	Subtotal float64 `json:"subtotal,omitempty"`
+	Fees     float64 `json:"fees,omitempty"`
+	Tax      float64 `json:"tax,omitempty"`
 }
 
 // ============================================================================
@@ -206,16 +389,18 @@ type NotificationBookingData struct {
 // ToBookingStatusResponse converts a Booking entity to a status response
 func (b *Booking) ToBookingStatusResponse() *BookingStatusResponse {
 	response := &BookingStatusResponse{
-		BookingID:     b.ID,
-		Status:        b.Status,
-		PaymentStatus: b.PaymentStatus,
-		CreatedAt:     b.CreatedAt,
-		ConfirmedAt:   b.ConfirmedAt,
-		FailedAt:      b.FailedAt,
-		ErrorMessage:  b.ErrorMessage,
+		BookingID:        b.ID,
+		ConfirmationCode: b.ConfirmationCode,
+		Status:           b.Status,
+		PaymentStatus:    b.PaymentStatus,
+		CreatedAt:        b.CreatedAt,
+		ConfirmedAt:      b.ConfirmedAt,
+		FailedAt:         b.FailedAt,
+		ErrorMessage:     b.ErrorMessage,
+		CallbackStatus:   b.CallbackStatus,
 	}
 
-	if b.Status == "confirmed" || b.Status == "processing" {
+	if b.Status == BookingStatusConfirmed || b.Status == BookingStatusProcessing {
 		response.Event = &BookingEventDetails{
 			EventID:   b.EventID,
 			Name:      b.EventName,
@@ -223,22 +408,122 @@ func (b *Booking) ToBookingStatusResponse() *BookingStatusResponse {
 			EventDate: b.EventDate,
 		}
 		response.Seats = b.Seats
-		response.TotalAmount = b.TotalAmount
+		response.TotalAmount = FromMinorUnits(b.TotalAmountCents)
+		response.Currency = b.Currency
+		response.AmountBreakdown = b.AmountBreakdown().ToPriceBreakdown()
 	}
 
 	return response
 }
 
+// AmountBreakdown returns the itemized price stored on the booking
+func (b *Booking) AmountBreakdown() AmountBreakdown {
+	return AmountBreakdown{
+		Subtotal: Money{Amount: b.SubtotalCents, Currency: b.Currency},
+		Fees:     Money{Amount: b.FeesCents, Currency: b.Currency},
+		Tax:      Money{Amount: b.TaxCents, Currency: b.Currency},
+		Total:    Money{Amount: b.TotalAmountCents, Currency: b.Currency},
+	}
+}
+
+// ToPriceBreakdown converts the breakdown for API responses and messages
+func (a AmountBreakdown) ToPriceBreakdown() *PriceBreakdown {
+	return &PriceBreakdown{
+		Subtotal: a.Subtotal.Decimal(),
+		Fees:     a.Fees.Decimal(),
+		Tax:      a.Tax.Decimal(),
+		Total:    a.Total.Decimal(),
+		Currency: a.Total.Currency,
+	}
+}
+
+// ToBookingRequest rebuilds the message queued for the worker when the booking
+// was submitted, timestamped now
+func (b *Booking) ToBookingRequest() BookingRequest {
+	amount := b.AmountBreakdown()
+	return BookingRequest{
+		BookingID:        b.ID,
+		ConfirmationCode: b.ConfirmationCode,
+		UserID:           b.UserID,
+		UserEmail:        b.UserEmail,
+		UserName:         b.UserName,
+		Locale:           b.Locale,
+		HoldID:           b.HoldID,
+		EventID:          b.EventID,
+		EventName:        b.EventName,
+		Venue:            b.Venue,
+		EventDate:        b.EventDate,
+		Seats:            b.Seats,
+		PaymentInfo: PaymentInfo{
+			PaymentMethod: b.PaymentMethod,
+			Amount:        amount.Total.Decimal(),
+			Currency:      amount.Total.Currency,
+		},
+		Timestamp:       time.Now().UTC(),
+		AmountBreakdown: amount.ToPriceBreakdown(),
+	}
+}
+
+// ToNotificationRequest builds a notification message for this booking
+func (b *Booking) ToNotificationRequest(notificationType string) *NotificationRequest {
+	return &NotificationRequest{
+		Type:           notificationType,
+		RecipientEmail: b.UserEmail,
+		BookingData: NotificationBookingData{
+			BookingID:        b.ID,
+			ConfirmationCode: b.ConfirmationCode,
+			EventName:        b.EventName,
+			Venue:            b.Venue,
+			EventDate:        b.EventDate,
+			Seats:            b.Seats,
+			TotalAmount:      FromMinorUnits(b.TotalAmountCents),
+			Currency:         b.Currency,
+			UserName:         b.UserName,
+			Subtotal:         FromMinorUnits(b.SubtotalCents),
+			Fees:             FromMinorUnits(b.FeesCents),
+			Tax:              FromMinorUnits(b.TaxCents),
+		},
+		Timestamp: time.Now().UTC(),
+		Locale:    b.Locale,
+	}
+}
+
+// ToBookingStatusUpdate converts a Booking entity to a status update
+func (b *Booking) ToBookingStatusUpdate() BookingStatusUpdate {
+	message := fmt.Sprintf("Current status: %s", b.Status)
+	if b.ErrorMessage != nil {
+		message = *b.ErrorMessage
+	}
+
+	updatedAt := b.CreatedAt
+	if b.ConfirmedAt != nil {
+		updatedAt = *b.ConfirmedAt
+	} else if b.FailedAt != nil {
+		updatedAt = *b.FailedAt
+	}
+
+	return BookingStatusUpdate{
+		BookingID:     b.ID,
+		UserID:        b.UserID,
+		Status:        b.Status,
+		PaymentStatus: b.PaymentStatus,
+		Message:       message,
+		UpdatedAt:     updatedAt,
+	}
+}
+
 // ToUserBookingSummary converts a Booking entity to a user booking summary
 func (b *Booking) ToUserBookingSummary() UserBookingSummary {
 	return UserBookingSummary{
-		BookingID:   b.ID,
-		Status:      b.Status,
-		EventName:   b.EventName,
-		Venue:       b.Venue,
-		EventDate:   b.EventDate,
-		Seats:       b.Seats,
-		TotalAmount: b.TotalAmount,
-		CreatedAt:   b.CreatedAt,
+		BookingID:        b.ID,
+		ConfirmationCode: b.ConfirmationCode,
+		Status:           b.Status,
+		EventName:        b.EventName,
+		Venue:            b.Venue,
+		EventDate:        b.EventDate,
+		Seats:            b.Seats,
+		TotalAmount:      FromMinorUnits(b.TotalAmountCents),
+		Currency:         b.Currency,
+		CreatedAt:        b.CreatedAt,
 	}
 }
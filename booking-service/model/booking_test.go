@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCanTransitionPaymentStatus(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		// The worker's flow: authorize, then capture or refund
+		{PaymentStatusPending, PaymentStatusAuthorized, true},
+		{PaymentStatusPending, PaymentStatusFailed, true},
+		{PaymentStatusAuthorized, PaymentStatusCaptured, true},
+		{PaymentStatusAuthorized, PaymentStatusRefunded, true},
+		{PaymentStatusCaptured, PaymentStatusRefunded, true},
+
+		// Redelivered messages repeat the current status
+		{PaymentStatusPending, PaymentStatusPending, true},
+		{PaymentStatusAuthorized, PaymentStatusAuthorized, true},
+		{PaymentStatusCaptured, PaymentStatusCaptured, true},
+
+		{PaymentStatusPending, PaymentStatusCaptured, false},
+		{PaymentStatusPending, PaymentStatusRefunded, false},
+		{PaymentStatusAuthorized, PaymentStatusPending, false},
+		{PaymentStatusAuthorized, PaymentStatusFailed, false},
+		{PaymentStatusCaptured, PaymentStatusAuthorized, false},
+		{PaymentStatusFailed, PaymentStatusAuthorized, false},
+		{PaymentStatusFailed, PaymentStatusFailed, false},
+		{PaymentStatusRefunded, PaymentStatusCaptured, false},
+		{PaymentStatusRefunded, PaymentStatusRefunded, false},
+		{"refund_pending", PaymentStatusRefunded, false},
+		{PaymentStatusPending, "completed", false},
+	}
+	for _, tt := range tests {
+		if got := CanTransitionPaymentStatus(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionPaymentStatus(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestBookingStatusResponseIncludesPaymentStatus(t *testing.T) {
+	booking := &Booking{ID: "booking-1", Status: BookingStatusFailed, PaymentStatus: PaymentStatusRefunded}
+
+	body, err := json.Marshal(booking.ToBookingStatusResponse())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"payment_status":"refunded"`) {
+		t.Errorf("status response %s lacks payment_status", body)
+	}
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+)
+
+// ConfirmationCodeLength is the number of characters in a confirmation code.
+// Five random bytes encode to exactly eight base32 characters.
+const ConfirmationCodeLength = 8
+
+// GenerateConfirmationCode returns a random, human-friendly booking reference
+// such as "K7QX2MZA" using the RFC 4648 base32 alphabet (A-Z, 2-7)
+func GenerateConfirmationCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(b), nil
+}
+
+// NormalizeConfirmationCode trims and upper-cases a code as typed by a user
+func NormalizeConfirmationCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
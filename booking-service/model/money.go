@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultCurrency is used when a request doesn't specify a currency
+const DefaultCurrency = "USD"
+
+// SupportedCurrencies lists the ISO 4217 codes accepted for bookings and payments.
+// All of them use two decimal places of minor units.
+var SupportedCurrencies = []string{"USD", "EUR", "GBP", "CAD", "AUD", "INR"}
+
+// Money represents an amount in integer minor units (e.g. cents) of a currency,
+// avoiding floating-point drift when computing totals
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// NewMoney creates a Money value from a decimal amount as received over the API
+func NewMoney(amount float64, currency string) Money {
+	return Money{Amount: ToMinorUnits(amount), Currency: NormalizeCurrency(currency)}
+}
+
+// Multiply returns the amount multiplied by a quantity (e.g. number of seats)
+func (m Money) Multiply(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Decimal returns the amount in major units for API responses
+func (m Money) Decimal() float64 {
+	return FromMinorUnits(m.Amount)
+}
+
+// String formats the amount with its currency code, e.g. "12.50 USD"
+func (m Money) String() string {
+	return fmt.Sprintf("%.2f %s", m.Decimal(), m.Currency)
+}
+
+// AmountBreakdown itemizes the price of a booking. Total is what the customer pays.
+type AmountBreakdown struct {
+	Subtotal Money // Seat prices
+	Fees     Money
+	Tax      Money
+	Total    Money
+}
+
+// PriceRules are the service fees and tax charged on top of the seat prices.
+// Rates are in basis points, so 250 is 2.5%.
+type PriceRules struct {
+	FeePerSeatCents    int64
+	FeeRateBasisPoints int64
+	TaxRateBasisPoints int64
+}
+
+// Apply computes the breakdown for seats costing subtotal in total. Fees are
+// the per-seat fee plus a share of the subtotal; tax is charged on the subtotal
+// and fees together. Each percentage is rounded half up to a whole minor unit
+// before summing, so the parts always add up to the total.
+func (r PriceRules) Apply(subtotal Money, seats int) AmountBreakdown {
+	fees := r.FeePerSeatCents*int64(seats) + percentOf(subtotal.Amount, r.FeeRateBasisPoints)
+	tax := percentOf(subtotal.Amount+fees, r.TaxRateBasisPoints)
+	return AmountBreakdown{
+		Subtotal: subtotal,
+		Fees:     Money{Amount: fees, Currency: subtotal.Currency},
+		Tax:      Money{Amount: tax, Currency: subtotal.Currency},
+		Total:    Money{Amount: subtotal.Amount + fees + tax, Currency: subtotal.Currency},
+	}
+}
+
+// percentOf returns basisPoints/10000 of a non-negative amount, rounded half up
+func percentOf(amount, basisPoints int64) int64 {
+	return (amount*basisPoints + 5000) / 10000
+}
+
+// ToMinorUnits converts a decimal amount to minor units, rounding to the nearest unit
+func ToMinorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// FromMinorUnits converts minor units to a decimal amount
+func FromMinorUnits(units int64) float64 {
+	return float64(units) / 100
+}
+
+// NormalizeCurrency upper-cases a currency code, defaulting to DefaultCurrency when empty
+func NormalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency
+	}
+	return code
+}
+
+// IsSupportedCurrency reports whether the currency code is in the allowlist
+func IsSupportedCurrency(code string) bool {
+	for _, supported := range SupportedCurrencies {
+		if supported == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package model
+
+import "testing"
+
+func TestToMinorUnits(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   int64
+	}{
+		{0, 0},
+		{12.5, 1250},
+		{0.1 + 0.2, 30},
+		{19.999, 2000},
+	}
+	for _, tt := range tests {
+		if got := ToMinorUnits(tt.amount); got != tt.want {
+			t.Errorf("ToMinorUnits(%v) = %d, want %d", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyMultiplyKeepsMinorUnits(t *testing.T) {
+	price := NewMoney(0.1, "usd")
+	total := price.Multiply(3)
+	if total.Amount != 30 || total.Currency != "USD" {
+		t.Fatalf("Multiply(3) = %+v, want 30 USD", total)
+	}
+	if total.Decimal() != 0.3 {
+		t.Errorf("Decimal() = %v, want 0.3", total.Decimal())
+	}
+	if total.String() != "0.30 USD" {
+		t.Errorf("String() = %q, want %q", total.String(), "0.30 USD")
+	}
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	tests := map[string]string{
+		"":      DefaultCurrency,
+		"  ":    DefaultCurrency,
+		"eur":   "EUR",
+		" gbp ": "GBP",
+	}
+	for code, want := range tests {
+		if got := NormalizeCurrency(code); got != want {
+			t.Errorf("NormalizeCurrency(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestIsSupportedCurrency(t *testing.T) {
+	for _, code := range SupportedCurrencies {
+		if !IsSupportedCurrency(code) {
+			t.Errorf("IsSupportedCurrency(%q) = false", code)
+		}
+	}
+	for _, code := range []string{"", "usd", "XYZ", "BTC"} {
+		if IsSupportedCurrency(code) {
+			t.Errorf("IsSupportedCurrency(%q) = true", code)
+		}
+	}
+}
+
+func TestPercentOfRoundsHalfUp(t *testing.T) {
+	tests := []struct {
+		amount, basisPoints, want int64
+	}{
+		{10000, 1000, 1000}, // 10% of 100.00
+		{1005, 1000, 101},   // 10.05 rounds up to 1.01
+		{1004, 1000, 100},   // 10.04 rounds down to 1.00
+		{333, 5000, 167},    // exactly half a cent rounds up
+		{12345, 0, 0},
+		{0, 2000, 0},
+	}
+	for _, tt := range tests {
+		if got := percentOf(tt.amount, tt.basisPoints); got != tt.want {
+			t.Errorf("percentOf(%d, %d) = %d, want %d", tt.amount, tt.basisPoints, got, tt.want)
+		}
+	}
+}
+
+func TestPriceRulesApply(t *testing.T) {
+	tests := []struct {
+		name                     string
+		rules                    PriceRules
+		subtotal                 int64
+		seats                    int
+		wantFees, wantTax, total int64
+	}{
+		{"no fees or tax", PriceRules{}, 5000, 2, 0, 0, 5000},
+		{"per-seat fee", PriceRules{FeePerSeatCents: 150}, 5000, 2, 300, 0, 5300},
+		{"fee rate", PriceRules{FeeRateBasisPoints: 250}, 4999, 1, 125, 0, 5124},
+		// Tax applies to the fees too: 8.25% of 53.00 is 4.3725, rounded to 4.37
+		{"tax on subtotal and fees", PriceRules{FeePerSeatCents: 150, TaxRateBasisPoints: 825}, 5000, 2, 300, 437, 5737},
+		{"everything", PriceRules{FeePerSeatCents: 99, FeeRateBasisPoints: 300, TaxRateBasisPoints: 2000}, 3333, 3, 397, 746, 4476},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rules.Apply(Money{Amount: tt.subtotal, Currency: "EUR"}, tt.seats)
+			if got.Subtotal.Amount != tt.subtotal || got.Fees.Amount != tt.wantFees || got.Tax.Amount != tt.wantTax || got.Total.Amount != tt.total {
+				t.Errorf("Apply() = %+v, want fees %d, tax %d, total %d", got, tt.wantFees, tt.wantTax, tt.total)
+			}
+			if got.Subtotal.Amount+got.Fees.Amount+got.Tax.Amount != got.Total.Amount {
+				t.Errorf("parts of %+v don't add up to the total", got)
+			}
+			for _, part := range []Money{got.Subtotal, got.Fees, got.Tax, got.Total} {
+				if part.Currency != "EUR" {
+					t.Errorf("currency = %q, want EUR", part.Currency)
+				}
+			}
+		})
+	}
+}
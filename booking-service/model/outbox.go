@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// OutboxMessage is a Kafka message recorded in the same transaction as the
+// change that produced it. The outbox relay publishes unsent rows and marks
+// them sent, so messages survive a crash between the commit and the publish.
+type OutboxMessage struct {
+	ID        string     `gorm:"primary_key;default:gen_random_uuid()"`
+	Topic     string     `gorm:"type:varchar(255);not null"`
+	Key       string     `gorm:"type:varchar(255)"`
+	Payload   []byte     `gorm:"type:bytea;not null"`
+	Attempts  int        `gorm:"not null;default:0"`
+	LastError *string    `gorm:"type:text"`
+	CreatedAt time.Time  `gorm:"default:CURRENT_TIMESTAMP;index"`
+	SentAt    *time.Time `gorm:"index"`
+}
+
+// TableName sets the table name for GORM
+func (OutboxMessage) TableName() string {
+	return "booking_outbox"
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// Callback delivery states for Booking.CallbackStatus. Bookings submitted
+// without a callback_url leave the status empty.
+const (
+	CallbackStatusPending   = "pending"
+	CallbackStatusDelivered = "delivered"
+	CallbackStatusFailed    = "failed"
+)
+
+// WebhookPayload is the body POSTed to a booking's callback URL once it is
+// confirmed or fails
+type WebhookPayload struct {
+	Event            string     `json:"event"` // booking.confirmed or booking.failed
+	BookingID        string     `json:"booking_id"`
+	ConfirmationCode string     `json:"confirmation_code"`
+	Status           string     `json:"status"`
+	PaymentStatus    string     `json:"payment_status"`
+	ErrorMessage     *string    `json:"error_message,omitempty"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	FailedAt         *time.Time `json:"failed_at,omitempty"`
+	Timestamp        time.Time  `json:"timestamp"`
+}
+
+// ToWebhookPayload builds the callback body for this booking's current status
+func (b *Booking) ToWebhookPayload() *WebhookPayload {
+	return &WebhookPayload{
+		Event:            "booking." + b.Status,
+		BookingID:        b.ID,
+		ConfirmationCode: b.ConfirmationCode,
+		Status:           b.Status,
+		PaymentStatus:    b.PaymentStatus,
+		ErrorMessage:     b.ErrorMessage,
+		ConfirmedAt:      b.ConfirmedAt,
+		FailedAt:         b.FailedAt,
+		Timestamp:        time.Now().UTC(),
+	}
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "errors"
+
+// Errors returned by BookingRepository implementations. They may be wrapped
+// with more context, so match them with errors.Is rather than their message.
+var (
+	ErrBookingNotFound = errors.New("booking not found")
+
+	// ErrInvalidTransition is wrapped with the rejected from and to statuses
+	ErrInvalidTransition = errors.New("invalid status transition")
+)
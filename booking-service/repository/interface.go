@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/arunvm123/eventbooking/booking-service/model"
 	"gorm.io/gorm"
 )
@@ -9,10 +11,31 @@ import (
 type BookingRepository interface {
 	// Booking operations
 	CreateBooking(req model.CreateBookingRequest) (*model.Booking, error)
+	// CreateBookingWithOutbox creates a booking and the Kafka message built from it
+	// in one transaction, so the message is published even if the process crashes
+	CreateBookingWithOutbox(req model.CreateBookingRequest, buildMessage func(booking *model.Booking) (*model.OutboxMessage, error)) (*model.Booking, error)
 	GetBookingByID(bookingID string) (*model.Booking, error)
 	GetBookingByHoldID(holdID string) (*model.Booking, error)
+	GetBookingByConfirmationCode(code string) (*model.Booking, error)
+	GetUserBookingsByIDs(userID string, bookingIDs []string) ([]model.Booking, error)
 	UpdateBookingStatus(req model.UpdateBookingStatusRequest) error
+	UpdateCallbackStatus(req model.UpdateCallbackStatusRequest) error
+	// ListPendingCallbackIDs returns finished bookings whose webhook hasn't
+	// been delivered or given up on, e.g. because the worker stopped mid-retry
+	ListPendingCallbackIDs() ([]string, error)
 	ListUserBookings(filter model.BookingFilter) ([]model.Booking, int, error)
+	// CountUserBookingsByStatus returns the number of the user's bookings in
+	// each status. Statuses without bookings are absent from the map.
+	CountUserBookingsByStatus(userID string) (map[string]int, error)
+	// AnonymizeUserBookings replaces the email and name on all of a user's
+	// bookings with placeholders and returns how many were changed
+	AnonymizeUserBookings(userID string) (int, error)
+
+	// Outbox relay
+	// RelayOutbox locks up to limit unsent messages, passes them to publish and
+	// marks them sent if publish succeeds. Rows locked by another relay are skipped.
+	RelayOutbox(limit int, publish func(messages []model.OutboxMessage) error) (int, error)
+	DeleteSentOutboxMessages(sentBefore time.Time) (int64, error)
 
 	// Health check
 	GetDB() *gorm.DB
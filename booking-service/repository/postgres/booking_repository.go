@@ -2,22 +2,41 @@ package postgres
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/arunvm123/eventbooking/booking-service/config"
 	"github.com/arunvm123/eventbooking/booking-service/model"
+	"github.com/arunvm123/eventbooking/booking-service/repository"
+	"github.com/arunvm123/eventbooking/shared/startup"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// maxConfirmationCodeAttempts bounds retries when a generated code collides
+const maxConfirmationCodeAttempts = 5
+
+// utcNow timestamps created and updated rows in UTC
+func utcNow() time.Time {
+	return time.Now().UTC()
+}
+
 type PostgresBookingRepository struct {
 	db *gorm.DB
 }
 
-func NewBookingRepository(cfg *config.Database) (*PostgresBookingRepository, error) {
+// NewBookingRepository connects to the database, retrying within the retry
+// budget while it isn't accepting connections yet
+func NewBookingRepository(cfg *config.Database, retry startup.Retry) (*PostgresBookingRepository, error) {
 	// Open database connection
-	db, err := gorm.Open(postgres.Open(cfg.GetDatabaseURL()), &gorm.Config{})
+	var db *gorm.DB
+	err := retry.Connect("postgres", func() (err error) {
+		db, err = gorm.Open(postgres.Open(cfg.GetDatabaseURL()), &gorm.Config{TranslateError: true, NowFunc: utcNow})
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -32,13 +51,113 @@ func NewBookingRepository(cfg *config.Database) (*PostgresBookingRepository, err
 	configureConnectionPool(sqlDB, cfg)
 
 	// Auto-migrate the booking table
-	if err := db.AutoMigrate(&model.Booking{}); err != nil {
+	if err := db.AutoMigrate(&model.Booking{}, &model.OutboxMessage{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// Move legacy float amounts to integer minor units
+	if err := migrateLegacyAmounts(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate legacy booking amounts: %w", err)
+	}
+
+	// Bookings made before fees and tax were itemized cost just their seats
+	if err := db.Exec(`UPDATE bookings SET subtotal_cents = total_amount_cents
+		WHERE subtotal_cents = 0 AND fees_cents = 0 AND tax_cents = 0 AND total_amount_cents <> 0`).Error; err != nil {
+		return nil, fmt.Errorf("failed to backfill booking subtotals: %w", err)
+	}
+
+	// Map payment statuses written by older workers onto the current set
+	if err := migrateLegacyPaymentStatuses(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate legacy payment statuses: %w", err)
+	}
+
+	// Give bookings created before confirmation codes existed a code of their own
+	if err := backfillConfirmationCodes(db); err != nil {
+		return nil, fmt.Errorf("failed to backfill confirmation codes: %w", err)
+	}
+
 	return &PostgresBookingRepository{db: db}, nil
 }
 
+// backfillConfirmationCodes assigns codes to bookings that don't have one yet
+func backfillConfirmationCodes(db *gorm.DB) error {
+	var bookingIDs []string
+	if err := db.Model(&model.Booking{}).
+		Where("confirmation_code IS NULL OR confirmation_code = ''").
+		Pluck("id", &bookingIDs).Error; err != nil {
+		return err
+	}
+
+	for _, bookingID := range bookingIDs {
+		if err := withUniqueConfirmationCode(func(code string) error {
+			return db.Model(&model.Booking{}).Where("id = ?", bookingID).
+				Update("confirmation_code", code).Error
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withUniqueConfirmationCode calls write with freshly generated codes until one
+// doesn't collide with an existing booking
+func withUniqueConfirmationCode(write func(code string) error) error {
+	for attempt := 0; attempt < maxConfirmationCodeAttempts; attempt++ {
+		code, err := model.GenerateConfirmationCode()
+		if err != nil {
+			return fmt.Errorf("failed to generate confirmation code: %w", err)
+		}
+
+		err = write(code)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			continue
+		}
+		return err
+	}
+
+	return fmt.Errorf("could not generate a unique confirmation code after %d attempts", maxConfirmationCodeAttempts)
+}
+
+// migrateLegacyAmounts backfills total_amount_cents from the legacy decimal
+// total_amount column and then drops it
+func migrateLegacyAmounts(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&model.Booking{}, "total_amount") {
+		return nil
+	}
+
+	if err := db.Exec(`UPDATE bookings SET total_amount_cents = ROUND(total_amount * 100)
+		WHERE total_amount_cents = 0`).Error; err != nil {
+		return err
+	}
+
+	return db.Migrator().DropColumn(&model.Booking{}, "total_amount")
+}
+
+// migrateLegacyPaymentStatuses rewrites the ad hoc payment statuses used before
+// the payment status set was defined. Bookings left refund_pending may never
+// have been refunded, and no status says so, so they are kept as they are and
+// reported for someone to check with the payment gateway.
+func migrateLegacyPaymentStatuses(db *gorm.DB) error {
+	if err := db.Exec(`UPDATE bookings SET payment_status = CASE payment_status
+			WHEN 'payment' THEN ?
+			WHEN 'completed' THEN ?
+		END
+		WHERE payment_status IN ('payment', 'completed')`,
+		model.PaymentStatusPending, model.PaymentStatusCaptured).Error; err != nil {
+		return err
+	}
+
+	var refundPending int64
+	if err := db.Model(&model.Booking{}).Where("payment_status = 'refund_pending'").Count(&refundPending).Error; err != nil {
+		return err
+	}
+	if refundPending > 0 {
+		log.Printf("%d bookings have the legacy payment status refund_pending; check their refunds with the payment gateway and update them by hand", refundPending)
+	}
+	return nil
+}
+
 // configureConnectionPool sets up database connection pooling
 func configureConnectionPool(sqlDB *sql.DB, cfg *config.Database) {
 	// Set maximum number of open connections
@@ -56,35 +175,144 @@ func configureConnectionPool(sqlDB *sql.DB, cfg *config.Database) {
 
 // CreateBooking creates a new booking record
 func (r *PostgresBookingRepository) CreateBooking(req model.CreateBookingRequest) (*model.Booking, error) {
+	booking := newBooking(req)
+
+	// Confirmation codes are random, so retry with a new one on the rare collision
+	if err := withUniqueConfirmationCode(func(code string) error {
+		booking.ConfirmationCode = code
+		return r.db.Create(booking).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create booking: %w", err)
+	}
+
+	return booking, nil
+}
+
+// newBooking builds a processing booking entity from a create request
+func newBooking(req model.CreateBookingRequest) *model.Booking {
 	booking := &model.Booking{
-		UserID:        req.UserID,
-		UserEmail:     req.UserEmail,
-		UserName:      req.UserName,
-		EventID:       req.EventID,
-		EventName:     req.EventName,
-		Venue:         req.Venue,
-		EventDate:     req.EventDate,
-		Seats:         req.Seats,
-		TotalAmount:   req.TotalAmount,
-		Status:        "processing",
-		PaymentStatus: "pending",
-		HoldID:        req.HoldID,
-	}
-
-	if err := r.db.Create(booking).Error; err != nil {
+		UserID:           req.UserID,
+		UserEmail:        req.UserEmail,
+		UserName:         req.UserName,
+		Locale:           req.Locale,
+		EventID:          req.EventID,
+		EventName:        req.EventName,
+		Venue:            req.Venue,
+		EventDate:        req.EventDate,
+		Seats:            req.Seats,
+		SubtotalCents:    req.Amount.Subtotal.Amount,
+		FeesCents:        req.Amount.Fees.Amount,
+		TaxCents:         req.Amount.Tax.Amount,
+		TotalAmountCents: req.Amount.Total.Amount,
+		Currency:         req.Amount.Total.Currency,
+		Status:           model.BookingStatusProcessing,
+		PaymentStatus:    model.PaymentStatusPending,
+		HoldID:           req.HoldID,
+		PaymentMethod:    req.PaymentMethod,
+		CallbackURL:      req.CallbackURL,
+	}
+	if req.CallbackURL != "" {
+		booking.CallbackStatus = model.CallbackStatusPending
+	}
+	return booking
+}
+
+// CreateBookingWithOutbox creates a booking and its outbox message in a single transaction
+func (r *PostgresBookingRepository) CreateBookingWithOutbox(
+	req model.CreateBookingRequest,
+	buildMessage func(booking *model.Booking) (*model.OutboxMessage, error),
+) (*model.Booking, error) {
+	var booking *model.Booking
+
+	// A confirmation code collision aborts the transaction, so retry it as a whole
+	err := withUniqueConfirmationCode(func(code string) error {
+		return r.db.Transaction(func(tx *gorm.DB) error {
+			booking = newBooking(req)
+			booking.ConfirmationCode = code
+			if err := tx.Create(booking).Error; err != nil {
+				return err
+			}
+
+			message, err := buildMessage(booking)
+			if err != nil {
+				return err
+			}
+			return tx.Create(message).Error
+		})
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to create booking: %w", err)
 	}
 
 	return booking, nil
 }
 
+// RelayOutbox publishes unsent outbox messages in creation order and marks them sent
+func (r *PostgresBookingRepository) RelayOutbox(limit int, publish func(messages []model.OutboxMessage) error) (int, error) {
+	var relayed int
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var messages []model.OutboxMessage
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("sent_at IS NULL").
+			Order("created_at ASC").
+			Limit(limit).
+			Find(&messages).Error; err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(messages))
+		for i, message := range messages {
+			ids[i] = message.ID
+		}
+
+		if publishErr := publish(messages); publishErr != nil {
+			// Record the failure but commit so the attempt count is kept; rows stay unsent
+			errMsg := publishErr.Error()
+			if err := tx.Model(&model.OutboxMessage{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": errMsg,
+			}).Error; err != nil {
+				return err
+			}
+			return nil
+		}
+
+		if err := tx.Model(&model.OutboxMessage{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"attempts": gorm.Expr("attempts + 1"),
+			"sent_at":  time.Now().UTC(),
+		}).Error; err != nil {
+			return err
+		}
+		relayed = len(messages)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to relay outbox: %w", err)
+	}
+
+	return relayed, nil
+}
+
+// DeleteSentOutboxMessages removes messages that were published before the cutoff
+func (r *PostgresBookingRepository) DeleteSentOutboxMessages(sentBefore time.Time) (int64, error) {
+	result := r.db.Where("sent_at IS NOT NULL AND sent_at < ?", sentBefore).Delete(&model.OutboxMessage{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete sent outbox messages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
 // GetBookingByID retrieves a booking by its ID
 func (r *PostgresBookingRepository) GetBookingByID(bookingID string) (*model.Booking, error) {
 	var booking model.Booking
 	err := r.db.Where("id = ?", bookingID).First(&booking).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("booking not found")
+			return nil, repository.ErrBookingNotFound
 		}
 		return nil, fmt.Errorf("failed to get booking: %w", err)
 	}
@@ -98,7 +326,7 @@ func (r *PostgresBookingRepository) GetBookingByHoldID(holdID string) (*model.Bo
 	err := r.db.Where("hold_id = ?", holdID).First(&booking).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("booking not found")
+			return nil, repository.ErrBookingNotFound
 		}
 		return nil, fmt.Errorf("failed to get booking by hold ID: %w", err)
 	}
@@ -106,11 +334,39 @@ func (r *PostgresBookingRepository) GetBookingByHoldID(holdID string) (*model.Bo
 	return &booking, nil
 }
 
+// GetBookingByConfirmationCode retrieves a booking by its confirmation code
+func (r *PostgresBookingRepository) GetBookingByConfirmationCode(code string) (*model.Booking, error) {
+	var booking model.Booking
+	err := r.db.Where("confirmation_code = ?", code).First(&booking).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repository.ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking by confirmation code: %w", err)
+	}
+
+	return &booking, nil
+}
+
+// GetUserBookingsByIDs retrieves the given bookings that belong to the user
+func (r *PostgresBookingRepository) GetUserBookingsByIDs(userID string, bookingIDs []string) ([]model.Booking, error) {
+	var bookings []model.Booking
+	err := r.db.Where("user_id = ? AND id IN ?", userID, bookingIDs).Find(&bookings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings by IDs: %w", err)
+	}
+
+	return bookings, nil
+}
+
 // UpdateBookingStatus updates the status of a booking
 func (r *PostgresBookingRepository) UpdateBookingStatus(req model.UpdateBookingStatusRequest) error {
 	updates := map[string]interface{}{
-		"status":         req.Status,
-		"payment_status": req.PaymentStatus,
+		"status": req.Status,
+	}
+
+	if req.PaymentStatus != "" {
+		updates["payment_status"] = req.PaymentStatus
 	}
 
 	if req.ErrorMessage != nil {
@@ -125,14 +381,67 @@ func (r *PostgresBookingRepository) UpdateBookingStatus(req model.UpdateBookingS
 		updates["failed_at"] = *req.FailedAt
 	}
 
+	// Validate against the current status under a row lock. Terminal bookings
+	// never change status again, so a redelivered message can't flip a
+	// confirmed booking to failed or vice versa.
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var booking model.Booking
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status", "payment_status").
+			Where("id = ?", req.BookingID).
+			First(&booking).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return repository.ErrBookingNotFound
+			}
+			return fmt.Errorf("failed to load booking status: %w", err)
+		}
+
+		if !model.CanTransitionBookingStatus(booking.Status, req.Status) {
+			return fmt.Errorf("%w: booking status %s to %s", repository.ErrInvalidTransition, booking.Status, req.Status)
+		}
+		if req.PaymentStatus != "" && !model.CanTransitionPaymentStatus(booking.PaymentStatus, req.PaymentStatus) {
+			return fmt.Errorf("%w: payment status %s to %s", repository.ErrInvalidTransition, booking.PaymentStatus, req.PaymentStatus)
+		}
+
+		if err := tx.Model(&model.Booking{}).Where("id = ?", req.BookingID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update booking status: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateCallbackStatus records the latest webhook delivery attempt for a booking
+func (r *PostgresBookingRepository) UpdateCallbackStatus(req model.UpdateCallbackStatusRequest) error {
+	updates := map[string]interface{}{
+		"callback_status":   req.Status,
+		"callback_attempts": req.Attempts,
+		"callback_error":    req.LastError,
+	}
+
+	if req.DeliveredAt != nil {
+		updates["callback_delivered_at"] = *req.DeliveredAt
+	}
+
 	err := r.db.Model(&model.Booking{}).Where("id = ?", req.BookingID).Updates(updates).Error
 	if err != nil {
-		return fmt.Errorf("failed to update booking status: %w", err)
+		return fmt.Errorf("failed to update callback status: %w", err)
 	}
 
 	return nil
 }
 
+// ListPendingCallbackIDs returns finished bookings still waiting on a webhook
+func (r *PostgresBookingRepository) ListPendingCallbackIDs() ([]string, error) {
+	var ids []string
+	if err := r.db.Model(&model.Booking{}).
+		Where("callback_status = ? AND status IN ?", model.CallbackStatusPending, model.TerminalBookingStatuses).
+		Order("created_at").
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list pending callbacks: %w", err)
+	}
+	return ids, nil
+}
+
 // ListUserBookings retrieves bookings for a specific user with filtering
 func (r *PostgresBookingRepository) ListUserBookings(filter model.BookingFilter) ([]model.Booking, int, error) {
 	var bookings []model.Booking
@@ -163,6 +472,47 @@ func (r *PostgresBookingRepository) ListUserBookings(filter model.BookingFilter)
 	return bookings, int(total), nil
 }
 
+// CountUserBookingsByStatus counts a user's bookings per status in a single grouped query
+func (r *PostgresBookingRepository) CountUserBookingsByStatus(userID string) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+
+	err := r.db.Model(&model.Booking{}).
+		Select("status, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count bookings: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// AnonymizeUserBookings strips a deleted user's personal data from their
+// bookings. Seats, amounts and statuses are kept so aggregate reporting still
+// adds up, and the user ID is kept since it no longer identifies anyone once
+// the user record is erased.
+func (r *PostgresBookingRepository) AnonymizeUserBookings(userID string) (int, error) {
+	result := r.db.Model(&model.Booking{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"user_email": model.AnonymizedUserEmail,
+			"user_name":  model.AnonymizedUserName,
+			"locale":     "",
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to anonymize bookings: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
 // GetDB returns the database instance for health checks
 func (r *PostgresBookingRepository) GetDB() *gorm.DB {
 	return r.db
@@ -1,25 +1,39 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"time"
 
 	"github.com/arunvm123/eventbooking/booking-service/cache/redis"
 	"github.com/arunvm123/eventbooking/booking-service/config"
+	"github.com/arunvm123/eventbooking/booking-service/messaging"
 	"github.com/arunvm123/eventbooking/booking-service/repository/postgres"
 	httpservice "github.com/arunvm123/eventbooking/booking-service/service/http"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/shared/queue"
 	"github.com/gin-gonic/gin"
-	"github.com/segmentio/kafka-go"
 )
 
 func SetupRouter(cfg *config.Config) *gin.Engine {
+	// Dependencies may still be starting, e.g. during ordered Kubernetes startup
+	retry := cfg.Startup.Retry()
+
 	// Initialize repository
-	repo, err := postgres.NewBookingRepository(&cfg.Database)
+	repo, err := postgres.NewBookingRepository(&cfg.Database, retry)
 	if err != nil {
 		log.Fatal("Failed to initialize repository:", err)
 	}
 
 	// Initialize cache
-	cache, err := redis.NewRedisCacheRepository(cfg.Redis.GetRedisURL(), cfg.Redis.Password, cfg.Redis.DB)
+	var cache *redis.RedisCacheRepository
+	err = retry.Connect("redis", func() (err error) {
+		cache, err = redis.NewRedisCacheRepository(&cfg.Redis)
+		return err
+	})
 	if err != nil {
 		log.Fatal("Failed to initialize cache:", err)
 	}
@@ -27,41 +41,107 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 	// Initialize Event Service client with connection pooling
 	eventService := httpservice.NewHTTPEventServiceWithConfig(&cfg.EventService, cfg.JWTSecret)
 
-	// Initialize Kafka writer
-	kafkaWriter := &kafka.Writer{
-		Addr:     kafka.TCP(cfg.Kafka.Brokers...),
-		Topic:    cfg.Kafka.BookingTopic,
-		Balancer: &kafka.LeastBytes{},
+	// Create missing topics on local/dev clusters
+	if cfg.Kafka.AutoCreateTopics && !cfg.Queue.Memory() {
+		topics := cfg.Topics()
+		settings := messaging.TopicSettings{
+			Partitions:        cfg.Kafka.TopicPartitions,
+			ReplicationFactor: cfg.Kafka.TopicReplicationFactor,
+		}
+		err := retry.Connect("kafka", func() error {
+			return messaging.EnsureTopics(cfg.Kafka.Brokers, topics, settings)
+		})
+		if err != nil {
+			log.Fatal("Failed to create Kafka topics:", err)
+		}
+	}
+
+	// Initialize message queue for booking requests and confirmation resends
+	mq, err := queue.New(cfg.Queue.Driver, cfg.Kafka.Brokers, cfg.Kafka.StartOffset, cfg.Queue.MemorySize)
+	if err != nil {
+		log.Fatal("Failed to initialize message queue:", err)
+	}
+
+	// Initialize outbox relay
+	outboxRelay := messaging.NewOutboxRelay(repo, mq,
+		time.Duration(cfg.Booking.OutboxPollIntervalMillis)*time.Millisecond, cfg.Booking.OutboxBatchSize)
+	go outboxRelay.Run(context.Background())
+
+	// An in-memory queue can't reach a separate worker process, so process
+	// bookings here instead
+	if cfg.Queue.Memory() {
+		log.Println("Using in-memory message queue, running the booking worker in-process")
+		startInProcessWorker(cfg, repo, cache, eventService, mq)
 	}
 
 	// Initialize JWT service
-	jwtService := NewJWTService(cfg.JWTSecret)
+	jwtService := auth.NewJWTService(cfg.JWTSecret)
+
+	// Publish admin re-drives to the audit trail
+	auditor := audit.NewRecorder("booking-service", mq, cfg.Kafka.AuditTopic)
 
 	// Initialize handlers
-	bookingHandler := NewBookingHandler(repo, cache, kafkaWriter, eventService)
+	bookingHandler := NewBookingHandler(cfg, repo, cache, outboxRelay, mq, eventService, auditor)
+
+	// Cap concurrent status streams
+	streamLimit := middleware.ConcurrencyLimit(cfg.Stream.MaxConnections,
+		time.Duration(cfg.Stream.RetryAfterSeconds)*time.Second, middleware.RespondError)
+
+	// Reject writes during maintenance; the bulk status lookup only reads
+	maintenance := middleware.Maintenance(cfg.MaintenanceMode, cache, middleware.RespondError, "/bookings/status")
 
 	// Setup Gin router
 	r := gin.Default()
 
 	// Add middleware
-	r.Use(CORSMiddleware())
-	r.Use(LoggingMiddleware())
+	r.Use(middleware.CORS())
+	r.Use(middleware.Debug())
+	r.Use(middleware.Logging())
+	r.Use(middleware.DefaultJSON())
+
+	// Unknown routes get a JSON error rather than gin's plain text 404
+	r.NoRoute(func(c *gin.Context) {
+		middleware.RespondError(c, http.StatusNotFound, "not_found", "Route not found")
+	})
 
 	// Health check endpoint (no auth required)
 	r.GET("/health", bookingHandler.HealthCheck)
 
 	// API routes
-	api := r.Group("/api")
+	registerRoutes := func(api *gin.RouterGroup) {
+		// Checkout endpoints, also open to guests holding seats without an account
+		checkout := api.Group("")
+		checkout.Use(GuestAuthMiddleware(jwtService), RejectDeletedUsers(cache), maintenance)
+		checkout.POST("/booking", bookingHandler.SubmitBooking)
+		checkout.GET("/booking/:bookingId/status", bookingHandler.GetBookingStatus)
+		checkout.GET("/booking/:bookingId/stream", streamLimit, bookingHandler.StreamBookingStatus)
+
+		// Protected endpoints (require authentication)
+		protected := api.Group("")
+		protected.Use(AuthMiddleware(jwtService), RejectDeletedUsers(cache), maintenance)
 
-	// Protected endpoints (require authentication)
-	protected := api.Group("")
-	protected.Use(AuthMiddleware(jwtService))
+		// Booking endpoints
+		protected.GET("/booking/by-code/:code", bookingHandler.GetBookingByCode)
+		protected.POST("/booking/:bookingId/resend-confirmation", bookingHandler.ResendConfirmation)
+		protected.GET("/bookings", bookingHandler.ListUserBookings)
+		protected.GET("/bookings/count", bookingHandler.CountUserBookings)
+		protected.POST("/bookings/status", bookingHandler.GetBookingStatuses)
 
-	// Booking endpoints
-	protected.POST("/booking", bookingHandler.SubmitBooking)
-	protected.GET("/booking/:bookingId/status", bookingHandler.GetBookingStatus)
-	protected.GET("/booking/:bookingId/stream", bookingHandler.StreamBookingStatus)
-	protected.GET("/bookings", bookingHandler.ListUserBookings)
+		// Internal endpoints for other services
+		internal := api.Group("/internal", AuthMiddleware(jwtService), RequireService())
+		internal.POST("/users/:userId/anonymize", bookingHandler.AnonymizeUserBookings)
+
+		// Operator tools
+		admin := api.Group("/admin", AuthMiddleware(jwtService), RequireAdmin())
+		admin.POST("/booking/:bookingId/reprocess", bookingHandler.ReprocessBooking)
+	}
+	registerRoutes(r.Group(cfg.APIBasePath))
+
+	// Keep serving the unversioned prefix while clients migrate
+	if cfg.APIBasePath != middleware.LegacyAPIBasePath {
+		registerRoutes(r.Group(middleware.LegacyAPIBasePath,
+			middleware.Deprecated(middleware.LegacyAPIBasePath, cfg.APIBasePath)))
+	}
 
 	return r
 }
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrHoldNotFound is returned when the event service has no such hold
+var ErrHoldNotFound = errors.New("hold not found")
+
+// ThrottledError is returned when the event service asks callers to back off
+// (429 Too Many Requests or 503 Service Unavailable). RetryAfter carries the
+// delay suggested by the Retry-After header, or the configured default.
+type ThrottledError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	Body       string
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("event service throttled request (status %d), retry after %s: %s",
+		e.StatusCode, e.RetryAfter, e.Body)
+}
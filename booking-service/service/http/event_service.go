@@ -5,12 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/arunvm123/eventbooking/booking-service/config"
 	"github.com/arunvm123/eventbooking/booking-service/service"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // JWTServiceInterface defines the interface for JWT operations
@@ -20,56 +25,53 @@ type JWTServiceInterface interface {
 
 // JWTService handles JWT operations for service-to-service communication
 type JWTService struct {
-	secretKey string
+	jwt *auth.JWTService
 }
 
 func NewJWTService(secretKey string) *JWTService {
-	return &JWTService{secretKey: secretKey}
-}
-
-// Claims represents the JWT claims
-type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	jwt.RegisteredClaims
+	return &JWTService{jwt: auth.NewJWTService(secretKey)}
 }
 
 // GenerateServiceToken generates a JWT token for service-to-service communication
 func (j *JWTService) GenerateServiceToken(userID, userEmail string) (string, error) {
 	// Create claims for service-to-service communication with actual user context
-	claims := Claims{
+	return j.jwt.GenerateToken(auth.Claims{
 		UserID: userID,
 		Email:  userEmail,
+		Role:   auth.RoleService,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(1 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "booking-service",
 			Subject:   "service-auth",
 		},
-	}
+	})
+}
 
-	// Create token with claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+// defaultRetryBackoff is used when a throttled response has no Retry-After header
+const defaultRetryBackoff = 1 * time.Second
 
-	// Generate encoded token and send it as response
-	tokenString, err := token.SignedString([]byte(j.secretKey))
-	if err != nil {
-		return "", err
-	}
+// defaultAPIBasePath is the event service's route prefix when none is configured
+const defaultAPIBasePath = "/api/v1"
 
-	return tokenString, nil
-}
+// getRetryDelay is the pause before the first GET retry, growing linearly after that
+const getRetryDelay = 200 * time.Millisecond
 
 type HTTPEventService struct {
-	baseURL    string
-	httpClient *http.Client
-	jwtService JWTServiceInterface
+	baseURL      string
+	apiBasePath  string
+	httpClient   *http.Client
+	jwtService   JWTServiceInterface
+	retryBackoff time.Duration
+	getRetries   int
 }
 
 func NewHTTPEventService(baseURL, jwtSecret string) *HTTPEventService {
 	return &HTTPEventService{
-		baseURL:    baseURL,
-		jwtService: NewJWTService(jwtSecret),
+		baseURL:      baseURL,
+		apiBasePath:  defaultAPIBasePath,
+		jwtService:   NewJWTService(jwtSecret),
+		retryBackoff: defaultRetryBackoff,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -88,9 +90,17 @@ func NewHTTPEventServiceWithConfig(cfg *config.EventService, jwtSecret string) *
 		ForceAttemptHTTP2:   true,  // Enable HTTP/2 for better multiplexing
 	}
 
+	retryBackoff := time.Duration(cfg.RetryBackoffSeconds) * time.Second
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+
 	return &HTTPEventService{
-		baseURL:    cfg.BaseURL,
-		jwtService: NewJWTService(jwtSecret),
+		baseURL:      cfg.BaseURL,
+		apiBasePath:  cfg.APIBasePath,
+		jwtService:   NewJWTService(jwtSecret),
+		retryBackoff: retryBackoff,
+		getRetries:   cfg.GetRetries,
 		httpClient: &http.Client{
 			Timeout:   time.Duration(cfg.RequestTimeout) * time.Second,
 			Transport: transport,
@@ -100,40 +110,15 @@ func NewHTTPEventServiceWithConfig(cfg *config.EventService, jwtSecret string) *
 
 // GetHoldDetails retrieves hold information from the event service
 func (s *HTTPEventService) GetHoldDetails(holdID, userID, userEmail string) (*service.HoldDetails, error) {
-	url := fmt.Sprintf("%s/api/events/holds/%s", s.baseURL, holdID)
+	url := fmt.Sprintf("%s%s/events/holds/%s", s.baseURL, s.apiBasePath, holdID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	body, err := s.do(http.MethodGet, url, nil, userID, userEmail)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Generate JWT token for service-to-service authentication with user context
-	token, err := s.jwtService.GenerateServiceToken(userID, userEmail)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate service token: %w", err)
-	}
-
-	// Add internal service authentication header
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("hold not found")
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("event service error (status %d): %s", resp.StatusCode, string(body))
+		return nil, err
 	}
 
 	var holdDetails service.HoldDetails
-	if err := json.NewDecoder(resp.Body).Decode(&holdDetails); err != nil {
+	if err := json.Unmarshal(body, &holdDetails); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -142,74 +127,137 @@ func (s *HTTPEventService) GetHoldDetails(holdID, userID, userEmail string) (*se
 
 // ConfirmHold confirms a hold (converts it to booking) in the event service
 func (s *HTTPEventService) ConfirmHold(holdID, userID, userEmail string) error {
-	url := fmt.Sprintf("%s/api/events/holds/%s/confirm", s.baseURL, holdID)
+	url := fmt.Sprintf("%s%s/events/holds/%s/confirm", s.baseURL, s.apiBasePath, holdID)
+
+	_, err := s.do(http.MethodPost, url, []byte("{}"), userID, userEmail)
+	return err
+}
 
-	req, err := http.NewRequest("POST", url, bytes.NewReader([]byte("{}")))
+// ReleaseHold releases a hold in the event service
+func (s *HTTPEventService) ReleaseHold(holdID, userID, userEmail string) error {
+	url := fmt.Sprintf("%s%s/events/holds/%s", s.baseURL, s.apiBasePath, holdID)
+
+	_, err := s.do(http.MethodDelete, url, nil, userID, userEmail)
+	return err
+}
+
+// do sends a request to the event service on behalf of the user and returns the
+// body of a 200 response. Every attempt is logged with its status and latency
+// under one request ID, which is also sent as X-Request-ID so the event
+// service's logs can be matched up. GETs are retried up to getRetries times on
+// network errors and server errors other than throttling, which is left to the
+// caller. Other responses are mapped to errors: 404 to service.ErrHoldNotFound,
+// 429 and 503 to *service.ThrottledError.
+func (s *HTTPEventService) do(method, url string, payload []byte, userID, userEmail string) ([]byte, error) {
+	requestID := uuid.NewString()
+
+	attempts := 1
+	if method == http.MethodGet {
+		attempts += s.getRetries
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(time.Duration(attempt-1) * getRetryDelay)
+		}
+
+		var resp *http.Response
+		var body []byte
+		resp, body, err = s.send(method, url, payload, userID, userEmail, requestID, attempt)
+		if err != nil {
+			continue // Network errors are worth retrying
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return body, nil
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, service.ErrHoldNotFound
+		}
+		if err := s.throttledError(resp, body); err != nil {
+			return nil, err
+		}
+
+		err = fmt.Errorf("event service error (status %d): %s", resp.StatusCode, string(body))
+		if resp.StatusCode < 500 {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// send makes a single attempt of a request and reads the whole response
+func (s *HTTPEventService) send(method, url string, payload []byte, userID, userEmail, requestID string, attempt int) (*http.Response, []byte, error) {
+	var bodyReader io.Reader
+	if payload != nil {
+		bodyReader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, url, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Generate JWT token for service-to-service authentication with user context
 	token, err := s.jwtService.GenerateServiceToken(userID, userEmail)
 	if err != nil {
-		return fmt.Errorf("failed to generate service token: %w", err)
+		return nil, nil, fmt.Errorf("failed to generate service token: %w", err)
 	}
 
 	// Add internal service authentication header
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.RequestIDHeader, requestID)
 
+	start := time.Now()
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		log.Printf("Event service %s %s failed after %s (request_id=%s, attempt %d): %v",
+			method, url, time.Since(start), requestID, attempt, err)
+		return nil, nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("hold not found")
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("event service error (status %d): %s", resp.StatusCode, string(body))
+	body, err := io.ReadAll(resp.Body)
+	log.Printf("Event service %s %s returned %d in %s (request_id=%s, attempt %d)",
+		method, url, resp.StatusCode, time.Since(start), requestID, attempt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return nil
+	return resp, body, nil
 }
 
-// ReleaseHold releases a hold in the event service
-func (s *HTTPEventService) ReleaseHold(holdID, userID, userEmail string) error {
-	url := fmt.Sprintf("%s/api/events/holds/%s", s.baseURL, holdID)
-
-	req, err := http.NewRequest("DELETE", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// throttledError returns a ThrottledError for 429 and 503 responses, or nil otherwise
+func (s *HTTPEventService) throttledError(resp *http.Response, body []byte) error {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil
 	}
 
-	// Generate JWT token for service-to-service authentication with user context
-	token, err := s.jwtService.GenerateServiceToken(userID, userEmail)
-	if err != nil {
-		return fmt.Errorf("failed to generate service token: %w", err)
+	return &service.ThrottledError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), s.retryBackoff),
+		Body:       string(body),
 	}
+}
 
-	// Add internal service authentication header
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// parseRetryAfter reads a Retry-After header given either as delay-seconds or an
+// HTTP date, falling back to the default when absent or malformed
+func parseRetryAfter(header string, fallback time.Duration) time.Duration {
+	if header == "" {
+		return fallback
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("hold not found")
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("event service error (status %d): %s", resp.StatusCode, string(body))
+	if at, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(at); delay > 0 {
+			return delay
+		}
+		return 0
 	}
 
-	return nil
+	return fallback
 }
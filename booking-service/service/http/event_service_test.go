@@ -0,0 +1,143 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/service"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	fallback := 3 * time.Second
+
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"absent", "", fallback},
+		{"seconds", "7", 7 * time.Second},
+		{"zero seconds", "0", 0},
+		{"negative seconds", "-5", fallback},
+		{"malformed", "soon", fallback},
+		{"date in the past", "Mon, 02 Jan 2006 15:04:05 GMT", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.header, fallback); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.header, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("date in the future", func(t *testing.T) {
+		header := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+		got := parseRetryAfter(header, fallback)
+		if got <= 50*time.Second || got > time.Minute {
+			t.Errorf("parseRetryAfter(%q) = %s, want about a minute", header, got)
+		}
+	})
+}
+
+func TestThrottledResponses(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		wantDelay  time.Duration
+	}{
+		{"429 with header", http.StatusTooManyRequests, "12", 12 * time.Second},
+		{"503 with header", http.StatusServiceUnavailable, "4", 4 * time.Second},
+		{"429 without header", http.StatusTooManyRequests, "", defaultRetryBackoff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewHTTPEventService(server.URL, "secret").ConfirmHold("hold-1", "user-1", "user@example.com")
+
+			var throttled *service.ThrottledError
+			if !errors.As(err, &throttled) {
+				t.Fatalf("ConfirmHold() error = %v, want *service.ThrottledError", err)
+			}
+			if throttled.StatusCode != tt.status || throttled.RetryAfter != tt.wantDelay {
+				t.Errorf("got status %d retry after %s, want %d and %s",
+					throttled.StatusCode, throttled.RetryAfter, tt.status, tt.wantDelay)
+			}
+		})
+	}
+}
+
+// countingServer answers with the given statuses in turn, repeating the last
+// one, and counts the requests it receives
+func countingServer(t *testing.T, statuses ...int) (*httptest.Server, *int) {
+	t.Helper()
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(requests, len(statuses)-1)]
+		requests++
+		if r.Header.Get(middleware.RequestIDHeader) == "" {
+			t.Errorf("request %d has no %s header", requests, middleware.RequestIDHeader)
+		}
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"hold_id":"hold-1"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestGetRetriesServerErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantRequests int
+		wantErr      bool
+	}{
+		{"succeeds after a 500", []int{http.StatusInternalServerError, http.StatusOK}, 2, false},
+		{"succeeds after two 502s", []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK}, 3, false},
+		{"gives up after the retries", []int{http.StatusInternalServerError}, 3, true},
+		{"doesn't retry a 400", []int{http.StatusBadRequest, http.StatusOK}, 1, true},
+		{"doesn't retry a 404", []int{http.StatusNotFound, http.StatusOK}, 1, true},
+		{"leaves throttling to the caller", []int{http.StatusServiceUnavailable, http.StatusOK}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := countingServer(t, tt.statuses...)
+			events := NewHTTPEventService(server.URL, "secret")
+			events.getRetries = 2
+
+			_, err := events.GetHoldDetails("hold-1", "user-1", "user@example.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetHoldDetails() error = %v, want error %v", err, tt.wantErr)
+			}
+			if *requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", *requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestWritesAreNotRetried(t *testing.T) {
+	server, requests := countingServer(t, http.StatusInternalServerError, http.StatusOK)
+	events := NewHTTPEventService(server.URL, "secret")
+	events.getRetries = 2
+
+	if err := events.ConfirmHold("hold-1", "user-1", "user@example.com"); err == nil {
+		t.Error("ConfirmHold() succeeded, want the 500")
+	}
+	if *requests != 1 {
+		t.Errorf("made %d requests, want 1", *requests)
+	}
+}
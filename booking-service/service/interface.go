@@ -14,14 +14,16 @@ type EventService interface {
 
 // HoldDetails represents hold information from the event service
 type HoldDetails struct {
-	HoldID     string   `json:"hold_id"`
-	UserID     string   `json:"user_id"`
-	UserName   string   `json:"user_name"`
-	EventID    string   `json:"event_id"`
-	EventName  string   `json:"event_name"`
-	Venue      string   `json:"venue"`
-	EventDate  string   `json:"event_date"`
-	Seats      []string `json:"seats"`
-	TotalPrice float64  `json:"total_price"`
-	ExpiresAt  string   `json:"expires_at"`
+	HoldID          string   `json:"hold_id"`
+	UserID          string   `json:"user_id"`
+	UserName        string   `json:"user_name"`
+	EventID         string   `json:"event_id"`
+	EventName       string   `json:"event_name"`
+	Venue           string   `json:"venue"`
+	EventDate       string   `json:"event_date"`
+	Seats           []string `json:"seats"`
+	TotalPrice      float64  `json:"total_price"`
+	TotalPriceCents int64    `json:"total_price_cents"`
+	Currency        string   `json:"currency"`
+	ExpiresAt       string   `json:"expires_at"`
 }
@@ -4,9 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"strconv"
 	"sync/atomic"
 	"time"
 
@@ -14,92 +15,68 @@ import (
 	"github.com/arunvm123/eventbooking/booking-service/model"
 	"github.com/arunvm123/eventbooking/booking-service/repository"
 	"github.com/arunvm123/eventbooking/booking-service/service"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/queue"
 	"github.com/segmentio/kafka-go"
 )
 
-// Object pools for memory optimization
-var (
-	// Pool for booking request objects
-	bookingRequestPool = sync.Pool{
-		New: func() interface{} {
-			return &model.BookingRequest{}
-		},
-	}
-
-	// Pool for notification request objects
-	notificationRequestPool = sync.Pool{
-		New: func() interface{} {
-			return &model.NotificationRequest{}
-		},
-	}
+// shutdownGracePeriod is how long shutdown waits for in-flight bookings to finish
+const shutdownGracePeriod = 30 * time.Second
 
-	// Pool for JSON encoding/decoding buffers
-	jsonBufferPool = sync.Pool{
-		New: func() interface{} {
-			return &bytes.Buffer{}
-		},
-	}
+// processingLeaseTTL bounds how long a crashed worker blocks redelivery of a booking
+const processingLeaseTTL = 5 * time.Minute
 
-	// Pool for byte slices used in HTTP/JSON operations
-	byteSlicePool = sync.Pool{
-		New: func() interface{} {
-			return make([]byte, 0, 4096) // 4KB initial capacity
-		},
-	}
+// processedMarkerTTL is how long finished bookings are remembered for dedupe
+const processedMarkerTTL = 7 * 24 * time.Hour
 
-	// Pool for status update objects
-	statusUpdatePool = sync.Pool{
-		New: func() interface{} {
-			return &model.BookingStatusUpdate{}
-		},
-	}
-)
+// maxEventServiceRetryDelay caps how long a worker waits on a single Retry-After
+const maxEventServiceRetryDelay = 30 * time.Second
 
-// resetBookingRequest clears a booking request for reuse
-func resetBookingRequest(req *model.BookingRequest) {
-	req.BookingID = ""
-	req.UserID = ""
-	req.UserEmail = ""
-	req.UserName = ""
-	req.EventID = ""
-	req.EventName = ""
-	req.Venue = ""
-	req.EventDate = time.Time{}
-	req.Seats = req.Seats[:0] // Keep capacity, reset length
-	req.HoldID = ""
-	req.PaymentInfo = model.PaymentInfo{}
-}
+// maxLoggedPayload caps how much of an undecodable booking request is logged
+const maxLoggedPayload = 512
 
-// resetNotificationRequest clears a notification request for reuse
-func resetNotificationRequest(req *model.NotificationRequest) {
-	req.Type = ""
-	req.RecipientEmail = ""
-	req.BookingData = model.NotificationBookingData{}
-	req.Timestamp = time.Time{}
-}
+// deadLetterRetryDelay is the pause between attempts to dead-letter a message
+// while the DLQ topic can't be written to
+const deadLetterRetryDelay = 5 * time.Second
 
-// resetStatusUpdate clears a status update for reuse
-func resetStatusUpdate(update *model.BookingStatusUpdate) {
-	update.BookingID = ""
-	update.Status = ""
-	update.Message = ""
-	update.UpdatedAt = time.Time{}
-}
+// Headers added to dead-lettered booking requests, named like the
+// notification service's so the same tooling can read both topics
+const (
+	dlqHeaderError     = "dlq-error"
+	dlqHeaderFailedAt  = "dlq-failed-at"
+	dlqHeaderPartition = "dlq-original-partition"
+	dlqHeaderOffset    = "dlq-original-offset"
+)
 
 type BookingProcessor struct {
 	repo         repository.BookingRepository
 	cache        cache.CacheRepository
 	eventService service.EventService
-	kafkaWriter  *kafka.Writer
-	consumer     *kafka.Reader
+	queue        queue.MessageQueue
+	consumer     queue.Consumer
+	webhooks     *WebhookDispatcher
+	audit        *audit.Recorder
+
+	// Topics published to; notifications are skipped when notificationTopic is empty
+	notificationTopic string
+	dlqTopic          string
 
 	// Worker pool for managing goroutines
 	workerPool chan chan kafka.Message
 	workers    []*BookingWorker
 
+	// Retries after the event service throttles a request
+	maxEventServiceRetries int
+
+	// chargePayment authorizes a booking's payment, through processPayment
+	// outside tests
+	chargePayment func(bookingReq model.BookingRequest) error
+
 	// Metrics
-	processedCount int64
-	activeWorkers  int64
+	processedCount    int64
+	activeWorkers     int64
+	forcedExits       int64
+	deadLetteredCount int64
 }
 
 type BookingWorker struct {
@@ -114,8 +91,13 @@ func NewBookingProcessor(
 	repo repository.BookingRepository,
 	cache cache.CacheRepository,
 	eventService service.EventService,
-	kafkaWriter *kafka.Writer,
-	consumer *kafka.Reader,
+	queue queue.MessageQueue,
+	consumer queue.Consumer,
+	notificationTopic string,
+	dlqTopic string,
+	webhooks *WebhookDispatcher,
+	auditor *audit.Recorder,
+	maxEventServiceRetries int,
 ) *BookingProcessor {
 	// Worker pool configuration
 	maxWorkers := 20
@@ -124,11 +106,18 @@ func NewBookingProcessor(
 		repo:         repo,
 		cache:        cache,
 		eventService: eventService,
-		kafkaWriter:  kafkaWriter,
+		queue:        queue,
 		consumer:     consumer,
+		webhooks:     webhooks,
+		audit:        auditor,
 		workerPool:   make(chan chan kafka.Message, maxWorkers),
 		workers:      make([]*BookingWorker, maxWorkers),
+
+		notificationTopic:      notificationTopic,
+		dlqTopic:               dlqTopic,
+		maxEventServiceRetries: maxEventServiceRetries,
 	}
+	processor.chargePayment = processor.processPayment
 
 	// Initialize worker pool
 	for i := 0; i < maxWorkers; i++ {
@@ -145,7 +134,7 @@ func NewBookingProcessor(
 	return processor
 }
 
-// Start begins processing booking requests from Kafka
+// Start begins processing booking requests from the queue
 func (p *BookingProcessor) Start(ctx context.Context) error {
 	log.Printf("Starting booking processor with %d workers...", len(p.workers))
 
@@ -165,12 +154,24 @@ func (p *BookingProcessor) Start(ctx context.Context) error {
 			p.shutdown()
 			return ctx.Err()
 		default:
-			// Read message from Kafka
-			msg, err := p.consumer.ReadMessage(ctx)
+			// Read message from the queue and commit it before processing
+			msg, err := p.consumer.Fetch(ctx)
 			if err != nil {
 				log.Printf("Error reading message: %v", err)
 				continue
 			}
+			decodable, err := p.checkDecodable(ctx, msg)
+			if err != nil {
+				// Left uncommitted, so it is redelivered after restart
+				p.shutdown()
+				return err
+			}
+			if !decodable {
+				continue
+			}
+			if err := p.consumer.Commit(ctx, msg); err != nil {
+				log.Printf("Error committing message at offset %d on partition %d: %v", msg.Offset, msg.Partition, err)
+			}
 
 			// Dispatch to worker pool (blocks if all workers busy)
 			select {
@@ -180,9 +181,11 @@ func (p *BookingProcessor) Start(ctx context.Context) error {
 				case jobChannel <- msg:
 					// Successfully dispatched
 				case <-ctx.Done():
+					p.shutdown()
 					return ctx.Err()
 				}
 			case <-ctx.Done():
+				p.shutdown()
 				return ctx.Err()
 			}
 		}
@@ -217,32 +220,46 @@ func (w *BookingWorker) start() {
 }
 
 func (w *BookingWorker) stop() {
-	w.quit <- true
+	// Closing rather than sending lets busy workers finish their current booking
+	// without blocking shutdown of the rest of the pool
+	close(w.quit)
 }
 
-// shutdown gracefully stops all workers
+// shutdown gracefully stops all workers, reporting how many bookings were still
+// in flight while draining and whether any had to be abandoned
 func (p *BookingProcessor) shutdown() {
-	log.Println("Shutting down booking processor workers...")
+	log.Printf("Shutting down booking processor workers (in-flight bookings: %d)...",
+		atomic.LoadInt64(&p.activeWorkers))
 
 	for _, worker := range p.workers {
 		worker.stop()
 	}
 
 	// Wait for active workers to finish (with timeout)
-	timeout := time.After(30 * time.Second)
+	start := time.Now()
+	timeout := time.After(shutdownGracePeriod)
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
+	lastActive := int64(-1)
 	for {
 		select {
 		case <-timeout:
-			log.Println("Shutdown timeout reached, forcing exit")
+			atomic.AddInt64(&p.forcedExits, 1)
+			log.Printf("Shutdown timeout of %s reached, forcing exit with %d in-flight bookings abandoned (processed this session: %d)",
+				shutdownGracePeriod, atomic.LoadInt64(&p.activeWorkers), atomic.LoadInt64(&p.processedCount))
 			return
 		case <-ticker.C:
-			if atomic.LoadInt64(&p.activeWorkers) == 0 {
-				log.Println("All workers finished gracefully")
+			active := atomic.LoadInt64(&p.activeWorkers)
+			if active == 0 {
+				log.Printf("All workers finished gracefully in %s (processed this session: %d)",
+					time.Since(start).Round(time.Millisecond), atomic.LoadInt64(&p.processedCount))
 				return
 			}
+			if active != lastActive {
+				log.Printf("Waiting for %d in-flight bookings to finish...", active)
+				lastActive = active
+			}
 		}
 	}
 }
@@ -269,45 +286,75 @@ func (p *BookingProcessor) reportMetrics(ctx context.Context) {
 func (p *BookingProcessor) processBooking(msg kafka.Message) error {
 	// Get pooled booking request object
 	bookingReq := bookingRequestPool.Get().(*model.BookingRequest)
-	defer func() {
-		resetBookingRequest(bookingReq)
-		bookingRequestPool.Put(bookingReq)
-	}()
+	defer bookingRequestPool.Put(bookingReq)
 
-	// Unmarshal into pooled object
+	// Unmarshal into pooled object; checkDecodable has already dead-lettered
+	// payloads that can't be decoded
 	if err := json.Unmarshal(msg.Value, bookingReq); err != nil {
-		return fmt.Errorf("failed to unmarshal booking request: %w", err)
+		return fmt.Errorf("failed to decode booking request: %w", err)
+	}
+
+	// Skip redelivered messages for bookings that are already being or have been processed
+	if !p.acquireBooking(bookingReq.BookingID) {
+		return nil
 	}
+	// Every path below ends in a terminal status, so remember the booking once done
+	defer func() {
+		if err := p.cache.MarkBookingProcessed(bookingReq.BookingID, processedMarkerTTL); err != nil {
+			log.Printf("Failed to mark booking %s processed: %v", bookingReq.BookingID, err)
+		}
+	}()
+	// Notify the integrator's callback URL, if any, once the final status is stored
+	defer p.webhooks.Dispatch(bookingReq.BookingID)
 
 	log.Printf("Processing booking: %s for user: %s", bookingReq.BookingID, bookingReq.UserID)
 
+	// Record end-to-end latency from submission so the API can estimate wait times
+	submittedAt := bookingReq.Timestamp
+	defer func() {
+		if submittedAt.IsZero() {
+			return
+		}
+		if err := p.cache.RecordProcessingDuration(time.Since(submittedAt)); err != nil {
+			log.Printf("Failed to record processing duration: %v", err)
+		}
+	}()
+
 	// Update status to processing
-	p.updateBookingStatus(bookingReq.BookingID, "processing", "payment", "Processing payment...", nil, nil)
+	p.updateBookingStatus(bookingReq.BookingID, bookingReq.UserID, model.BookingStatusProcessing, "", "Processing payment...", nil, nil)
 
 	// Step 1: Simulate payment processing
-	if err := p.processPayment(*bookingReq); err != nil {
+	if err := p.chargePayment(*bookingReq); err != nil {
 		// Payment failed - release hold and mark booking as failed
-		p.eventService.ReleaseHold(bookingReq.HoldID, bookingReq.UserID, bookingReq.UserEmail)
-		failTime := time.Now()
+		p.withEventServiceRetry(func() error {
+			return p.eventService.ReleaseHold(bookingReq.HoldID, bookingReq.UserID, bookingReq.UserEmail)
+		})
+		failTime := time.Now().UTC()
 		errMsg := fmt.Sprintf("Payment failed: %s", err.Error())
-		p.updateBookingStatus(bookingReq.BookingID, "failed", "failed", errMsg, nil, &failTime)
+		p.updateBookingStatus(bookingReq.BookingID, bookingReq.UserID, model.BookingStatusFailed, model.PaymentStatusFailed, errMsg, nil, &failTime)
 		p.sendNotification(*bookingReq, "booking_failed", errMsg)
 		return err
 	}
 
+	p.updateBookingStatus(bookingReq.BookingID, bookingReq.UserID, model.BookingStatusProcessing, model.PaymentStatusAuthorized, "Payment authorized, confirming seats...", nil, nil)
+
 	// Step 2: Confirm hold with Event Service (mark seats as booked)
-	if err := p.eventService.ConfirmHold(bookingReq.HoldID, bookingReq.UserID, bookingReq.UserEmail); err != nil {
+	if err := p.withEventServiceRetry(func() error {
+		return p.eventService.ConfirmHold(bookingReq.HoldID, bookingReq.UserID, bookingReq.UserEmail)
+	}); err != nil {
 		// Hold confirmation failed - could be expired, seats taken, etc.
-		failTime := time.Now()
+		// The authorized payment is refunded.
+		p.refundPayment(*bookingReq)
+		failTime := time.Now().UTC()
 		errMsg := fmt.Sprintf("Failed to confirm seats: %s", err.Error())
-		p.updateBookingStatus(bookingReq.BookingID, "failed", "refund_pending", errMsg, nil, &failTime)
+		p.updateBookingStatus(bookingReq.BookingID, bookingReq.UserID, model.BookingStatusFailed, model.PaymentStatusRefunded, errMsg, nil, &failTime)
 		p.sendNotification(*bookingReq, "booking_failed", errMsg)
 		return err
 	}
 
 	// Step 3: Mark booking as confirmed
-	confirmTime := time.Now()
-	p.updateBookingStatus(bookingReq.BookingID, "confirmed", "completed", "Booking confirmed successfully", &confirmTime, nil)
+	confirmTime := time.Now().UTC()
+	p.updateBookingStatus(bookingReq.BookingID, bookingReq.UserID, model.BookingStatusConfirmed, model.PaymentStatusCaptured, "Booking confirmed successfully", &confirmTime, nil)
 
 	// Step 4: Send confirmation notification
 	p.sendNotification(*bookingReq, "booking_confirmed", "Your booking has been confirmed!")
@@ -316,14 +363,128 @@ func (p *BookingProcessor) processBooking(msg kafka.Message) error {
 	return nil
 }
 
+// payloadExcerpt quotes at most maxLoggedPayload bytes of a raw message
+func payloadExcerpt(value []byte) string {
+	if len(value) <= maxLoggedPayload {
+		return strconv.Quote(string(value))
+	}
+	return fmt.Sprintf("%s... (%d bytes)", strconv.Quote(string(value[:maxLoggedPayload])), len(value))
+}
+
+// deadLetter publishes a booking request to the DLQ topic with the reason it
+// couldn't be processed in the message headers
+func (p *BookingProcessor) deadLetter(msg kafka.Message, cause error) error {
+	// Use a fresh context so messages are still dead-lettered during shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := p.queue.Produce(ctx, kafka.Message{
+		Topic: p.dlqTopic,
+		Key:   msg.Key,
+		Value: msg.Value,
+		Headers: []kafka.Header{
+			{Key: dlqHeaderError, Value: []byte(cause.Error())},
+			{Key: dlqHeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+			{Key: dlqHeaderPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+			{Key: dlqHeaderOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dead-letter booking request at offset %d on partition %d: %w",
+			msg.Offset, msg.Partition, err)
+	}
+	atomic.AddInt64(&p.deadLetteredCount, 1)
+	return nil
+}
+
+// checkDecodable reports whether a message holds a booking request. A payload
+// that can't be decoded never will be, so it is dead-lettered rather than
+// handled like a failed booking, and committed only once that succeeds: while
+// the DLQ can't be written to, dead-lettering is retried, and if ctx ends first
+// the error is returned with the message still uncommitted.
+func (p *BookingProcessor) checkDecodable(ctx context.Context, msg kafka.Message) (bool, error) {
+	var bookingReq model.BookingRequest
+	decodeErr := json.Unmarshal(msg.Value, &bookingReq)
+	if decodeErr == nil {
+		return true, nil
+	}
+
+	decodeErr = fmt.Errorf("failed to decode booking request: %w", decodeErr)
+	log.Printf("Undecodable booking request at offset %d on partition %d with key %q: %v; payload: %s",
+		msg.Offset, msg.Partition, msg.Key, decodeErr, payloadExcerpt(msg.Value))
+	for {
+		err := p.deadLetter(msg, decodeErr)
+		if err == nil {
+			break
+		}
+		log.Printf("%v; retrying in %s", err, deadLetterRetryDelay)
+		select {
+		case <-time.After(deadLetterRetryDelay):
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+
+	if err := p.consumer.Commit(ctx, msg); err != nil {
+		log.Printf("Error committing message at offset %d on partition %d: %v", msg.Offset, msg.Partition, err)
+	}
+	return false, nil
+}
+
+// acquireBooking decides whether a booking message should be processed. The
+// Redis lease dedupes concurrent and repeated deliveries; the database status
+// is the source of truth when Redis has no record (e.g. after eviction).
+func (p *BookingProcessor) acquireBooking(bookingID string) bool {
+	acquired, err := p.cache.AcquireProcessingLease(bookingID, processingLeaseTTL)
+	if err != nil {
+		log.Printf("Dedupe store unavailable for booking %s, relying on database status: %v", bookingID, err)
+	} else if !acquired {
+		log.Printf("Skipping duplicate message for booking %s", bookingID)
+		return false
+	}
+
+	booking, err := p.repo.GetBookingByID(bookingID)
+	if err == nil && booking.IsTerminal() {
+		log.Printf("Skipping message for booking %s already in terminal status %s", bookingID, booking.Status)
+		p.cache.MarkBookingProcessed(bookingID, processedMarkerTTL)
+		return false
+	}
+
+	return true
+}
+
+// withEventServiceRetry calls fn, retrying after the delay requested by the event
+// service whenever it responds with a throttling error
+func (p *BookingProcessor) withEventServiceRetry(fn func() error) error {
+	err := fn()
+	for attempt := 1; attempt <= p.maxEventServiceRetries; attempt++ {
+		var throttled *service.ThrottledError
+		if !errors.As(err, &throttled) {
+			return err
+		}
+
+		delay := throttled.RetryAfter
+		if delay > maxEventServiceRetryDelay {
+			delay = maxEventServiceRetryDelay
+		}
+		log.Printf("Event service throttled request, retrying in %s (attempt %d/%d)",
+			delay, attempt, p.maxEventServiceRetries)
+		time.Sleep(delay)
+
+		err = fn()
+	}
+	return err
+}
+
 // processPayment simulates payment processing
 func (p *BookingProcessor) processPayment(bookingReq model.BookingRequest) error {
 	// Simulate payment processing time
 	time.Sleep(2 * time.Second)
 
 	// Simulate payment validation
-	if bookingReq.PaymentInfo.Amount <= 0 {
-		return fmt.Errorf("invalid payment amount: %f", bookingReq.PaymentInfo.Amount)
+	amount := bookingReq.PaymentInfo.Money()
+	if amount.Amount <= 0 {
+		return fmt.Errorf("invalid payment amount: %s", amount)
 	}
 
 	if bookingReq.PaymentInfo.PaymentMethod == "" {
@@ -336,13 +497,32 @@ func (p *BookingProcessor) processPayment(bookingReq model.BookingRequest) error
 		return fmt.Errorf("payment gateway declined transaction")
 	}
 
-	log.Printf("Payment processed successfully for booking: %s, amount: $%.2f",
-		bookingReq.BookingID, bookingReq.PaymentInfo.Amount)
+	log.Printf("Payment processed successfully for booking: %s, amount: %s",
+		bookingReq.BookingID, amount)
 	return nil
 }
 
-// updateBookingStatus updates booking status in both database and cache
-func (p *BookingProcessor) updateBookingStatus(bookingID string, status, paymentStatus, message string, confirmedAt, failedAt *time.Time) {
+// refundPayment simulates returning an authorized payment
+func (p *BookingProcessor) refundPayment(bookingReq model.BookingRequest) {
+	// In real implementation, this would void or refund through the payment gateway
+	log.Printf("Payment refunded for booking: %s, amount: %s",
+		bookingReq.BookingID, bookingReq.PaymentInfo.Money())
+
+	p.audit.Record(context.Background(), audit.Event{
+		Action:    audit.ActionPaymentRefunded,
+		ActorRole: audit.ActorSystem,
+		Target:    audit.Target("booking", bookingReq.BookingID),
+		Details: map[string]string{
+			"user_id": bookingReq.UserID,
+			"hold_id": bookingReq.HoldID,
+			"amount":  bookingReq.PaymentInfo.Money().String(),
+		},
+	})
+}
+
+// updateBookingStatus updates booking status in both database and cache. An
+// empty paymentStatus leaves the payment status unchanged.
+func (p *BookingProcessor) updateBookingStatus(bookingID, userID string, status, paymentStatus, message string, confirmedAt, failedAt *time.Time) {
 	// Update database
 	updateReq := model.UpdateBookingStatusRequest{
 		BookingID:     bookingID,
@@ -352,56 +532,77 @@ func (p *BookingProcessor) updateBookingStatus(bookingID string, status, payment
 		FailedAt:      failedAt,
 	}
 
-	if status == "failed" {
+	if status == model.BookingStatusFailed {
 		updateReq.ErrorMessage = &message
 	}
 
+	// Rejected transitions leave the cached status alone too, so SSE clients
+	// never see a status the database doesn't have
 	if err := p.repo.UpdateBookingStatus(updateReq); err != nil {
-		log.Printf("Failed to update booking status in database: %v", err)
+		if errors.Is(err, repository.ErrInvalidTransition) {
+			log.Printf("Ignoring status update for booking %s: %v", bookingID, err)
+			return
+		}
+		log.Printf("Failed to update booking %s status to %s: %v", bookingID, status, err)
+		return
 	}
 
 	// Update cache for SSE
 	statusUpdate := &model.BookingStatusUpdate{
-		BookingID: bookingID,
-		Status:    status,
-		Message:   message,
-		UpdatedAt: time.Now(),
+		BookingID:     bookingID,
+		UserID:        userID,
+		Status:        status,
+		PaymentStatus: paymentStatus,
+		Message:       message,
+		UpdatedAt:     time.Now().UTC(),
 	}
 
 	if err := p.cache.SetBookingStatus(bookingID, statusUpdate, 24*time.Hour); err != nil {
 		log.Printf("Failed to update booking status in cache: %v", err)
 	}
+	if err := p.cache.InvalidateBookingCounts(userID); err != nil {
+		log.Printf("Failed to invalidate booking counts for user %s: %v", userID, err)
+	}
 }
 
-// sendNotification sends notification to Kafka notification topic with object pooling
+// sendNotification sends notification to the notification topic with object
+// pooling. Without a topic, notifications are disabled and only logged.
 func (p *BookingProcessor) sendNotification(bookingReq model.BookingRequest, notificationType, message string) {
+	if p.notificationTopic == "" {
+		log.Printf("Notifications disabled, skipping %s notification for booking %s", notificationType, bookingReq.BookingID)
+		return
+	}
+
 	// Get pooled notification request object
 	notification := notificationRequestPool.Get().(*model.NotificationRequest)
-	defer func() {
-		resetNotificationRequest(notification)
-		notificationRequestPool.Put(notification)
-	}()
+	defer notificationRequestPool.Put(notification)
 
 	// Get pooled JSON buffer
 	jsonBuffer := jsonBufferPool.Get().(*bytes.Buffer)
-	defer func() {
-		jsonBuffer.Reset()
-		jsonBufferPool.Put(jsonBuffer)
-	}()
+	defer jsonBufferPool.Put(jsonBuffer)
 
 	// Populate notification
+	amount := bookingReq.PaymentInfo.Money()
 	notification.Type = notificationType
 	notification.RecipientEmail = bookingReq.UserEmail
 	notification.BookingData = model.NotificationBookingData{
-		BookingID:   bookingReq.BookingID,
-		EventName:   bookingReq.EventName,
-		Venue:       bookingReq.Venue,
-		EventDate:   bookingReq.EventDate,
-		Seats:       bookingReq.Seats,
-		TotalAmount: bookingReq.PaymentInfo.Amount,
-		UserName:    bookingReq.UserName,
+		BookingID:        bookingReq.BookingID,
+		ConfirmationCode: bookingReq.ConfirmationCode,
+		EventName:        bookingReq.EventName,
+		Venue:            bookingReq.Venue,
+		EventDate:        bookingReq.EventDate,
+		Seats:            bookingReq.Seats,
+		TotalAmount:      amount.Decimal(),
+		Currency:         amount.Currency,
+		UserName:         bookingReq.UserName,
+	}
+	if breakdown := bookingReq.AmountBreakdown; breakdown != nil {
+		notification.BookingData.Subtotal = breakdown.Subtotal
+		notification.BookingData.Fees = breakdown.Fees
+		notification.BookingData.Tax = breakdown.Tax
 	}
-	notification.Timestamp = time.Now()
+	notification.Timestamp = time.Now().UTC()
+	notification.Locale = bookingReq.Locale
 
 	// Encode using pooled buffer
 	encoder := json.NewEncoder(jsonBuffer)
@@ -410,9 +611,12 @@ func (p *BookingProcessor) sendNotification(bookingReq model.BookingRequest, not
 		return
 	}
 
-	p.kafkaWriter.WriteMessages(context.Background(),
+	if err := p.queue.Produce(context.Background(),
 		kafka.Message{
+			Topic: p.notificationTopic,
 			Key:   []byte(bookingReq.BookingID),
 			Value: jsonBuffer.Bytes(),
-		})
+		}); err != nil {
+		log.Printf("Failed to send %s notification for booking %s: %v", notificationType, bookingReq.BookingID, err)
+	}
 }
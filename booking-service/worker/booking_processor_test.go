@@ -0,0 +1,242 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/model"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	testBookingTopic = "booking-requests"
+	testDLQTopic     = "booking-requests-dlq"
+)
+
+// recordingConsumer remembers the messages committed through it
+type recordingConsumer struct {
+	queue.Consumer
+	committed []kafka.Message
+}
+
+func (c *recordingConsumer) Commit(ctx context.Context, msgs ...kafka.Message) error {
+	c.committed = append(c.committed, msgs...)
+	return nil
+}
+
+// failingQueue refuses every message
+type failingQueue struct {
+	queue.MessageQueue
+}
+
+func (q failingQueue) Produce(ctx context.Context, msgs ...kafka.Message) error {
+	return errors.New("broker unavailable")
+}
+
+func newTestProcessor(mq queue.MessageQueue, consumer queue.Consumer) *BookingProcessor {
+	return NewBookingProcessor(nil, nil, nil, mq, consumer, "", testDLQTopic, nil, nil, 3)
+}
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func TestCheckDecodableDeadLettersGarbage(t *testing.T) {
+	mq := queue.NewMemoryQueue(10)
+	consumer := &recordingConsumer{}
+	processor := newTestProcessor(mq, consumer)
+	dlq := mq.Consume(testDLQTopic, "test")
+
+	garbage := kafka.Message{
+		Topic:     testBookingTopic,
+		Partition: 2,
+		Offset:    41,
+		Key:       []byte("booking-1"),
+		Value:     []byte("\x00not json{"),
+	}
+	decodable, err := processor.checkDecodable(context.Background(), garbage)
+	if err != nil || decodable {
+		t.Fatalf("checkDecodable() = %v, %v, want false, nil", decodable, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	dead, err := dlq.Fetch(ctx)
+	if err != nil {
+		t.Fatalf("nothing dead-lettered: %v", err)
+	}
+	if string(dead.Key) != "booking-1" || string(dead.Value) != string(garbage.Value) {
+		t.Errorf("dead letter = %q: %q, want the original key and payload", dead.Key, dead.Value)
+	}
+	if cause := header(dead, dlqHeaderError); !strings.Contains(cause, "failed to decode booking request") {
+		t.Errorf("%s header = %q, want the decode error", dlqHeaderError, cause)
+	}
+	if header(dead, dlqHeaderPartition) != "2" || header(dead, dlqHeaderOffset) != "41" {
+		t.Errorf("original position headers = %s/%s, want 2/41", header(dead, dlqHeaderPartition), header(dead, dlqHeaderOffset))
+	}
+	if len(consumer.committed) != 1 || consumer.committed[0].Offset != 41 {
+		t.Errorf("committed %v, want the dead-lettered message", consumer.committed)
+	}
+}
+
+func TestCheckDecodablePassesBookingRequests(t *testing.T) {
+	mq := queue.NewMemoryQueue(10)
+	consumer := &recordingConsumer{}
+	processor := newTestProcessor(mq, consumer)
+
+	msg := kafka.Message{Topic: testBookingTopic, Value: []byte(`{"booking_id":"booking-1","hold_id":"hold-1"}`)}
+	decodable, err := processor.checkDecodable(context.Background(), msg)
+	if err != nil || !decodable {
+		t.Fatalf("checkDecodable() = %v, %v, want true, nil", decodable, err)
+	}
+	if len(consumer.committed) != 0 {
+		t.Errorf("committed %v before processing", consumer.committed)
+	}
+}
+
+func TestCheckDecodableKeepsGarbageUncommittedWhileDLQIsDown(t *testing.T) {
+	consumer := &recordingConsumer{}
+	processor := newTestProcessor(failingQueue{}, consumer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := processor.checkDecodable(ctx, kafka.Message{Topic: testBookingTopic, Value: []byte("garbage")})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("checkDecodable() error = %v, want %v", err, context.Canceled)
+	}
+	if len(consumer.committed) != 0 {
+		t.Errorf("committed %v although it was never dead-lettered", consumer.committed)
+	}
+}
+
+const testNotificationTopic = "notifications"
+
+// newDedupeTestProcessor builds a processor whose payments always succeed,
+// publishing notifications to mq
+func newDedupeTestProcessor(repo *fakeBookingRepository, cache *fakeCache, events *fakeEventService, mq queue.MessageQueue) *BookingProcessor {
+	processor := NewBookingProcessor(repo, cache, events, mq, nil, testNotificationTopic, testDLQTopic,
+		&WebhookDispatcher{}, audit.NewRecorder("booking-service", nil, ""), 3)
+	processor.chargePayment = func(model.BookingRequest) error { return nil }
+	return processor
+}
+
+func bookingMessage(t *testing.T, bookingID string) kafka.Message {
+	t.Helper()
+	value, err := json.Marshal(model.BookingRequest{
+		BookingID:   bookingID,
+		UserID:      "user-1",
+		UserEmail:   "ana@example.com",
+		HoldID:      "hold-1",
+		Seats:       []string{"A1"},
+		PaymentInfo: model.PaymentInfo{PaymentMethod: "card", Amount: 50, Currency: "USD"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kafka.Message{Topic: testBookingTopic, Key: []byte(bookingID), Value: value}
+}
+
+// countMessages drains topic, counting the messages buffered on it
+func countMessages(mq queue.MessageQueue, topic string) int {
+	consumer := mq.Consume(topic, "test")
+	count := 0
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		_, err := consumer.Fetch(ctx)
+		cancel()
+		if err != nil {
+			return count
+		}
+		count++
+	}
+}
+
+func TestRedeliveredBookingIsProcessedOnce(t *testing.T) {
+	tests := []struct {
+		name     string
+		leaseErr error
+	}{
+		{"lease held", nil},
+		{"Redis down, database status", errRedisDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeBookingRepository(&model.Booking{ID: "booking-1", Status: model.BookingStatusProcessing})
+			cache := newFakeCache()
+			cache.leaseErr = tt.leaseErr
+			events := &fakeEventService{}
+			mq := queue.NewMemoryQueue(10)
+			defer mq.Close()
+			processor := newDedupeTestProcessor(repo, cache, events, mq)
+
+			msg := bookingMessage(t, "booking-1")
+			for delivery := 1; delivery <= 2; delivery++ {
+				if err := processor.processBooking(msg); err != nil {
+					t.Fatalf("delivery %d: processBooking() error = %v", delivery, err)
+				}
+			}
+
+			if len(events.confirmed) != 1 {
+				t.Errorf("hold confirmed %d times, want once", len(events.confirmed))
+			}
+			confirmations := 0
+			for _, update := range repo.updates {
+				if update.Status == model.BookingStatusConfirmed {
+					confirmations++
+				}
+			}
+			if confirmations != 1 {
+				t.Errorf("booking moved to confirmed %d times, want once", confirmations)
+			}
+			if sent := countMessages(mq, testNotificationTopic); sent != 1 {
+				t.Errorf("%d notifications sent, want 1", sent)
+			}
+		})
+	}
+}
+
+func TestAcquireBooking(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		leaseHeld     bool
+		leaseErr      error
+		want          bool
+		wantProcessed bool
+	}{
+		{"new booking", model.BookingStatusProcessing, false, nil, true, true},
+		{"lease already held", model.BookingStatusProcessing, true, nil, false, true},
+		{"already confirmed", model.BookingStatusConfirmed, false, nil, false, true},
+		{"already failed", model.BookingStatusFailed, false, nil, false, true},
+		{"Redis down, processing", model.BookingStatusProcessing, false, errRedisDown, true, false},
+		{"Redis down, confirmed", model.BookingStatusConfirmed, false, errRedisDown, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeBookingRepository(&model.Booking{ID: "booking-1", Status: tt.status})
+			cache := newFakeCache()
+			cache.processing["booking-1"] = tt.leaseHeld
+			cache.leaseErr = tt.leaseErr
+			processor := NewBookingProcessor(repo, cache, nil, nil, nil, "", testDLQTopic, nil, nil, 3)
+
+			if got := processor.acquireBooking("booking-1"); got != tt.want {
+				t.Errorf("acquireBooking() = %v, want %v", got, tt.want)
+			}
+			if cache.processing["booking-1"] != tt.wantProcessed {
+				t.Errorf("booking leased or marked processed = %v, want %v", cache.processing["booking-1"], tt.wantProcessed)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"errors"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/cache"
+	"github.com/arunvm123/eventbooking/booking-service/model"
+	"github.com/arunvm123/eventbooking/booking-service/repository"
+	"github.com/arunvm123/eventbooking/booking-service/service"
+)
+
+// fakeBookingRepository keeps bookings in memory and records status updates.
+// Methods a test doesn't set up panic through the embedded nil interface.
+type fakeBookingRepository struct {
+	repository.BookingRepository
+	bookings map[string]*model.Booking
+	updates  []model.UpdateBookingStatusRequest
+}
+
+func newFakeBookingRepository(bookings ...*model.Booking) *fakeBookingRepository {
+	repo := &fakeBookingRepository{bookings: make(map[string]*model.Booking)}
+	for _, booking := range bookings {
+		repo.bookings[booking.ID] = booking
+	}
+	return repo
+}
+
+func (r *fakeBookingRepository) GetBookingByID(id string) (*model.Booking, error) {
+	booking, ok := r.bookings[id]
+	if !ok {
+		return nil, repository.ErrBookingNotFound
+	}
+	copied := *booking
+	return &copied, nil
+}
+
+func (r *fakeBookingRepository) UpdateBookingStatus(req model.UpdateBookingStatusRequest) error {
+	booking, ok := r.bookings[req.BookingID]
+	if !ok {
+		return repository.ErrBookingNotFound
+	}
+	r.updates = append(r.updates, req)
+	booking.Status = req.Status
+	return nil
+}
+
+// fakeCache keeps processing leases in memory; leaseErr makes Redis unavailable
+type fakeCache struct {
+	cache.CacheRepository
+	processing map[string]bool
+	leaseErr   error
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{processing: make(map[string]bool)}
+}
+
+func (c *fakeCache) AcquireProcessingLease(bookingID string, ttl time.Duration) (bool, error) {
+	if c.leaseErr != nil {
+		return false, c.leaseErr
+	}
+	if c.processing[bookingID] {
+		return false, nil
+	}
+	c.processing[bookingID] = true
+	return true, nil
+}
+
+func (c *fakeCache) MarkBookingProcessed(bookingID string, ttl time.Duration) error {
+	if c.leaseErr != nil {
+		return c.leaseErr
+	}
+	c.processing[bookingID] = true
+	return nil
+}
+
+func (c *fakeCache) SetBookingStatus(bookingID string, update *model.BookingStatusUpdate, ttl time.Duration) error {
+	return nil
+}
+
+func (c *fakeCache) InvalidateBookingCounts(userID string) error {
+	return nil
+}
+
+func (c *fakeCache) RecordProcessingDuration(duration time.Duration) error {
+	return nil
+}
+
+// fakeEventService counts the holds confirmed and released
+type fakeEventService struct {
+	service.EventService
+	confirmed []string
+	released  []string
+}
+
+func (s *fakeEventService) ConfirmHold(holdID, userID, userEmail string) error {
+	s.confirmed = append(s.confirmed, holdID)
+	return nil
+}
+
+func (s *fakeEventService) ReleaseHold(holdID, userID, userEmail string) error {
+	s.released = append(s.released, holdID)
+	return nil
+}
+
+var errRedisDown = errors.New("redis unavailable")
@@ -0,0 +1,158 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/segmentio/kafka-go"
+)
+
+// HealthCheck verifies connectivity to a single dependency
+type HealthCheck func(ctx context.Context) error
+
+// HealthServer exposes liveness, readiness and metrics endpoints so the worker
+// can be probed by Kubernetes and scraped by Prometheus
+type HealthServer struct {
+	server *http.Server
+	checks map[string]HealthCheck
+}
+
+// readinessResponse represents the /readyz response body
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func NewHealthServer(port string, checks map[string]HealthCheck) *HealthServer {
+	s := &HealthServer{checks: checks}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", s.livez)
+	mux.HandleFunc("/readyz", s.readyz)
+	mux.Handle("/metrics", promhttp.Handler())
+
+	s.server = &http.Server{
+		Addr:              ":" + port,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return s
+}
+
+// Run serves until the context is cancelled, then shuts the server down
+func (s *HealthServer) Run(ctx context.Context) error {
+	errChan := make(chan error, 1)
+	go func() {
+		log.Printf("Worker health server listening on %s", s.server.Addr)
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- err
+		}
+		close(errChan)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(shutdownCtx)
+}
+
+func (s *HealthServer) livez(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+func (s *HealthServer) readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	response := readinessResponse{Status: "ready", Checks: make(map[string]string, len(s.checks))}
+	statusCode := http.StatusOK
+
+	for name, check := range s.checks {
+		if err := check(ctx); err != nil {
+			response.Checks[name] = err.Error()
+			response.Status = "not_ready"
+			statusCode = http.StatusServiceUnavailable
+			continue
+		}
+		response.Checks[name] = "ok"
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// KafkaHealthCheck returns a check that succeeds when any broker accepts a connection
+func KafkaHealthCheck(brokers []string) HealthCheck {
+	return func(ctx context.Context) error {
+		var lastErr error
+		for _, broker := range brokers {
+			conn, err := kafka.DialContext(ctx, "tcp", broker)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			conn.Close()
+			return nil
+		}
+		return fmt.Errorf("no kafka broker reachable: %w", lastErr)
+	}
+}
+
+// RegisterMetrics exposes the processor's counters to Prometheus
+func (p *BookingProcessor) RegisterMetrics(registerer prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "booking_worker_messages_processed_total",
+			Help: "Total number of booking messages processed by the worker",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.processedCount))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "booking_worker_active_workers",
+			Help: "Number of workers currently processing a booking message",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.activeWorkers))
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "booking_worker_messages_dead_lettered_total",
+			Help: "Total number of undecodable booking messages published to the dead-letter topic",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.deadLetteredCount))
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "booking_worker_forced_shutdowns_total",
+			Help: "Number of shutdowns that timed out with bookings still in flight",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.forcedExits))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "booking_worker_pool_size",
+			Help: "Configured size of the booking worker pool",
+		}, func() float64 {
+			return float64(len(p.workers))
+		}),
+	}
+	collectors = append(collectors, poolCollectors()...)
+
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package worker
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Every booking message is decoded into a BookingRequest, and each result
+// email is built as a NotificationRequest encoded through a bytes.Buffer.
+// These are reused through pools instead of being allocated per message.
+// Objects are reset before going back so no booking data leaks into the next
+// message, and anything that grew unusually large is dropped rather than kept,
+// so one huge payload doesn't pin its memory in the pool for good.
+//
+// The pool metrics show whether this pays off: allocations close to gets means
+// objects are rarely reused, and many discards mean the caps are too tight.
+const (
+	// maxPooledBufferBytes is the largest buffer capacity returned to the pool
+	maxPooledBufferBytes = 64 << 10
+
+	// maxPooledSeats is the largest seat slice capacity a pooled booking request keeps
+	maxPooledSeats = 256
+)
+
+var (
+	bookingRequestPool = newTrackedPool("booking_request",
+		func() interface{} { return &model.BookingRequest{} },
+		func(obj interface{}) bool { return resetBookingRequest(obj.(*model.BookingRequest)) })
+
+	notificationRequestPool = newTrackedPool("notification_request",
+		func() interface{} { return &model.NotificationRequest{} },
+		func(obj interface{}) bool { return resetNotificationRequest(obj.(*model.NotificationRequest)) })
+
+	jsonBufferPool = newTrackedPool("json_buffer",
+		func() interface{} { return &bytes.Buffer{} },
+		func(obj interface{}) bool { return resetJSONBuffer(obj.(*bytes.Buffer)) })
+
+	pooledBufferCapacity = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "booking_worker_pool_buffer_capacity_bytes",
+		Help:    "Capacity of JSON buffers when they are returned to the pool",
+		Buckets: prometheus.ExponentialBuckets(512, 4, 7),
+	})
+)
+
+// resetBookingRequest clears a booking request for reuse
+func resetBookingRequest(req *model.BookingRequest) bool {
+	req.BookingID = ""
+	req.ConfirmationCode = ""
+	req.UserID = ""
+	req.UserEmail = ""
+	req.UserName = ""
+	req.Locale = ""
+	req.EventID = ""
+	req.EventName = ""
+	req.Venue = ""
+	req.EventDate = time.Time{}
+	if cap(req.Seats) > maxPooledSeats {
+		req.Seats = nil
+	} else {
+		req.Seats = req.Seats[:0] // Keep capacity, reset length
+	}
+	req.HoldID = ""
+	req.PaymentInfo = model.PaymentInfo{}
+	req.Timestamp = time.Time{}
+	req.AmountBreakdown = nil
+	return true
+}
+
+// resetNotificationRequest clears a notification request for reuse
+func resetNotificationRequest(req *model.NotificationRequest) bool {
+	req.Type = ""
+	req.RecipientEmail = ""
+	req.BookingData = model.NotificationBookingData{}
+	req.Timestamp = time.Time{}
+	req.Locale = ""
+	return true
+}
+
+// resetJSONBuffer empties a buffer for reuse, dropping it if it has grown
+// past maxPooledBufferBytes
+func resetJSONBuffer(buf *bytes.Buffer) bool {
+	pooledBufferCapacity.Observe(float64(buf.Cap()))
+	if buf.Cap() > maxPooledBufferBytes {
+		return false
+	}
+	buf.Reset()
+	return true
+}
+
+// trackedPool is a sync.Pool that resets objects on Put and counts how they
+// move through it
+type trackedPool struct {
+	name string
+	pool sync.Pool
+	// reset clears an object for reuse, returning false if it should be dropped
+	reset func(obj interface{}) bool
+
+	gets        int64
+	puts        int64
+	allocations int64
+	discards    int64
+}
+
+func newTrackedPool(name string, newObject func() interface{}, reset func(obj interface{}) bool) *trackedPool {
+	p := &trackedPool{name: name, reset: reset}
+	p.pool.New = func() interface{} {
+		atomic.AddInt64(&p.allocations, 1)
+		return newObject()
+	}
+	return p
+}
+
+func (p *trackedPool) Get() interface{} {
+	atomic.AddInt64(&p.gets, 1)
+	return p.pool.Get()
+}
+
+// Put resets the object and returns it to the pool unless reset rejects it
+func (p *trackedPool) Put(obj interface{}) {
+	if !p.reset(obj) {
+		atomic.AddInt64(&p.discards, 1)
+		return
+	}
+	atomic.AddInt64(&p.puts, 1)
+	p.pool.Put(obj)
+}
+
+// collectors exposes the pool's counters, labelled with its name
+func (p *trackedPool) collectors() []prometheus.Collector {
+	labels := prometheus.Labels{"pool": p.name}
+	counter := func(name, help string, value *int64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name:        name,
+			Help:        help,
+			ConstLabels: labels,
+		}, func() float64 {
+			return float64(atomic.LoadInt64(value))
+		})
+	}
+
+	return []prometheus.Collector{
+		counter("booking_worker_pool_gets_total", "Objects taken from the pool", &p.gets),
+		counter("booking_worker_pool_puts_total", "Objects returned to the pool for reuse", &p.puts),
+		counter("booking_worker_pool_allocations_total", "Objects allocated because the pool was empty", &p.allocations),
+		counter("booking_worker_pool_discards_total", "Objects dropped instead of returned because they grew too large", &p.discards),
+	}
+}
+
+// poolCollectors returns the metrics of every worker pool
+func poolCollectors() []prometheus.Collector {
+	collectors := []prometheus.Collector{pooledBufferCapacity}
+	for _, pool := range []*trackedPool{bookingRequestPool, notificationRequestPool, jsonBufferPool} {
+		collectors = append(collectors, pool.collectors()...)
+	}
+	return collectors
+}
@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/model"
+)
+
+func TestResetBookingRequestCapsSeats(t *testing.T) {
+	tests := []struct {
+		name     string
+		seats    int
+		wantKept bool
+	}{
+		{"small", 4, true},
+		{"at cap", maxPooledSeats, true},
+		{"over cap", maxPooledSeats + 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &model.BookingRequest{BookingID: "booking-1", Seats: make([]string, tt.seats)}
+			if !resetBookingRequest(req) {
+				t.Fatal("resetBookingRequest() = false, want true")
+			}
+			if req.BookingID != "" || len(req.Seats) != 0 {
+				t.Errorf("request not cleared: %+v", req)
+			}
+			if kept := cap(req.Seats) > 0; kept != tt.wantKept {
+				t.Errorf("seat capacity kept = %v, want %v", kept, tt.wantKept)
+			}
+		})
+	}
+}
+
+// Messages queued before amounts were itemized have no breakdown, so decoding
+// one into a reused request must not leave the previous booking's in place
+func TestResetBookingRequestClearsBreakdown(t *testing.T) {
+	req := &model.BookingRequest{}
+	if err := json.Unmarshal([]byte(`{"booking_id":"booking-1","amount_breakdown":{"total":53}}`), req); err != nil {
+		t.Fatal(err)
+	}
+	resetBookingRequest(req)
+	if err := json.Unmarshal([]byte(`{"booking_id":"booking-2"}`), req); err != nil {
+		t.Fatal(err)
+	}
+	if req.AmountBreakdown != nil {
+		t.Errorf("AmountBreakdown = %+v, want nil for a message without one", req.AmountBreakdown)
+	}
+}
+
+func TestResetJSONBufferDropsLargeBuffers(t *testing.T) {
+	small := bytes.NewBufferString("payload")
+	if !resetJSONBuffer(small) || small.Len() != 0 {
+		t.Errorf("small buffer should be emptied and kept")
+	}
+
+	large := bytes.NewBuffer(make([]byte, 0, maxPooledBufferBytes+1))
+	if resetJSONBuffer(large) {
+		t.Errorf("buffer with capacity %d should be dropped", large.Cap())
+	}
+}
+
+func benchmarkMessage(b *testing.B) []byte {
+	b.Helper()
+	msg, err := json.Marshal(model.BookingRequest{
+		BookingID: "booking-1",
+		UserID:    "user-1",
+		UserEmail: "user@example.com",
+		EventID:   "event-1",
+		EventName: "Test Event",
+		Venue:     "Test Venue",
+		EventDate: time.Now(),
+		Seats:     []string{"A1", "A2", "A3"},
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return msg
+}
+
+// The pooled and unpooled benchmarks decode a booking request and encode a
+// notification for it, as each processed message does. Compare their
+// allocations with -benchmem.
+func BenchmarkPooledMessage(b *testing.B) {
+	msg := benchmarkMessage(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req := bookingRequestPool.Get().(*model.BookingRequest)
+		notification := notificationRequestPool.Get().(*model.NotificationRequest)
+		buf := jsonBufferPool.Get().(*bytes.Buffer)
+
+		if err := json.Unmarshal(msg, req); err != nil {
+			b.Fatal(err)
+		}
+		notification.RecipientEmail = req.UserEmail
+		notification.BookingData.Seats = req.Seats
+		if err := json.NewEncoder(buf).Encode(notification); err != nil {
+			b.Fatal(err)
+		}
+
+		jsonBufferPool.Put(buf)
+		notificationRequestPool.Put(notification)
+		bookingRequestPool.Put(req)
+	}
+}
+
+func BenchmarkUnpooledMessage(b *testing.B) {
+	msg := benchmarkMessage(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req := &model.BookingRequest{}
+		notification := &model.NotificationRequest{}
+		buf := &bytes.Buffer{}
+
+		if err := json.Unmarshal(msg, req); err != nil {
+			b.Fatal(err)
+		}
+		notification.RecipientEmail = req.UserEmail
+		notification.BookingData.Seats = req.Seats
+		if err := json.NewEncoder(buf).Encode(notification); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
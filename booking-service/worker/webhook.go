@@ -0,0 +1,236 @@
+package worker
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/arunvm123/eventbooking/booking-service/model"
+	"github.com/arunvm123/eventbooking/booking-service/repository"
+)
+
+const (
+	// WebhookSignatureHeader carries "sha256=<hex HMAC of timestamp.body>"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookTimestampHeader carries the Unix time the payload was signed at
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+
+	// Backoff between delivery attempts doubles from the initial delay up to the cap
+	webhookInitialBackoff = 2 * time.Second
+	webhookMaxBackoff     = time.Minute
+
+	// webhookShutdownTimeout is how long shutdown waits for in-flight deliveries
+	webhookShutdownTimeout = 10 * time.Second
+)
+
+// WebhookDispatcher POSTs signed callbacks to integrators when bookings finish
+// processing. Deliveries run in the background so slow endpoints don't hold up
+// booking workers; each attempt is recorded on the booking.
+type WebhookDispatcher struct {
+	repo        repository.BookingRepository
+	client      *http.Client
+	secret      []byte
+	maxAttempts int
+	inFlight    sync.WaitGroup
+}
+
+// NewWebhookDispatcher creates a dispatcher. An empty secret disables callbacks.
+// Unless allowPrivate is set, callbacks are only delivered to public addresses,
+// so callback URLs can't be used to reach internal services.
+func NewWebhookDispatcher(repo repository.BookingRepository, secret string, maxAttempts int, timeout time.Duration, allowPrivate bool) *WebhookDispatcher {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		// Check the address actually dialed, after DNS resolution and for
+		// every redirect, and never go through a proxy that would hide it
+		transport.Proxy = nil
+		transport.DialContext = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   rejectPrivateAddress,
+		}).DialContext
+	}
+
+	return &WebhookDispatcher{
+		repo:        repo,
+		client:      &http.Client{Timeout: timeout, Transport: transport},
+		secret:      []byte(secret),
+		maxAttempts: maxAttempts,
+	}
+}
+
+// IsPrivateAddress reports whether ip is loopback, private, link-local or
+// otherwise not a public unicast address that callbacks may be sent to
+func IsPrivateAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast()
+}
+
+// rejectPrivateAddress is a dialer Control function refusing connections to
+// private addresses
+func rejectPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || IsPrivateAddress(ip) {
+		return fmt.Errorf("callback address %s is not a public address", host)
+	}
+	return nil
+}
+
+// ResumePending restarts deliveries of callbacks still pending, e.g. when the
+// previous worker stopped before its retries finished. Receivers may see a
+// delivery again if it succeeded just before the stop.
+func (d *WebhookDispatcher) ResumePending() {
+	if len(d.secret) == 0 {
+		return
+	}
+
+	ids, err := d.repo.ListPendingCallbackIDs()
+	if err != nil {
+		log.Printf("Failed to list pending webhook deliveries: %v", err)
+		return
+	}
+	if len(ids) > 0 {
+		log.Printf("Resuming %d pending webhook deliveries", len(ids))
+	}
+	for _, id := range ids {
+		d.Dispatch(id)
+	}
+}
+
+// Dispatch starts delivering the callback for a finished booking, if it has one
+func (d *WebhookDispatcher) Dispatch(bookingID string) {
+	if len(d.secret) == 0 {
+		return
+	}
+
+	booking, err := d.repo.GetBookingByID(bookingID)
+	if err != nil {
+		log.Printf("Failed to load booking %s for webhook delivery: %v", bookingID, err)
+		return
+	}
+	if booking.CallbackURL == "" || !booking.IsTerminal() || booking.CallbackStatus == model.CallbackStatusDelivered {
+		return
+	}
+
+	d.inFlight.Add(1)
+	go func() {
+		defer d.inFlight.Done()
+		d.deliver(booking)
+	}()
+}
+
+// deliver posts the payload, retrying with exponential backoff until it is
+// accepted or the attempts run out
+func (d *WebhookDispatcher) deliver(booking *model.Booking) {
+	body, err := json.Marshal(booking.ToWebhookPayload())
+	if err != nil {
+		log.Printf("Failed to marshal webhook payload for booking %s: %v", booking.ID, err)
+		return
+	}
+
+	// Resumed deliveries continue from the attempts already recorded
+	backoff := webhookInitialBackoff
+	for attempt := min(booking.CallbackAttempts+1, d.maxAttempts); attempt <= d.maxAttempts; attempt++ {
+		err := d.post(booking.CallbackURL, body)
+		if err == nil {
+			deliveredAt := time.Now().UTC()
+			d.record(model.UpdateCallbackStatusRequest{
+				BookingID:   booking.ID,
+				Status:      model.CallbackStatusDelivered,
+				Attempts:    attempt,
+				DeliveredAt: &deliveredAt,
+			})
+			log.Printf("Delivered webhook for booking %s (attempt %d)", booking.ID, attempt)
+			return
+		}
+
+		errMsg := err.Error()
+		status := model.CallbackStatusPending
+		if attempt == d.maxAttempts {
+			status = model.CallbackStatusFailed
+		}
+		d.record(model.UpdateCallbackStatusRequest{
+			BookingID: booking.ID,
+			Status:    status,
+			Attempts:  attempt,
+			LastError: &errMsg,
+		})
+		log.Printf("Webhook delivery for booking %s failed (attempt %d/%d): %v",
+			booking.ID, attempt, d.maxAttempts, err)
+
+		if attempt < d.maxAttempts {
+			time.Sleep(backoff)
+			backoff = min(backoff*2, webhookMaxBackoff)
+		}
+	}
+}
+
+// post sends one signed delivery, treating any non-2xx response as a failure
+func (d *WebhookDispatcher) post(url string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+d.sign(timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sign returns the hex HMAC-SHA256 of "<timestamp>.<body>". Including the
+// timestamp lets receivers reject replayed deliveries.
+func (d *WebhookDispatcher) sign(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, d.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *WebhookDispatcher) record(req model.UpdateCallbackStatusRequest) {
+	if err := d.repo.UpdateCallbackStatus(req); err != nil {
+		log.Printf("Failed to record webhook status for booking %s: %v", req.BookingID, err)
+	}
+}
+
+// Shutdown waits briefly for in-flight deliveries. Deliveries still running are
+// abandoned and stay pending on the booking until ResumePending picks them up.
+func (d *WebhookDispatcher) Shutdown() {
+	done := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(webhookShutdownTimeout):
+		log.Printf("Webhook shutdown timeout of %s reached, abandoning in-flight deliveries", webhookShutdownTimeout)
+	}
+}
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"context"
 	"time"
 
 	"github.com/arunvm123/eventbooking/event-service/model"
@@ -27,6 +28,40 @@ type CacheRepository interface {
 	SetEventList(filterKey string, response *model.EventListResponse, ttl time.Duration) error
 	InvalidateEventList(pattern string) error
 
+	// Event view operations
+	// RecordEventView counts a view of the event, kept for at least window
+	RecordEventView(eventID string, window time.Duration) error
+	// TopViewedEvents returns up to count events with the most views over the
+	// last window, most viewed first
+	TopViewedEvents(window time.Duration, count int) ([]model.EventViews, error)
+	// Popular event lists are stored with the event lists, so invalidating
+	// those also drops them
+	GetPopularEvents(limit int) (*model.PopularEventsResponse, error)
+	SetPopularEvents(limit int, response *model.PopularEventsResponse, ttl time.Duration) error
+
+	// Rate limiting
+	// TakeRequestToken spends one token from the named bucket, e.g. a user's
+	// or a client IP's, refilled at perMinute a minute up to perMinute. When
+	// none are left it reports how long until the next one.
+	TakeRequestToken(bucket string, perMinute int) (allowed bool, retryAfter time.Duration, err error)
+
+	// Hold expiry notifications
+	// TrackHoldExpiry stores a key for the hold that Redis expires with it
+	TrackHoldExpiry(holdID string, expiresAt time.Time) error
+	// ExpiredHolds streams the IDs of tracked holds as their keys expire, until
+	// ctx is cancelled. Notifications missed while disconnected are lost.
+	ExpiredHolds(ctx context.Context) (<-chan string, error)
+
+	// Deleted users
+	// MarkUserDeleted refuses the user's remaining tokens for ttl
+	MarkUserDeleted(userID string, ttl time.Duration) error
+	IsUserDeleted(userID string) (bool, error)
+
+	// Maintenance mode
+	// MaintenanceMode reports whether writes were switched off at runtime
+	MaintenanceMode() (bool, error)
+	SetMaintenanceMode(enabled bool) error
+
 	// Health check
 	Ping() error
 
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/arunvm123/eventbooking/event-service/config"
 	"github.com/arunvm123/eventbooking/event-service/model"
 	"github.com/redis/go-redis/v9"
 )
@@ -17,11 +19,16 @@ type RedisCacheRepository struct {
 	ctx    context.Context
 }
 
-func NewRedisCacheRepository(redisURL, password string, db int) (*RedisCacheRepository, error) {
+func NewRedisCacheRepository(cfg *config.RedisConfig) (*RedisCacheRepository, error) {
 	client := redis.NewClient(&redis.Options{
-		Addr:     redisURL,
-		Password: password,
-		DB:       db,
+		Addr:         cfg.GetRedisURL(),
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		DialTimeout:  cfg.DialTimeout,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		MaxRetries:   cfg.MaxRetries,
 	})
 
 	ctx := context.Background()
@@ -54,40 +61,62 @@ func (r *RedisCacheRepository) eventListKey(filterKey string) string {
 	return fmt.Sprintf("events:list:%s", filterKey)
 }
 
+func (r *RedisCacheRepository) popularEventsKey(limit int) string {
+	return r.eventListKey(fmt.Sprintf("popular:%d", limit))
+}
+
+// viewBucket is how long each event view counter covers. Views age out of
+// the popular ranking one bucket at a time.
+const viewBucket = time.Hour
+
+func (r *RedisCacheRepository) eventViewsKey(bucket time.Time) string {
+	return fmt.Sprintf("events:views:%s", bucket.UTC().Format("2006010215"))
+}
+
+// viewBucketKeys returns the keys of the buckets covering window up to now,
+// newest first
+func (r *RedisCacheRepository) viewBucketKeys(now time.Time, window time.Duration) []string {
+	buckets := int((window + viewBucket - 1) / viewBucket)
+	if buckets < 1 {
+		buckets = 1
+	}
+
+	current := now.Truncate(viewBucket)
+	keys := make([]string, buckets)
+	for i := range keys {
+		keys[i] = r.eventViewsKey(current.Add(-time.Duration(i) * viewBucket))
+	}
+	return keys
+}
+
 // Seat availability caching
+// The seat list is stored as one JSON array so it keeps the database's seat
+// order, which pages are sliced from, and a sold out event's empty list can
+// be told apart from a miss
 func (r *RedisCacheRepository) GetAvailableSeats(eventID string) ([]string, error) {
 	key := r.availableSeatsKey(eventID)
-	seats, err := r.client.SMembers(r.ctx, key).Result()
+	data, err := r.client.Get(r.ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, nil // Cache miss
 		}
 		return nil, err
 	}
+
+	seats := []string{}
+	if err := json.Unmarshal([]byte(data), &seats); err != nil {
+		return nil, err
+	}
 	return seats, nil
 }
 
 func (r *RedisCacheRepository) SetAvailableSeats(eventID string, seats []string, ttl time.Duration) error {
 	key := r.availableSeatsKey(eventID)
-
-	// Clear existing set
-	if err := r.client.Del(r.ctx, key).Err(); err != nil {
+	data, err := json.Marshal(seats)
+	if err != nil {
 		return err
 	}
-
-	// Add all seats to set
-	if len(seats) > 0 {
-		seatInterfaces := make([]interface{}, len(seats))
-		for i, seat := range seats {
-			seatInterfaces[i] = seat
-		}
-		if err := r.client.SAdd(r.ctx, key, seatInterfaces...).Err(); err != nil {
-			return err
-		}
-	}
-
-	// Set expiration
-	return r.client.Expire(r.ctx, key, ttl).Err()
+	return r.client.Set(r.ctx, key, data, ttl).Err()
 }
 
 func (r *RedisCacheRepository) InvalidateAvailableSeats(eventID string) error {
@@ -201,6 +230,227 @@ func (r *RedisCacheRepository) InvalidateEventList(pattern string) error {
 	return nil
 }
 
+// Event view tracking
+func (r *RedisCacheRepository) RecordEventView(eventID string, window time.Duration) error {
+	key := r.eventViewsKey(time.Now())
+	pipe := r.client.Pipeline()
+	pipe.ZIncrBy(r.ctx, key, 1, eventID)
+	// Keep the bucket until it falls out of the window
+	pipe.Expire(r.ctx, key, window+viewBucket)
+	_, err := pipe.Exec(r.ctx)
+	return err
+}
+
+func (r *RedisCacheRepository) TopViewedEvents(window time.Duration, count int) ([]model.EventViews, error) {
+	if count < 1 {
+		return nil, nil
+	}
+
+	// Sum the buckets into a scratch key and read the top of it in one
+	// transaction, so concurrent rankings can't read each other's sums
+	dest := "events:views:ranking"
+	var top *redis.ZSliceCmd
+	_, err := r.client.TxPipelined(r.ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZUnionStore(r.ctx, dest, &redis.ZStore{Keys: r.viewBucketKeys(time.Now(), window)})
+		top = pipe.ZRevRangeWithScores(r.ctx, dest, 0, int64(count-1))
+		pipe.Del(r.ctx, dest)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]model.EventViews, 0, len(top.Val()))
+	for _, entry := range top.Val() {
+		eventID, ok := entry.Member.(string)
+		if !ok {
+			continue
+		}
+		views = append(views, model.EventViews{EventID: eventID, Views: int64(entry.Score)})
+	}
+	return views, nil
+}
+
+func (r *RedisCacheRepository) GetPopularEvents(limit int) (*model.PopularEventsResponse, error) {
+	data, err := r.client.Get(r.ctx, r.popularEventsKey(limit)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil // Cache miss
+		}
+		return nil, err
+	}
+
+	var response model.PopularEventsResponse
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (r *RedisCacheRepository) SetPopularEvents(limit int, response *model.PopularEventsResponse, ttl time.Duration) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(r.ctx, r.popularEventsKey(limit), data, ttl).Err()
+}
+
+// tokenBucketScript refills a token bucket for the time elapsed since it was
+// last used and takes a token if one is available. It returns whether a token
+// was taken and, if not, how many milliseconds until the next one. Idle
+// buckets expire once they would be full again.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local per_ms = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1]) or capacity
+local updated = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - updated) * per_ms)
+
+local allowed, wait = 0, 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / per_ms)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / per_ms))
+return {allowed, wait}
+`)
+
+func (r *RedisCacheRepository) requestTokensKey(bucket string) string {
+	return fmt.Sprintf("ratelimit:%s", bucket)
+}
+
+// Rate limiting
+func (r *RedisCacheRepository) TakeRequestToken(bucket string, perMinute int) (bool, time.Duration, error) {
+	perMillisecond := float64(perMinute) / float64(time.Minute/time.Millisecond)
+	result, err := tokenBucketScript.Run(r.ctx, r.client, []string{r.requestTokensKey(bucket)},
+		perMinute, perMillisecond, time.Now().UnixMilli()).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
+
+// holdExpiryKeyPrefix prefixes the keys tracking active holds' expiry
+const holdExpiryKeyPrefix = "hold:expiry:"
+
+// holdExpiryMargin delays hold keys' expiry past the hold's so the database
+// agrees the hold has expired when the notification arrives, despite clock
+// skew between Redis and Postgres
+const holdExpiryMargin = time.Second
+
+// Hold expiry notifications
+func (r *RedisCacheRepository) TrackHoldExpiry(holdID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt) + holdExpiryMargin
+	if ttl <= 0 {
+		return nil
+	}
+	return r.client.Set(r.ctx, holdExpiryKeyPrefix+holdID, 1, ttl).Err()
+}
+
+func (r *RedisCacheRepository) ExpiredHolds(ctx context.Context) (<-chan string, error) {
+	// Servers that refuse CONFIG, e.g. managed Redis, must have expiry events
+	// enabled in their own configuration
+	if err := r.enableExpiryEvents(ctx); err != nil {
+		log.Printf("Failed to enable Redis expiry events, relying on the server's configuration: %v", err)
+	}
+
+	channel := fmt.Sprintf("__keyevent@%d__:expired", r.client.Options().DB)
+	pubsub := r.client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+
+	holdIDs := make(chan string)
+	go func() {
+		defer close(holdIDs)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				holdID, tracked := strings.CutPrefix(msg.Payload, holdExpiryKeyPrefix)
+				if !tracked {
+					continue
+				}
+				select {
+				case holdIDs <- holdID:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return holdIDs, nil
+}
+
+// enableExpiryEvents adds expired key events to the server's keyspace
+// notifications, keeping any already enabled
+func (r *RedisCacheRepository) enableExpiryEvents(ctx context.Context) error {
+	const parameter = "notify-keyspace-events"
+	current, err := r.client.ConfigGet(ctx, parameter).Result()
+	if err != nil {
+		return err
+	}
+	flags := current[parameter]
+	if strings.Contains(flags, "E") && strings.ContainsAny(flags, "xA") {
+		return nil
+	}
+	return r.client.ConfigSet(ctx, parameter, flags+"Ex").Err()
+}
+
+// deletedUserKey marks a user who deleted their account. The booking service
+// writes the same key, so either service's marker refuses the user's tokens.
+func (r *RedisCacheRepository) deletedUserKey(userID string) string {
+	return fmt.Sprintf("deleted_user:%s", userID)
+}
+
+// Deleted users
+func (r *RedisCacheRepository) MarkUserDeleted(userID string, ttl time.Duration) error {
+	return r.client.Set(r.ctx, r.deletedUserKey(userID), time.Now().Unix(), ttl).Err()
+}
+
+func (r *RedisCacheRepository) IsUserDeleted(userID string) (bool, error) {
+	count, err := r.client.Exists(r.ctx, r.deletedUserKey(userID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// maintenanceKey holds the runtime maintenance switch. Services sharing this
+// Redis instance share the switch.
+const maintenanceKey = "maintenance:writes"
+
+// Maintenance mode
+func (r *RedisCacheRepository) MaintenanceMode() (bool, error) {
+	count, err := r.client.Exists(r.ctx, maintenanceKey).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *RedisCacheRepository) SetMaintenanceMode(enabled bool) error {
+	if !enabled {
+		return r.client.Del(r.ctx, maintenanceKey).Err()
+	}
+	return r.client.Set(r.ctx, maintenanceKey, "1", 0).Err()
+}
+
 // Health check
 func (r *RedisCacheRepository) Ping() error {
 	return r.client.Ping(r.ctx).Err()
@@ -242,6 +492,16 @@ func GenerateFilterKey(filter model.EventFilter) string {
 	if filter.DateTo != nil {
 		parts = append(parts, fmt.Sprintf("to:%s", filter.DateTo.Format("2006-01-02")))
 	}
+	if filter.PriceMin != nil {
+		parts = append(parts, fmt.Sprintf("pmin:%d", *filter.PriceMin))
+	}
+	if filter.PriceMax != nil {
+		parts = append(parts, fmt.Sprintf("pmax:%d", *filter.PriceMax))
+	}
+
+	if filter.Sort != "" {
+		parts = append(parts, fmt.Sprintf("sort:%s", filter.Sort))
+	}
 
 	parts = append(parts, fmt.Sprintf("limit:%d", filter.Limit))
 	parts = append(parts, fmt.Sprintf("offset:%d", filter.Offset))
@@ -0,0 +1,18 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+)
+
+func TestGenerateFilterKeyIncludesSort(t *testing.T) {
+	keys := make(map[string]string)
+	for _, sort := range model.EventSorts {
+		key := GenerateFilterKey(model.EventFilter{City: "Berlin", Sort: sort, Limit: 20})
+		if other, ok := keys[key]; ok {
+			t.Errorf("sorts %q and %q share the cache key %q", sort, other, key)
+		}
+		keys[key] = sort
+	}
+}
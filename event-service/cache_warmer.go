@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/cache"
+	"github.com/arunvm123/eventbooking/event-service/config"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// seatCacheKind identifies one of an event's seat caches
+type seatCacheKind string
+
+const (
+	seatCacheCount seatCacheKind = "seat_count"
+	seatCacheList  seatCacheKind = "seat_list"
+)
+
+type seatCacheKey struct {
+	eventID string
+	kind    seatCacheKind
+}
+
+// readWindow counts reads of a seat cache in the current and previous window,
+// so an event doesn't stop being hot the moment a new window starts
+type readWindow struct {
+	start    time.Time
+	count    int
+	previous int
+}
+
+// CacheWarmer wraps the cache to track how often each event's seat caches are
+// read. When a hot event's seat cache is invalidated it is recomputed in the
+// background, so the readers that follow a hold or release don't all fall
+// through to the database at once. Cold events are left to refill on demand.
+type CacheWarmer struct {
+	cache.CacheRepository
+	repo repository.EventRepository
+	ttl  time.Duration
+	cfg  config.CacheWarmingConfig
+
+	mu      sync.Mutex
+	reads   map[seatCacheKey]*readWindow
+	pending map[seatCacheKey]bool
+	queue   chan seatCacheKey
+
+	warms    *prometheus.CounterVec
+	failures prometheus.Counter
+	dropped  prometheus.Counter
+}
+
+// NewCacheWarmer wraps the cache, repopulating seat caches for ttl
+func NewCacheWarmer(cache cache.CacheRepository, repo repository.EventRepository, ttl time.Duration, cfg config.CacheWarmingConfig) *CacheWarmer {
+	return &CacheWarmer{
+		CacheRepository: cache,
+		repo:            repo,
+		ttl:             ttl,
+		cfg:             cfg,
+		reads:           make(map[seatCacheKey]*readWindow),
+		pending:         make(map[seatCacheKey]bool),
+		queue:           make(chan seatCacheKey, cfg.MaxTracked),
+		warms: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "event_cache_warms_total",
+			Help: "Seat caches repopulated in the background after invalidation, by cache",
+		}, []string{"cache"}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "event_cache_warm_failures_total",
+			Help: "Background seat cache warms that failed to load from the database",
+		}),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "event_cache_warms_dropped_total",
+			Help: "Seat cache warms skipped because the warm queue was full",
+		}),
+	}
+}
+
+// RegisterMetrics exposes the warmer's counters to Prometheus
+func (w *CacheWarmer) RegisterMetrics(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{w.warms, w.failures, w.dropped} {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run warms queued seat caches until the context is cancelled
+func (w *CacheWarmer) Run(ctx context.Context) {
+	log.Printf("Starting seat cache warming (%d reads per %s, %d workers)", w.cfg.HotThreshold, w.cfg.Window, w.cfg.Workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case key := <-w.queue:
+					// Clear pending first so an invalidation during the warm
+					// queues another one instead of leaving a stale value
+					w.mu.Lock()
+					delete(w.pending, key)
+					w.mu.Unlock()
+
+					w.warm(key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// GetAvailableSeats records a seat list read
+func (w *CacheWarmer) GetAvailableSeats(eventID string) ([]string, error) {
+	w.recordRead(seatCacheKey{eventID, seatCacheList})
+	return w.CacheRepository.GetAvailableSeats(eventID)
+}
+
+// GetAvailableSeatCount records a seat count read
+func (w *CacheWarmer) GetAvailableSeatCount(eventID string) (int, error) {
+	w.recordRead(seatCacheKey{eventID, seatCacheCount})
+	return w.CacheRepository.GetAvailableSeatCount(eventID)
+}
+
+// InvalidateAvailableSeats invalidates the seat list and warms it if hot
+func (w *CacheWarmer) InvalidateAvailableSeats(eventID string) error {
+	err := w.CacheRepository.InvalidateAvailableSeats(eventID)
+	w.schedule(seatCacheKey{eventID, seatCacheList})
+	return err
+}
+
+// InvalidateAvailableSeatCount invalidates the seat count and warms it if hot
+func (w *CacheWarmer) InvalidateAvailableSeatCount(eventID string) error {
+	err := w.CacheRepository.InvalidateAvailableSeatCount(eventID)
+	w.schedule(seatCacheKey{eventID, seatCacheCount})
+	return err
+}
+
+func (w *CacheWarmer) recordRead(key seatCacheKey) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	window, ok := w.reads[key]
+	if !ok {
+		if len(w.reads) >= w.cfg.MaxTracked {
+			w.evictIdle(now)
+			if len(w.reads) >= w.cfg.MaxTracked {
+				return
+			}
+		}
+		window = &readWindow{start: now}
+		w.reads[key] = window
+	}
+
+	w.rotate(window, now)
+	window.count++
+}
+
+// evictIdle forgets seat caches that haven't been read for two windows
+func (w *CacheWarmer) evictIdle(now time.Time) {
+	for key, window := range w.reads {
+		if now.Sub(window.start) >= 2*w.cfg.Window {
+			delete(w.reads, key)
+		}
+	}
+}
+
+// rotate starts a new window once the current one has elapsed
+func (w *CacheWarmer) rotate(window *readWindow, now time.Time) {
+	elapsed := now.Sub(window.start)
+	if elapsed < w.cfg.Window {
+		return
+	}
+	window.previous = window.count
+	if elapsed >= 2*w.cfg.Window {
+		window.previous = 0
+	}
+	window.count = 0
+	window.start = now
+}
+
+// schedule queues a warm for a hot seat cache unless one is already pending
+func (w *CacheWarmer) schedule(key seatCacheKey) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	window, ok := w.reads[key]
+	if !ok || w.pending[key] {
+		return
+	}
+	w.rotate(window, time.Now())
+	if window.count < w.cfg.HotThreshold && window.previous < w.cfg.HotThreshold {
+		return
+	}
+
+	select {
+	case w.queue <- key:
+		w.pending[key] = true
+	default:
+		w.dropped.Inc()
+	}
+}
+
+// warm recomputes a seat cache from the primary database and stores it. Warms
+// follow writes, so the replica could still return the stale seats.
+func (w *CacheWarmer) warm(key seatCacheKey) {
+	var err error
+	switch key.kind {
+	case seatCacheCount:
+		var count int
+		if count, err = w.repo.GetPrimaryAvailableSeatCount(key.eventID); err == nil {
+			err = w.CacheRepository.SetAvailableSeatCount(key.eventID, count, w.ttl)
+		}
+	case seatCacheList:
+		var seats []string
+		if seats, err = w.repo.GetPrimaryAvailableSeats(key.eventID); err == nil && seats != nil {
+			err = w.CacheRepository.SetAvailableSeats(key.eventID, seats, w.ttl)
+		}
+	}
+	if err != nil {
+		w.failures.Inc()
+		log.Printf("Failed to warm %s cache for event %s: %v", key.kind, key.eventID, err)
+		return
+	}
+	w.warms.WithLabelValues(string(key.kind)).Inc()
+}
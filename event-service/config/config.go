@@ -1,6 +1,16 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/shared/pagination"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/arunvm123/eventbooking/shared/startup"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -11,6 +21,206 @@ type Config struct {
 	Database  DatabaseConfig `yaml:"database" env:"DATABASE"`
 	JWTSecret string         `yaml:"jwt_secret" env:"JWT_SECRET"`
 	Redis     RedisConfig    `yaml:"redis" env:"REDIS"`
+
+	// Prefix for all API routes; the legacy /api prefix is kept as an alias
+	APIBasePath string `yaml:"api_base_path" env:"API_BASE_PATH"`
+
+	// Optional read replica used for read-only queries (listing, details, availability).
+	// Leave the host empty to route all queries to the primary database.
+	ReadReplica DatabaseConfig `yaml:"read_replica" env-prefix:"REPLICA_"`
+
+	Pagination PaginationConfig `yaml:"pagination"`
+	Cache      CacheConfig      `yaml:"cache"`
+
+	// Events with more seats than this have their seats generated in the background
+	AsyncSeatThreshold int `yaml:"async_seat_threshold" env:"ASYNC_SEAT_THRESHOLD"`
+
+	// Background seat generation that has added no seats for this long is
+	// presumed dead, e.g. after a crash, and the seats may be regenerated
+	SeatGenerationStaleAfter time.Duration `yaml:"seat_generation_stale_after" env:"SEAT_GENERATION_STALE_AFTER"`
+
+	// Seat availability queries slower than this are logged
+	SlowSeatQueryThreshold time.Duration `yaml:"slow_seat_query_threshold" env:"SLOW_SEAT_QUERY_THRESHOLD"`
+
+	// Most seats a single hold request may ask for, bounding the rows it locks
+	MaxSeatsPerHold int `yaml:"max_seats_per_hold" env:"MAX_SEATS_PER_HOLD"`
+
+	// Categories events may use, e.g. "Concert,Sports,Theater". Leave empty to
+	// accept any category.
+	EventCategories []string `yaml:"event_categories" env:"EVENT_CATEGORIES" env-separator:","`
+
+	// Order of event lists requested without a sort: date, name or created_at
+	DefaultEventSort string `yaml:"default_event_sort" env:"DEFAULT_EVENT_SORT"`
+
+	Kafka       KafkaConfig       `yaml:"kafka"`
+	Queue       QueueConfig       `yaml:"queue"`
+	HoldWarning HoldWarningConfig `yaml:"hold_warning"`
+	HoldCleanup HoldCleanupConfig `yaml:"hold_cleanup"`
+
+	// Holds that expired at most this long ago can still be confirmed if their
+	// seats are free, so payments finishing right at expiry don't need a refund
+	HoldConfirmGrace time.Duration `yaml:"hold_confirm_grace" env:"HOLD_CONFIRM_GRACE"`
+
+	GuestHolds GuestHoldsConfig `yaml:"guest_holds"`
+
+	UserService UserServiceConfig `yaml:"user_service"`
+
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+
+	Startup StartupConfig `yaml:"startup"`
+
+	// Reject writes with 503 regardless of the runtime switch, which admins
+	// can also turn on through the API
+	MaintenanceMode bool `yaml:"maintenance_mode" env:"MAINTENANCE_MODE"`
+}
+
+// StartupConfig bounds how long the service waits at boot for Postgres and
+// Redis to accept connections before exiting
+type StartupConfig struct {
+	ConnectAttempts   int           `yaml:"connect_attempts" env:"STARTUP_CONNECT_ATTEMPTS"`
+	ConnectBackoff    time.Duration `yaml:"connect_backoff" env:"STARTUP_CONNECT_BACKOFF"`
+	ConnectMaxBackoff time.Duration `yaml:"connect_max_backoff" env:"STARTUP_CONNECT_MAX_BACKOFF"`
+}
+
+// Retry returns the connection budget for the shared startup helper
+func (s *StartupConfig) Retry() startup.Retry {
+	return startup.Retry{Attempts: s.ConnectAttempts, Backoff: s.ConnectBackoff, MaxBackoff: s.ConnectMaxBackoff}
+}
+
+// RateLimitConfig caps how many requests each authenticated user may make,
+// keyed by their user ID, with a larger quota for admins. Unauthenticated
+// routes, i.e. placing guest holds, are limited per client IP instead. Quotas
+// are requests per minute, and a caller idle for a minute may burst up to the
+// full quota. Internal service tokens aren't limited.
+type RateLimitConfig struct {
+	Enabled            bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	UserPerMinute      int  `yaml:"user_per_minute" env:"RATE_LIMIT_USER_PER_MINUTE"`
+	AdminPerMinute     int  `yaml:"admin_per_minute" env:"RATE_LIMIT_ADMIN_PER_MINUTE"`
+	AnonymousPerMinute int  `yaml:"anonymous_per_minute" env:"RATE_LIMIT_ANONYMOUS_PER_MINUTE"`
+}
+
+// QuotaFor returns the requests per minute allowed for a JWT role. Roles
+// without a quota of their own, guests included, get the user quota.
+func (r *RateLimitConfig) QuotaFor(role string) int {
+	if role == "admin" {
+		return r.AdminPerMinute
+	}
+	return r.UserPerMinute
+}
+
+// UserServiceConfig controls looking up holders' names for hold details. The
+// lookup is best-effort: after BreakerThreshold consecutive failures it is
+// skipped for BreakerCooldown. Leave the URL empty to disable it.
+type UserServiceConfig struct {
+	BaseURL     string `yaml:"base_url" env:"USER_SERVICE_URL"`
+	APIBasePath string `yaml:"api_base_path" env:"USER_SERVICE_API_BASE_PATH"`
+
+	LookupTimeout    time.Duration `yaml:"lookup_timeout" env:"USER_LOOKUP_TIMEOUT"`
+	BreakerThreshold int           `yaml:"breaker_threshold" env:"USER_LOOKUP_BREAKER_THRESHOLD"`
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown" env:"USER_LOOKUP_BREAKER_COOLDOWN"`
+}
+
+// GuestHoldsConfig controls holds placed by buyers without an account. Each
+// guest hold comes with a token scoped to it, valid for TokenTTL so the guest
+// can still follow their booking after the hold itself has been confirmed.
+type GuestHoldsConfig struct {
+	Enabled  bool          `yaml:"enabled" env:"GUEST_HOLDS_ENABLED"`
+	TokenTTL time.Duration `yaml:"token_ttl" env:"GUEST_TOKEN_TTL"`
+}
+
+// HoldCleanupConfig controls the background sweep that expires stale holds.
+// Each batch runs in its own transaction to keep row locks short.
+type HoldCleanupConfig struct {
+	Interval  time.Duration `yaml:"interval" env:"HOLD_CLEANUP_INTERVAL"`
+	BatchSize int           `yaml:"batch_size" env:"HOLD_CLEANUP_BATCH_SIZE"`
+
+	// Also release each hold's seats the moment it expires, using Redis
+	// keyspace expiry notifications, with the sweep catching any missed
+	ExpiryNotifications bool `yaml:"expiry_notifications" env:"HOLD_EXPIRY_NOTIFICATIONS"`
+}
+
+// KafkaConfig configures publishing to the notification topic. Leave the
+// brokers empty to disable notifications from the event service, unless it
+// runs on the in-memory queue.
+type KafkaConfig struct {
+	Brokers           []string `yaml:"brokers" env:"KAFKA_BROKERS" env-separator:","`
+	NotificationTopic string   `yaml:"notification_topic" env:"KAFKA_NOTIFICATION_TOPIC"`
+
+	// Event deletions and admin actions are published here; without brokers
+	// they are only logged
+	AuditTopic string `yaml:"audit_topic" env:"AUDIT_TOPIC"`
+}
+
+// QueueConfig selects the message queue carrying notifications and audit events
+type QueueConfig struct {
+	// "kafka", or "memory" to run without a broker. In memory mode the
+	// messages can't leave the process, so they are logged in-process.
+	Driver string `yaml:"driver" env:"MESSAGE_QUEUE"`
+
+	// Messages buffered per topic by the in-memory queue
+	MemorySize int `yaml:"memory_size" env:"MEMORY_QUEUE_SIZE"`
+}
+
+// Memory reports whether messages stay in-process instead of going through Kafka
+func (q *QueueConfig) Memory() bool {
+	return q.Driver == queue.DriverMemory
+}
+
+// HoldWarningConfig controls the email sent to holders shortly before their hold expires
+type HoldWarningConfig struct {
+	// How long before expiry the warning is sent, e.g. "3m"
+	LeadTime time.Duration `yaml:"lead_time" env:"HOLD_WARNING_LEAD_TIME"`
+	// How often to look for expiring holds
+	CheckInterval time.Duration `yaml:"check_interval" env:"HOLD_WARNING_CHECK_INTERVAL"`
+	// Link to complete the booking; {hold_id} is replaced with the hold ID
+	CheckoutURL string `yaml:"checkout_url" env:"HOLD_CHECKOUT_URL"`
+}
+
+// NotificationsEnabled reports whether a message queue has been configured for
+// notifications: Kafka brokers, or the in-memory queue
+func (c *Config) NotificationsEnabled() bool {
+	return len(c.Kafka.Brokers) > 0 || c.Queue.Memory()
+}
+
+// CacheConfig controls how long cached entries stay fresh, e.g. "5m" or "30s"
+type CacheConfig struct {
+	EventCacheTTL     time.Duration `yaml:"event_ttl" env:"EVENT_CACHE_TTL"`
+	SeatCacheTTL      time.Duration `yaml:"seat_ttl" env:"SEAT_CACHE_TTL"`
+	EventListCacheTTL time.Duration `yaml:"event_list_ttl" env:"EVENT_LIST_CACHE_TTL"`
+
+	Warming CacheWarmingConfig `yaml:"warming"`
+
+	Popular PopularEventsConfig `yaml:"popular"`
+}
+
+// PopularEventsConfig controls the popular events ranking. Views are counted
+// in hourly buckets, so Window is rounded up to whole hours.
+type PopularEventsConfig struct {
+	Window   time.Duration `yaml:"window" env:"POPULAR_EVENTS_WINDOW"`
+	CacheTTL time.Duration `yaml:"cache_ttl" env:"POPULAR_EVENTS_CACHE_TTL"`
+}
+
+// CacheWarmingConfig controls repopulating the seat caches of hot events in
+// the background right after holds invalidate them. Events only count as hot
+// once their seat caches are read HotThreshold times within Window.
+type CacheWarmingConfig struct {
+	Enabled      bool          `yaml:"enabled" env:"CACHE_WARMING_ENABLED"`
+	HotThreshold int           `yaml:"hot_threshold" env:"CACHE_WARMING_HOT_THRESHOLD"`
+	Window       time.Duration `yaml:"window" env:"CACHE_WARMING_WINDOW"`
+	// Upper bound on the seat caches tracked, and on warms queued at once
+	MaxTracked int `yaml:"max_tracked" env:"CACHE_WARMING_MAX_TRACKED"`
+	Workers    int `yaml:"workers" env:"CACHE_WARMING_WORKERS"`
+}
+
+// PaginationConfig controls page sizes for list endpoints
+type PaginationConfig struct {
+	DefaultPageSize int `yaml:"default_page_size" env:"DEFAULT_PAGE_SIZE"`
+	MaxPageSize     int `yaml:"max_page_size" env:"MAX_PAGE_SIZE"`
+}
+
+// Limits returns the page sizes for the shared pagination helper
+func (p *PaginationConfig) Limits() pagination.Limits {
+	return pagination.Limits{Default: p.DefaultPageSize, Max: p.MaxPageSize}
 }
 
 type DatabaseConfig struct {
@@ -27,11 +237,26 @@ type RedisConfig struct {
 	Port     string `yaml:"port" env:"REDIS_PORT"`
 	Password string `yaml:"password" env:"REDIS_PASSWORD"`
 	DB       int    `yaml:"db" env:"REDIS_DB"`
+
+	// Connection pool and timeouts, so a struggling Redis fails fast instead
+	// of stalling request handlers
+	PoolSize     int           `yaml:"pool_size" env:"REDIS_POOL_SIZE"`
+	DialTimeout  time.Duration `yaml:"dial_timeout" env:"REDIS_DIAL_TIMEOUT"`
+	ReadTimeout  time.Duration `yaml:"read_timeout" env:"REDIS_READ_TIMEOUT"`
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"REDIS_WRITE_TIMEOUT"`
+	// Retries per command after a network error; -1 disables retries
+	MaxRetries int `yaml:"max_retries" env:"REDIS_MAX_RETRIES"`
 }
 
-// GetDatabaseURL constructs the PostgreSQL connection string
+// GetDatabaseURL constructs the PostgreSQL connection string. Sessions use
+// UTC so NOW() and timestamps agree with the application.
 func (d *DatabaseConfig) GetDatabaseURL() string {
-	return "postgres://" + d.User + ":" + d.Password + "@" + d.Host + ":" + d.Port + "/" + d.DatabaseName + "?sslmode=" + d.SSLMode
+	return "postgres://" + d.User + ":" + d.Password + "@" + d.Host + ":" + d.Port + "/" + d.DatabaseName + "?sslmode=" + d.SSLMode + "&TimeZone=UTC"
+}
+
+// HasReadReplica reports whether a read replica has been configured
+func (c *Config) HasReadReplica() bool {
+	return c.ReadReplica.Host != ""
 }
 
 // GetRedisURL constructs the Redis connection string
@@ -77,6 +302,24 @@ func Initialise(filepath string, env bool) (*Config, error) {
 	if configuration.JWTSecret == "" {
 		configuration.JWTSecret = "your-secret-key-change-in-production"
 	}
+	// Replica settings not explicitly provided are inherited from the primary
+	if configuration.HasReadReplica() {
+		if configuration.ReadReplica.User == "" {
+			configuration.ReadReplica.User = configuration.Database.User
+		}
+		if configuration.ReadReplica.Password == "" {
+			configuration.ReadReplica.Password = configuration.Database.Password
+		}
+		if configuration.ReadReplica.DatabaseName == "" {
+			configuration.ReadReplica.DatabaseName = configuration.Database.DatabaseName
+		}
+		if configuration.ReadReplica.Port == "" {
+			configuration.ReadReplica.Port = configuration.Database.Port
+		}
+		if configuration.ReadReplica.SSLMode == "" {
+			configuration.ReadReplica.SSLMode = configuration.Database.SSLMode
+		}
+	}
 	if configuration.Redis.Host == "" {
 		configuration.Redis.Host = "localhost"
 	}
@@ -86,10 +329,240 @@ func Initialise(filepath string, env bool) (*Config, error) {
 	if configuration.Redis.DB == 0 {
 		configuration.Redis.DB = 0
 	}
+	if configuration.Redis.PoolSize == 0 {
+		configuration.Redis.PoolSize = 20
+	}
+	if configuration.Redis.DialTimeout == 0 {
+		configuration.Redis.DialTimeout = 2 * time.Second
+	}
+	if configuration.Redis.ReadTimeout == 0 {
+		configuration.Redis.ReadTimeout = 500 * time.Millisecond
+	}
+	if configuration.Redis.WriteTimeout == 0 {
+		configuration.Redis.WriteTimeout = 500 * time.Millisecond
+	}
+	if configuration.Redis.MaxRetries == 0 {
+		configuration.Redis.MaxRetries = 2
+	}
+	if configuration.Pagination.DefaultPageSize == 0 {
+		configuration.Pagination.DefaultPageSize = 20
+	}
+	if configuration.Pagination.MaxPageSize == 0 {
+		configuration.Pagination.MaxPageSize = 100
+	}
+	if configuration.AsyncSeatThreshold == 0 {
+		configuration.AsyncSeatThreshold = 10000
+	}
+	if configuration.SlowSeatQueryThreshold == 0 {
+		configuration.SlowSeatQueryThreshold = 250 * time.Millisecond
+	}
+	if configuration.MaxSeatsPerHold == 0 {
+		configuration.MaxSeatsPerHold = 10
+	}
+	if configuration.Cache.EventCacheTTL == 0 {
+		configuration.Cache.EventCacheTTL = 5 * time.Minute
+	}
+	if configuration.Cache.SeatCacheTTL == 0 {
+		configuration.Cache.SeatCacheTTL = 30 * time.Second
+	}
+	if configuration.Cache.EventListCacheTTL == 0 {
+		configuration.Cache.EventListCacheTTL = 2 * time.Minute
+	}
+	if configuration.Cache.Warming.HotThreshold == 0 {
+		configuration.Cache.Warming.HotThreshold = 20
+	}
+	if configuration.Cache.Warming.Window == 0 {
+		configuration.Cache.Warming.Window = time.Minute
+	}
+	if configuration.Cache.Warming.MaxTracked == 0 {
+		configuration.Cache.Warming.MaxTracked = 1000
+	}
+	if configuration.Cache.Warming.Workers == 0 {
+		configuration.Cache.Warming.Workers = 2
+	}
+	if configuration.Cache.Popular.Window == 0 {
+		configuration.Cache.Popular.Window = 24 * time.Hour
+	}
+	if configuration.Cache.Popular.CacheTTL == 0 {
+		configuration.Cache.Popular.CacheTTL = time.Minute
+	}
+	if configuration.Kafka.NotificationTopic == "" {
+		configuration.Kafka.NotificationTopic = "notification-requests"
+	}
+	if configuration.Kafka.AuditTopic == "" {
+		configuration.Kafka.AuditTopic = audit.DefaultTopic
+	}
+	if configuration.Queue.Driver == "" {
+		configuration.Queue.Driver = queue.DriverKafka
+	}
+	if configuration.Queue.MemorySize == 0 {
+		configuration.Queue.MemorySize = 1000
+	}
+	if configuration.HoldWarning.LeadTime == 0 {
+		configuration.HoldWarning.LeadTime = 3 * time.Minute
+	}
+	if configuration.HoldWarning.CheckInterval == 0 {
+		configuration.HoldWarning.CheckInterval = 30 * time.Second
+	}
+	if configuration.HoldWarning.CheckoutURL == "" {
+		configuration.HoldWarning.CheckoutURL = "http://localhost:3000/checkout?hold_id={hold_id}"
+	}
+	if configuration.HoldCleanup.Interval == 0 {
+		configuration.HoldCleanup.Interval = time.Minute
+	}
+	if configuration.HoldCleanup.BatchSize == 0 {
+		configuration.HoldCleanup.BatchSize = 500
+	}
+	if configuration.SeatGenerationStaleAfter == 0 {
+		configuration.SeatGenerationStaleAfter = 10 * time.Minute
+	}
+	if configuration.HoldConfirmGrace == 0 {
+		configuration.HoldConfirmGrace = 10 * time.Second
+	}
+	if configuration.GuestHolds.TokenTTL == 0 {
+		configuration.GuestHolds.TokenTTL = time.Hour
+	}
+	if configuration.UserService.APIBasePath == "" {
+		configuration.UserService.APIBasePath = "/api/v1"
+	}
+	if configuration.UserService.LookupTimeout == 0 {
+		configuration.UserService.LookupTimeout = 500 * time.Millisecond
+	}
+	if configuration.UserService.BreakerThreshold == 0 {
+		configuration.UserService.BreakerThreshold = 5
+	}
+	if configuration.UserService.BreakerCooldown == 0 {
+		configuration.UserService.BreakerCooldown = 30 * time.Second
+	}
+	if configuration.RateLimit.UserPerMinute == 0 {
+		configuration.RateLimit.UserPerMinute = 120
+	}
+	if configuration.RateLimit.AdminPerMinute == 0 {
+		configuration.RateLimit.AdminPerMinute = 1200
+	}
+	if configuration.RateLimit.AnonymousPerMinute == 0 {
+		configuration.RateLimit.AnonymousPerMinute = 10
+	}
+	if configuration.Startup.ConnectAttempts == 0 {
+		configuration.Startup.ConnectAttempts = 10
+	}
+	if configuration.Startup.ConnectBackoff == 0 {
+		configuration.Startup.ConnectBackoff = time.Second
+	}
+	if configuration.Startup.ConnectMaxBackoff == 0 {
+		configuration.Startup.ConnectMaxBackoff = 15 * time.Second
+	}
+	if configuration.APIBasePath == "" {
+		configuration.APIBasePath = "/api/v1"
+	}
+	if configuration.DefaultEventSort == "" {
+		configuration.DefaultEventSort = model.EventSortDate
+	}
+	if configuration.APIBasePath, err = middleware.NormalizeBasePath(configuration.APIBasePath); err != nil {
+		return nil, fmt.Errorf("invalid API base path: %w", err)
+	}
+	if configuration.UserService.APIBasePath, err = middleware.NormalizeBasePath(configuration.UserService.APIBasePath); err != nil {
+		return nil, fmt.Errorf("invalid user service API base path: %w", err)
+	}
+	if configuration.Queue.Driver != queue.DriverKafka && configuration.Queue.Driver != queue.DriverMemory {
+		return nil, fmt.Errorf("unknown message queue %q, expected %q or %q",
+			configuration.Queue.Driver, queue.DriverKafka, queue.DriverMemory)
+	}
+	if configuration.UserService.LookupTimeout < 0 || configuration.UserService.BreakerThreshold < 0 || configuration.UserService.BreakerCooldown < 0 {
+		return nil, fmt.Errorf("user lookup timeout, breaker threshold and cooldown must be positive")
+	}
+	if configuration.RateLimit.UserPerMinute < 0 || configuration.RateLimit.AdminPerMinute < 0 || configuration.RateLimit.AnonymousPerMinute < 0 {
+		return nil, fmt.Errorf("rate limit quotas must be positive")
+	}
+	if !model.ValidEventSort(configuration.DefaultEventSort) {
+		return nil, fmt.Errorf("default event sort %q must be one of %s",
+			configuration.DefaultEventSort, strings.Join(model.EventSorts, ", "))
+	}
+	if configuration.EventCategories, err = normalizeCategories(configuration.EventCategories); err != nil {
+		return nil, fmt.Errorf("invalid event categories: %w", err)
+	}
+	if configuration.Cache.Warming.HotThreshold < 0 || configuration.Cache.Warming.Window < 0 ||
+		configuration.Cache.Warming.MaxTracked < 0 || configuration.Cache.Warming.Workers < 0 {
+		return nil, fmt.Errorf("cache warming settings must be positive")
+	}
+	if configuration.Cache.Popular.Window < 0 || configuration.Cache.Popular.CacheTTL < 0 {
+		return nil, fmt.Errorf("popular events window and cache TTL must be positive durations")
+	}
+	if configuration.SlowSeatQueryThreshold < 0 {
+		return nil, fmt.Errorf("slow seat query threshold must be a positive duration")
+	}
+	if configuration.MaxSeatsPerHold < 0 {
+		return nil, fmt.Errorf("max seats per hold must be positive")
+	}
+	if configuration.Redis.PoolSize < 0 {
+		return nil, fmt.Errorf("redis pool size must be positive")
+	}
+	if configuration.Redis.DialTimeout < 0 || configuration.Redis.ReadTimeout < 0 || configuration.Redis.WriteTimeout < 0 {
+		return nil, fmt.Errorf("redis timeouts must be positive durations")
+	}
+	if configuration.SeatGenerationStaleAfter < 0 {
+		return nil, fmt.Errorf("seat generation stale after must be a positive duration")
+	}
+	if configuration.HoldConfirmGrace < 0 {
+		return nil, fmt.Errorf("hold confirm grace must be a positive duration")
+	}
+	if configuration.GuestHolds.TokenTTL < 0 {
+		return nil, fmt.Errorf("guest token TTL must be a positive duration")
+	}
+	if configuration.HoldCleanup.Interval < 0 || configuration.HoldCleanup.BatchSize < 0 {
+		return nil, fmt.Errorf("hold cleanup interval and batch size must be positive")
+	}
+	if configuration.HoldWarning.LeadTime < 0 || configuration.HoldWarning.CheckInterval < 0 {
+		return nil, fmt.Errorf("hold warning lead time and check interval must be positive durations")
+	}
+	if configuration.Cache.EventCacheTTL < 0 || configuration.Cache.SeatCacheTTL < 0 || configuration.Cache.EventListCacheTTL < 0 {
+		return nil, fmt.Errorf("cache TTLs must be positive durations")
+	}
+	if configuration.Startup.ConnectAttempts < 0 || configuration.Startup.ConnectBackoff < 0 || configuration.Startup.ConnectMaxBackoff < 0 {
+		return nil, fmt.Errorf("startup connect attempts and backoff must be positive")
+	}
+	if configuration.Pagination.DefaultPageSize > configuration.Pagination.MaxPageSize {
+		return nil, fmt.Errorf("default page size %d exceeds max page size %d",
+			configuration.Pagination.DefaultPageSize, configuration.Pagination.MaxPageSize)
+	}
 
 	return &configuration, nil
 }
 
+// normalizeCategories trims the configured categories and drops empty entries,
+// rejecting ones that only differ by case since they're matched ignoring case
+func normalizeCategories(categories []string) ([]string, error) {
+	seen := make(map[string]bool, len(categories))
+	normalized := make([]string, 0, len(categories))
+	for _, category := range categories {
+		category = strings.TrimSpace(category)
+		if category == "" {
+			continue
+		}
+		if seen[strings.ToLower(category)] {
+			return nil, fmt.Errorf("%q is listed more than once", category)
+		}
+		seen[strings.ToLower(category)] = true
+		normalized = append(normalized, category)
+	}
+	return normalized, nil
+}
+
+// CanonicalCategory matches a category against EventCategories ignoring case
+// and returns it spelled as configured. Any category matches when no
+// categories are configured.
+func (c *Config) CanonicalCategory(category string) (string, bool) {
+	if len(c.EventCategories) == 0 {
+		return category, true
+	}
+	for _, allowed := range c.EventCategories {
+		if strings.EqualFold(strings.TrimSpace(category), allowed) {
+			return allowed, true
+		}
+	}
+	return "", false
+}
+
 func GetConfig() *Config {
 	return &configuration
 }
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetDatabaseURLUsesUTCSessions(t *testing.T) {
+	db := DatabaseConfig{User: "events", Password: "secret", Host: "db", Port: "5432", DatabaseName: "events", SSLMode: "disable"}
+	if url := db.GetDatabaseURL(); !strings.Contains(url, "TimeZone=UTC") {
+		t.Errorf("GetDatabaseURL() = %q, want a TimeZone=UTC session", url)
+	}
+}
+
+func TestNormalizeCategories(t *testing.T) {
+	got, err := normalizeCategories([]string{" Concert", "Sports ", "", "Theater"})
+	if err != nil {
+		t.Fatalf("normalizeCategories() error = %v", err)
+	}
+	if strings.Join(got, ",") != "Concert,Sports,Theater" {
+		t.Errorf("normalizeCategories() = %q, want Concert, Sports and Theater", got)
+	}
+
+	if _, err := normalizeCategories([]string{"Concert", "concert"}); err == nil {
+		t.Error("normalizeCategories() accepted a category listed twice")
+	}
+}
+
+func TestCanonicalCategory(t *testing.T) {
+	restricted := &Config{EventCategories: []string{"Concert", "Sports"}}
+	tests := []struct {
+		cfg      *Config
+		category string
+		want     string
+		wantOK   bool
+	}{
+		{restricted, "Concert", "Concert", true},
+		{restricted, "concert", "Concert", true},
+		{restricted, " SPORTS ", "Sports", true},
+		{restricted, "concret", "", false},
+		{&Config{}, "anything goes", "anything goes", true},
+	}
+	for _, tt := range tests {
+		got, ok := tt.cfg.CanonicalCategory(tt.category)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("CanonicalCategory(%q) = %q, %v, want %q, %v", tt.category, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
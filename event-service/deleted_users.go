@@ -0,0 +1,56 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/cache"
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// deletedUserMarkerTTL is how long a deleted user's tokens are refused, longer
+// than any token issued before the deletion stays valid
+const deletedUserMarkerTTL = 24 * time.Hour
+
+// RejectDeletedUsers refuses the still-valid tokens of users who have deleted
+// their account. It must run after authentication. If the cache can't be
+// reached the request is let through rather than locking everyone out.
+func RejectDeletedUsers(cacheRepo cache.CacheRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deleted, err := cacheRepo.IsUserDeleted(c.GetString(middleware.ContextUserID))
+		if err != nil {
+			log.Printf("Failed to check whether user is deleted: %v", err)
+		}
+		if deleted {
+			middleware.RespondError(c, http.StatusUnauthorized, "account_deleted", "This account has been deleted")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// AnonymizeUserHolds handles the user service's request to erase a deleted
+// user's email from their holds. The user is marked deleted first so a hold
+// placed meanwhile can't slip in with their email.
+func (h *EventHandler) AnonymizeUserHolds(c *gin.Context) {
+	userID := c.Param("userId")
+
+	if err := h.cache.MarkUserDeleted(userID, deletedUserMarkerTTL); err != nil {
+		log.Printf("Failed to mark user %s deleted: %v", userID, err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to anonymize holds")
+		return
+	}
+
+	anonymized, err := h.repo.AnonymizeUserHolds(userID)
+	if err != nil {
+		log.Printf("Failed to anonymize holds of user %s: %v", userID, err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to anonymize holds")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.AnonymizeUserHoldsResponse{AnonymizedHolds: anonymized})
+}
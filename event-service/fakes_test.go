@@ -0,0 +1,161 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/cache"
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// fakeEventRepository keeps events in memory. Methods a test doesn't set up
+// panic through the embedded nil interface.
+type fakeEventRepository struct {
+	repository.EventRepository
+	events map[string]*model.Event
+	// availableSeats lists each event's free seats in venue order
+	availableSeats map[string][]string
+	holds          map[string]*model.Hold
+	createErr      error
+}
+
+func newFakeEventRepository(events ...*model.Event) *fakeEventRepository {
+	repo := &fakeEventRepository{events: make(map[string]*model.Event)}
+	for _, event := range events {
+		repo.events[event.ID] = event
+	}
+	return repo
+}
+
+func (r *fakeEventRepository) GetEventByID(id string) (*model.Event, error) {
+	event, ok := r.events[id]
+	if !ok {
+		return nil, repository.ErrEventNotFound
+	}
+	copied := *event
+	return &copied, nil
+}
+
+func (r *fakeEventRepository) CreateEvent(req model.CreateEventRequest) (*model.Event, error) {
+	if r.createErr != nil {
+		return nil, r.createErr
+	}
+	event := &model.Event{ID: req.ID, Name: req.Name, TotalSeats: req.TotalSeats, CreatedBy: req.CreatedBy}
+	r.events[event.ID] = event
+	return r.GetEventByID(event.ID)
+}
+
+func (r *fakeEventRepository) UpdateEvent(req model.UpdateEventRequest) (*model.Event, error) {
+	event, ok := r.events[req.ID]
+	if !ok {
+		return nil, repository.ErrEventNotFound
+	}
+	event.Name = req.Name
+	event.Venue = req.Venue
+	event.City = req.City
+	event.Category = req.Category
+	event.EventDate = req.EventDate
+	event.UpdatedAt = time.Now()
+	return r.GetEventByID(req.ID)
+}
+
+func (r *fakeEventRepository) GetHoldByID(holdID string) (*model.Hold, error) {
+	hold, ok := r.holds[holdID]
+	if !ok {
+		return nil, repository.ErrHoldNotFound
+	}
+	copied := *hold
+	return &copied, nil
+}
+
+func (r *fakeEventRepository) GetAvailableSeats(eventID string) ([]string, error) {
+	return r.availableSeats[eventID], nil
+}
+
+func (r *fakeEventRepository) GetAvailableSeatCount(eventID string) (int, error) {
+	return r.events[eventID].TotalSeats, nil
+}
+
+// fakeCache keeps cache entries in memory, with the same misses as Redis
+type fakeCache struct {
+	cache.CacheRepository
+	events     map[string]*model.Event
+	lists      map[string]*model.EventListResponse
+	seatCounts map[string]int
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{
+		events:     make(map[string]*model.Event),
+		lists:      make(map[string]*model.EventListResponse),
+		seatCounts: make(map[string]int),
+	}
+}
+
+func (c *fakeCache) GetEvent(eventID string) (*model.Event, error) {
+	return c.events[eventID], nil
+}
+
+func (c *fakeCache) SetEvent(eventID string, event *model.Event, ttl time.Duration) error {
+	c.events[eventID] = event
+	return nil
+}
+
+func (c *fakeCache) GetEventList(filterKey string) (*model.EventListResponse, error) {
+	return c.lists[filterKey], nil
+}
+
+func (c *fakeCache) SetEventList(filterKey string, response *model.EventListResponse, ttl time.Duration) error {
+	c.lists[filterKey] = response
+	return nil
+}
+
+func (c *fakeCache) InvalidateEventList(pattern string) error {
+	prefix := strings.TrimSuffix(pattern, "*")
+	for key := range c.lists {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.lists, key)
+		}
+	}
+	return nil
+}
+
+func (c *fakeCache) GetAvailableSeatCount(eventID string) (int, error) {
+	count, ok := c.seatCounts[eventID]
+	if !ok {
+		return -1, nil
+	}
+	return count, nil
+}
+
+func (c *fakeCache) SetAvailableSeatCount(eventID string, count int, ttl time.Duration) error {
+	c.seatCounts[eventID] = count
+	return nil
+}
+
+func (c *fakeCache) InvalidateEventRelatedCache(eventID string) error {
+	delete(c.events, eventID)
+	delete(c.seatCounts, eventID)
+	return c.InvalidateEventList("*")
+}
+
+// serve runs one request through handler on route, as the given user
+func serve(route, method, target, userID, body string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Handle(method, route, func(c *gin.Context) {
+		c.Set("user_id", userID)
+	}, handler)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
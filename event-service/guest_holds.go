@@ -0,0 +1,92 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// HoldSeatsAsGuest handles seat holds from buyers without an account. The hold
+// is placed for a one-off guest ID, and the returned token is scoped to it so
+// the guest can complete the booking or release the seats without registering.
+func (h *EventHandler) HoldSeatsAsGuest(c *gin.Context) {
+	eventID := c.Param("id")
+
+	var req model.GuestHoldSeatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+	if !h.validateHoldSize(c, req.SeatNumbers) {
+		return
+	}
+
+	guestID := uuid.New().String()
+	holdReq := req.ToCreateHoldRequest(guestID, eventID, time.Now().UTC().Add(holdDuration))
+	holdReq.UserEmail = req.Email
+	holdReq.UserLocale = req.Locale
+	holdReq.Guest = true
+
+	hold, ok := h.createHold(c, holdReq)
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(h.cfg.GuestHolds.TokenTTL)
+	token, err := h.jwtService.GenerateToken(auth.Claims{
+		UserID: guestID,
+		Email:  req.Email,
+		Role:   auth.RoleGuest,
+		Locale: req.Locale,
+		HoldID: hold.HoldID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	})
+	if err != nil {
+		// Without a token the guest can't use the hold, so give the seats back
+		log.Printf("Failed to issue guest token for hold %s: %v", hold.HoldID, err)
+		if err := h.repo.ReleaseHold(hold.HoldID); err == nil {
+			h.cache.InvalidateAvailableSeats(eventID)
+			h.cache.InvalidateAvailableSeatCount(eventID)
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to hold seats")
+		return
+	}
+
+	c.JSON(http.StatusCreated, model.GuestHoldResponse{
+		HoldResponse:        *hold,
+		GuestToken:          token,
+		GuestTokenExpiresAt: expiresAt,
+	})
+}
+
+// GuestAuthMiddleware authenticates like AuthMiddleware but also accepts
+// guest tokens. Routes using it must be scoped with RequireGuestHold.
+func GuestAuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
+	return middleware.AuthAllowGuests(jwtService, middleware.RespondError, middleware.DefaultAuthErrorCodes)
+}
+
+// RequireGuestHold limits guest tokens to the hold named in the :holdId
+// parameter. Account holders pass through unchanged.
+func RequireGuestHold() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(middleware.ContextUserRole) == auth.RoleGuest &&
+			c.GetString(middleware.ContextGuestHoldID) != c.Param("holdId") {
+			middleware.RespondError(c, http.StatusForbidden, "hold_not_owned", "Guest token is not valid for this hold")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
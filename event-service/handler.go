@@ -1,27 +1,66 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/arunvm123/eventbooking/event-service/cache"
 	"github.com/arunvm123/eventbooking/event-service/cache/redis"
+	"github.com/arunvm123/eventbooking/event-service/config"
 	"github.com/arunvm123/eventbooking/event-service/model"
 	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/shared/pagination"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// eventDateGracePeriod tolerates small clock skew between clients and the server
+// when rejecting event dates in the past
+const eventDateGracePeriod = 5 * time.Minute
+
+// holdDuration is how long held seats stay locked for checkout
+const holdDuration = 15 * time.Minute
+
+const (
+	// defaultSeatPageSize is the number of seat numbers returned when paging
+	// without seat_limit, enough for a full generated row
+	defaultSeatPageSize = 500
+
+	// maxSeatPageSize caps seat_limit to keep event detail responses small
+	maxSeatPageSize = 5000
+
+	// maxSeatAlternatives caps the seats suggested when a hold conflicts, so a
+	// large reservation doesn't return a huge conflict body
+	maxSeatAlternatives = 50
+)
+
 type EventHandler struct {
-	repo  repository.EventRepository
-	cache cache.CacheRepository
+	cfg        *config.Config
+	repo       repository.EventRepository
+	cache      cache.CacheRepository
+	jwtService *auth.JWTService
+	userNames  *UserNameLookup
+	audit      *audit.Recorder
 }
 
-func NewEventHandler(repo repository.EventRepository, cache cache.CacheRepository) *EventHandler {
+func NewEventHandler(cfg *config.Config, repo repository.EventRepository, cache cache.CacheRepository, jwtService *auth.JWTService, userNames *UserNameLookup, auditor *audit.Recorder) *EventHandler {
 	return &EventHandler{
-		repo:  repo,
-		cache: cache,
+		cfg:        cfg,
+		repo:       repo,
+		cache:      cache,
+		jwtService: jwtService,
+		userNames:  userNames,
+		audit:      auditor,
 	}
 }
 
@@ -29,49 +68,76 @@ func NewEventHandler(repo repository.EventRepository, cache cache.CacheRepositor
 func (h *EventHandler) CreateEvent(c *gin.Context) {
 	var req model.CreateEventAPIRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{
-			Error:   "validation_failed",
-			Message: err.Error(),
-		})
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	if fieldErr := validateEventDate(req.EventDate, time.Now().UTC()); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
+		return
+	}
+
+	if fieldErr := validateCurrency(req.Currency); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
+		return
+	}
+
+	if fieldErr := h.validateCategory(&req.Category); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
+		return
+	}
+
+	if fieldErr := validateSeatLabels(req.SeatLabels, req.TotalSeats); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User ID not found in token AAA",
-		})
+		middleware.RespondError(c, http.StatusUnauthorized, "unauthorized", "User ID not found in token AAA")
 		return
 	}
 
 	userIDStr, ok := userID.(string)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Invalid user ID format",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Invalid user ID format")
 		return
 	}
 
-	// Convert API request to repository request and generate UUID
-	createReq := req.ToCreateEventRequest(userIDStr)
+	// Convert API request to repository request
+	h.createEvent(c, req.ToCreateEventRequest(userIDStr))
+}
+
+// createEvent stores a new event and responds with it, generating the seats of
+// large events in the background
+func (h *EventHandler) createEvent(c *gin.Context, createReq model.CreateEventRequest) {
 	createReq.ID = uuid.New().String()
+	createReq.GenerateSeatsAsync = createReq.TotalSeats > h.cfg.AsyncSeatThreshold
 
 	// Create event
 	event, err := h.repo.CreateEvent(createReq)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create event",
-		})
+		if errors.Is(err, repository.ErrSeatCreationFailed) {
+			log.Printf("Failed to create seats for new event: %v", err)
+			middleware.RespondError(c, http.StatusServiceUnavailable, "seat_creation_failed",
+				"The event's seats could not be created, so the event was not saved. Please try again.")
+			return
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to create event")
 		return
 	}
 
 	// Invalidate event list caches since new event was created
 	h.cache.InvalidateEventList("*")
 
+	// Large events are returned straight away while their seats are generated
+	if createReq.GenerateSeatsAsync {
+		go h.generateSeats(event.ID, createReq.TotalSeats, createReq.SeatLabels)
+		c.JSON(http.StatusAccepted, event.ToEventResponse(0))
+		return
+	}
+
 	// Get available seat count for response
 	availableSeats, err := h.repo.GetAvailableSeatCount(event.ID)
 	if err != nil {
@@ -82,359 +148,1350 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 	c.JSON(http.StatusCreated, response)
 }
 
-// GetEvent handles retrieving a single event by ID
-func (h *EventHandler) GetEvent(c *gin.Context) {
+// DuplicateEvent copies an event to a new date for recurring shows. The copy
+// keeps the source's details, pricing and seat layout unless overridden in the
+// request, starts with every seat available and belongs to the caller.
+func (h *EventHandler) DuplicateEvent(c *gin.Context) {
 	eventID := c.Param("id")
 
-	// Try to get event from cache first
-	event, err := h.cache.GetEvent(eventID)
-	if err != nil || event == nil {
-		// Cache miss, get from database
-		event, err = h.repo.GetEventByID(eventID)
-		if err != nil {
-			if err.Error() == "event not found" {
-				c.JSON(http.StatusNotFound, model.ErrorResponse{
-					Error:   "not_found",
-					Message: "Event not found",
-				})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to retrieve event",
-			})
+	var req model.DuplicateEventAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	if fieldErr := validateEventDate(req.EventDate, time.Now().UTC()); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
+		return
+	}
+	if fieldErr := h.validateEventChanges(&req.UpdateEventAPIRequest); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
+		return
+	}
+
+	source, err := h.repo.GetEventByID(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrEventNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
 			return
 		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve event")
+		return
+	}
+	userID := c.GetString("user_id")
+	if source.CreatedBy != userID && !isAdmin(c) {
+		middleware.RespondError(c, http.StatusForbidden, "forbidden", "Only the event creator can duplicate this event")
+		return
+	}
 
-		// Cache the event for 5 minutes
-		h.cache.SetEvent(eventID, event, 5*time.Minute)
+	// The seat layout is read from the source's seats, so they must all exist
+	if source.SeatStatus != model.SeatStatusReady {
+		middleware.RespondError(c, http.StatusConflict, "seats_not_ready", "The event's seats are not ready to be copied")
+		return
+	}
+	labels, err := h.repo.GetCustomSeatLabels(eventID, source.TotalSeats)
+	if err != nil {
+		log.Printf("Failed to read seat layout of event %s: %v", eventID, err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to duplicate event")
+		return
 	}
 
-	// Try to get available seat count from cache first
-	availableSeats, err := h.cache.GetAvailableSeatCount(eventID)
-	if err != nil || availableSeats == -1 {
-		// Cache miss, get from database
-		availableSeats, err = h.repo.GetAvailableSeatCount(eventID)
-		if err != nil {
-			availableSeats = 0
-		} else {
-			// Cache the seat count for 30 seconds (more frequent updates)
-			h.cache.SetAvailableSeatCount(eventID, availableSeats, 30*time.Second)
-		}
+	createReq := req.ToCreateEventRequest(source, userID)
+	createReq.SeatLabels = labels
+	h.createEvent(c, createReq)
+}
+
+// generateSeats populates seats for a large event in the background and
+// refreshes the cached event once they're ready
+func (h *EventHandler) generateSeats(eventID string, totalSeats int, labels []string) {
+	start := time.Now()
+	if err := h.repo.GenerateSeats(eventID, totalSeats, labels); err != nil {
+		log.Printf("Seat generation failed for event %s: %v", eventID, err)
+	} else {
+		log.Printf("Generated %d seats for event %s in %s", totalSeats, eventID, time.Since(start))
 	}
 
-	response := event.ToEventResponse(availableSeats)
+	h.cache.InvalidateEvent(eventID)
+	h.cache.InvalidateAvailableSeats(eventID)
+	h.cache.InvalidateAvailableSeatCount(eventID)
+	h.cache.InvalidateEventList("*")
+}
+
+// GetEvent handles retrieving a single event by ID
+func (h *EventHandler) GetEvent(c *gin.Context) {
+	eventID := c.Param("id")
+
+	// Seat numbers are only returned when asked for, either with include_seats=true
+	// or by paging through them with seat_limit, seat_offset or seat_prefix
+	seatQuery, ok := parseSeatQuery(c)
+	if !ok {
+		return
+	}
+
+	event, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+	h.cache.RecordEventView(eventID, h.cfg.Cache.Popular.Window)
+
+	response := event.ToEventResponse(h.availableSeatCount(eventID))
+	if !seatQuery.include {
+		c.JSON(http.StatusOK, response)
+		return
+	}
 
-	// Try to get available seat numbers from cache first
+	// The full seat list is cached; pages are sliced from it per request
 	seatNumbers, err := h.cache.GetAvailableSeats(eventID)
 	if err != nil || seatNumbers == nil {
 		// Cache miss, get from database
 		seatNumbers, err = h.repo.GetAvailableSeats(eventID)
 		if err == nil && seatNumbers != nil {
-			// Cache seat numbers for 30 seconds
-			h.cache.SetAvailableSeats(eventID, seatNumbers, 30*time.Second)
-			response.AvailableSeatNumbers = seatNumbers
+			h.cache.SetAvailableSeats(eventID, seatNumbers, h.cfg.Cache.SeatCacheTTL)
 		}
-	} else {
-		response.AvailableSeatNumbers = seatNumbers
 	}
 
+	response.AvailableSeatNumbers, response.SeatPagination = seatQuery.page(seatNumbers)
+
 	c.JSON(http.StatusOK, response)
 }
 
-// ListEvents handles event listing with filtering and pagination
-func (h *EventHandler) ListEvents(c *gin.Context) {
-	// Parse query parameters
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+// seatQuery holds the seat list options for GetEvent
+type seatQuery struct {
+	include bool
+	limit   int
+	offset  int
+	prefix  string
+}
 
-	// Validate limits
-	if limit > 100 {
-		limit = 100
+// parseSeatQuery reads the seat list query parameters, writing a 400 response
+// and returning false if any are invalid
+func parseSeatQuery(c *gin.Context) (seatQuery, bool) {
+	query := seatQuery{
+		limit:  defaultSeatPageSize,
+		prefix: c.Query("seat_prefix"),
 	}
-	if limit < 1 {
-		limit = 20
+
+	if raw := c.Query("include_seats"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "invalid_request", "include_seats must be true or false")
+			return query, false
+		}
+		query.include = parsed
 	}
 
-	filter := model.EventFilter{
-		City:     c.Query("city"),
-		Category: c.Query("category"),
-		Name:     c.Query("name"),
-		Limit:    limit,
-		Offset:   offset,
+	if raw := c.Query("seat_limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			middleware.RespondError(c, http.StatusBadRequest, "invalid_request", "seat_limit must be a positive integer")
+			return query, false
+		}
+		query.limit = min(limit, maxSeatPageSize)
+		query.include = true
 	}
 
-	// Parse date filters
-	if dateFromStr := c.Query("date_from"); dateFromStr != "" {
-		if dateFrom, err := time.Parse("2006-01-02", dateFromStr); err == nil {
-			filter.DateFrom = &dateFrom
+	if raw := c.Query("seat_offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			middleware.RespondError(c, http.StatusBadRequest, "invalid_request", "seat_offset must be a non-negative integer")
+			return query, false
 		}
+		query.offset = offset
+		query.include = true
 	}
-	if dateToStr := c.Query("date_to"); dateToStr != "" {
-		if dateTo, err := time.Parse("2006-01-02", dateToStr); err == nil {
-			filter.DateTo = &dateTo
+
+	if query.prefix != "" {
+		query.include = true
+	}
+
+	return query, true
+}
+
+// page filters seat numbers by prefix and returns the requested slice
+func (q seatQuery) page(seatNumbers []string) ([]string, *model.Pagination) {
+	if q.prefix != "" {
+		filtered := make([]string, 0)
+		for _, seat := range seatNumbers {
+			if strings.HasPrefix(seat, q.prefix) {
+				filtered = append(filtered, seat)
+			}
 		}
+		seatNumbers = filtered
 	}
 
-	// Try to get cached event list first
-	filterKey := redis.GenerateFilterKey(filter)
-	cachedResponse, err := h.cache.GetEventList(filterKey)
-	if err == nil && cachedResponse != nil {
-		// Cache hit
-		c.JSON(http.StatusOK, cachedResponse)
+	total := len(seatNumbers)
+	start := min(q.offset, total)
+	end := min(start+q.limit, total)
+
+	return seatNumbers[start:end], &model.Pagination{
+		Total:   total,
+		Limit:   q.limit,
+		Offset:  q.offset,
+		HasMore: end < total,
+	}
+}
+
+// UpdateEvent handles partial updates to an event by its creator
+func (h *EventHandler) UpdateEvent(c *gin.Context) {
+	eventID := c.Param("id")
+
+	var req model.UpdateEventAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
 		return
 	}
 
-	// Cache miss, get from database
-	events, total, err := h.repo.ListEvents(filter)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to retrieve events",
-		})
+	if fieldErr := h.validateEventChanges(&req); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
 		return
 	}
 
-	// Convert to response format
-	var eventResponses []model.EventResponse
-	for _, event := range events {
-		// Try to get available seat count from cache first
-		availableSeats, err := h.cache.GetAvailableSeatCount(event.ID)
-		if err != nil || availableSeats == -1 {
-			// Cache miss, get from database
-			availableSeats, err = h.repo.GetAvailableSeatCount(event.ID)
-			if err != nil {
-				availableSeats = 0
-			} else {
-				// Cache the seat count for 30 seconds
-				h.cache.SetAvailableSeatCount(event.ID, availableSeats, 30*time.Second)
-			}
-		}
-		eventResponses = append(eventResponses, *event.ToEventResponse(availableSeats))
+	// Read from the database rather than the cache so updates never build on stale data
+	event, ok := h.ownedEvent(c, eventID)
+	if !ok {
+		return
 	}
 
-	response := model.EventListResponse{
-		Events: eventResponses,
-		Pagination: model.Pagination{
-			Total:   total,
-			Limit:   limit,
-			Offset:  offset,
-			HasMore: offset+limit < total,
-		},
+	updated, err := h.repo.UpdateEvent(req.ToUpdateEventRequest(event))
+	if err != nil {
+		if errors.Is(err, repository.ErrEventNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
+			return
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to update event")
+		return
 	}
 
-	// Cache the response for 2 minutes
-	h.cache.SetEventList(filterKey, &response, 2*time.Minute)
+	// Cached lists are keyed by city, category and name filters, so any of them
+	// may now hold outdated entries for this event
+	h.cache.InvalidateEventRelatedCache(eventID)
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, updated.ToEventResponse(h.availableSeatCount(eventID)))
 }
 
-// HoldSeats handles seat holding requests
-func (h *EventHandler) HoldSeats(c *gin.Context) {
+// PatchEvent handles sparse event updates by the creator, writing only the
+// fields present in the body
+func (h *EventHandler) PatchEvent(c *gin.Context) {
 	eventID := c.Param("id")
 
-	var req model.HoldSeatsRequest
+	var req model.PatchEventAPIRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{
-			Error:   "validation_failed",
-			Message: err.Error(),
-		})
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
 		return
 	}
 
-	// Get user ID from context
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User ID not found in token",
-		})
+	if fieldErr := h.validateEventChanges(&req.UpdateEventAPIRequest); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
 		return
 	}
 
-	userIDStr, ok := userID.(string)
+	event, ok := h.ownedEvent(c, eventID)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Invalid user ID format",
-		})
 		return
 	}
 
-	// Hold expires in 15 minutes
-	expiresAt := time.Now().Add(15 * time.Minute)
+	// Seats are generated up front, so resizing would need more than a column update
+	if req.TotalSeats != nil && *req.TotalSeats != event.TotalSeats {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", "total_seats can't be changed once seats are generated",
+			[]model.FieldError{{Field: "total_seats", Message: "total_seats can't be changed once seats are generated"}})
+		return
+	}
 
-	// Convert to repository request and generate UUID
-	holdReq := req.ToCreateHoldRequest(userIDStr, eventID, expiresAt)
-	holdReq.ID = uuid.New().String()
+	fields := req.ToPatchFields()
+	if len(fields) == 0 {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", "Request must change at least one field")
+		return
+	}
 
-	// Create hold
-	hold, err := h.repo.CreateHold(holdReq)
+	updated, err := h.repo.PatchEvent(eventID, fields)
 	if err != nil {
-		errorMessage := err.Error()
-		if errorMessage == "seats not available" {
-			c.JSON(http.StatusConflict, model.ErrorResponse{
-				Error:   "seats_unavailable",
-				Message: "Some requested seats are not available",
-			})
+		if errors.Is(err, repository.ErrEventNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
 			return
 		}
-		if len(errorMessage) > 25 && errorMessage[:25] == "seat numbers do not exist" {
-			c.JSON(http.StatusBadRequest, model.ErrorResponse{
-				Error:   "invalid_seats",
-				Message: errorMessage,
-			})
-			return
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to update event")
+		return
+	}
+
+	h.cache.InvalidateEventRelatedCache(eventID)
+
+	c.JSON(http.StatusOK, updated.ToEventResponse(h.availableSeatCount(eventID)))
+}
+
+// validateEventChanges checks the optional fields of an update or patch that
+// can't be expressed as binding tags
+func (h *EventHandler) validateEventChanges(req *model.UpdateEventAPIRequest) *model.FieldError {
+	if req.EventDate != nil {
+		if fieldErr := validateEventDate(*req.EventDate, time.Now().UTC()); fieldErr != nil {
+			return fieldErr
+		}
+	}
+	if req.Currency != nil {
+		if fieldErr := validateCurrency(*req.Currency); fieldErr != nil {
+			return fieldErr
+		}
+	}
+	if req.Category != nil {
+		if fieldErr := h.validateCategory(req.Category); fieldErr != nil {
+			return fieldErr
+		}
+	}
+	return nil
+}
+
+// validateCategory checks the category against the configured categories and
+// rewrites it to their spelling, so "concert" is stored as "Concert"
+func (h *EventHandler) validateCategory(category *string) *model.FieldError {
+	canonical, ok := h.cfg.CanonicalCategory(*category)
+	if !ok {
+		return &model.FieldError{
+			Field:   "category",
+			Message: fmt.Sprintf("category must be one of %s", strings.Join(h.cfg.EventCategories, ", ")),
 		}
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to hold seats",
-		})
+	}
+	*category = canonical
+	return nil
+}
+
+// ListCategories returns the categories events may use so frontends can offer
+// them as choices. An empty list with restricted false means any category is accepted.
+func (h *EventHandler) ListCategories(c *gin.Context) {
+	c.JSON(http.StatusOK, model.CategoriesResponse{
+		Categories: append([]string{}, h.cfg.EventCategories...),
+		Restricted: len(h.cfg.EventCategories) > 0,
+	})
+}
+
+// DeleteEvent handles deleting an event by its creator
+func (h *EventHandler) DeleteEvent(c *gin.Context) {
+	eventID := c.Param("id")
+
+	event, ok := h.ownedEvent(c, eventID)
+	if !ok {
 		return
 	}
 
-	// Invalidate seat-related caches since seats were held
-	h.cache.InvalidateAvailableSeats(eventID)
-	h.cache.InvalidateAvailableSeatCount(eventID)
+	if err := h.repo.DeleteEvent(eventID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEventNotFound):
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
+		case errors.Is(err, repository.ErrEventHasBookings):
+			middleware.RespondError(c, http.StatusConflict, "event_has_bookings", "Events with active holds or bookings can't be deleted")
+		default:
+			middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to delete event")
+		}
+		return
+	}
 
-	// Get event to calculate total price
+	h.cache.InvalidateEventRelatedCache(eventID)
+	h.audit.RecordRequest(c, audit.ActionEventDeleted, audit.Target("event", eventID),
+		map[string]string{"name": event.Name})
+
+	c.Status(http.StatusNoContent)
+}
+
+// ownedEvent loads an event from the database and checks that the caller
+// created it, writing the error response and returning false otherwise
+func (h *EventHandler) ownedEvent(c *gin.Context, eventID string) (*model.Event, bool) {
 	event, err := h.repo.GetEventByID(eventID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to retrieve event details",
-		})
-		return
+		if errors.Is(err, repository.ErrEventNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
+			return nil, false
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve event")
+		return nil, false
 	}
 
-	totalPrice := event.PricePerSeat * float64(len(req.SeatNumbers))
-	response := hold.ToHoldResponse(totalPrice)
+	if event.CreatedBy != c.GetString("user_id") {
+		middleware.RespondError(c, http.StatusForbidden, "forbidden", "Only the event creator can modify this event")
+		return nil, false
+	}
 
-	c.JSON(http.StatusCreated, response)
+	return event, true
 }
 
-// ReleaseHold handles releasing a seat hold
-func (h *EventHandler) ReleaseHold(c *gin.Context) {
-	holdID := c.Param("holdId")
+// GetSeatCount returns only the available seat count for an event, without the seat list
+func (h *EventHandler) GetSeatCount(c *gin.Context) {
+	eventID := c.Param("id")
 
-	// Get hold first to know which event to invalidate cache for
-	hold, err := h.repo.GetHoldByID(holdID)
+	event, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SeatCountResponse{
+		EventID:        event.ID,
+		TotalSeats:     event.TotalSeats,
+		AvailableSeats: h.availableSeatCount(eventID),
+	})
+}
+
+// ListEventHolds handles listing an event's holds so organizers can see why
+// seats appear unavailable. Only active holds are returned unless status=all.
+func (h *EventHandler) ListEventHolds(c *gin.Context) {
+	eventID := c.Param("id")
+
+	event, err := h.repo.GetEventByID(eventID)
 	if err != nil {
-		if err.Error() == "hold not found" {
-			c.JSON(http.StatusNotFound, model.ErrorResponse{
-				Error:   "not_found",
-				Message: "Hold not found",
-			})
+		if errors.Is(err, repository.ErrEventNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get hold details",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve event")
+		return
+	}
+	if event.CreatedBy != c.GetString("user_id") && !isAdmin(c) {
+		middleware.RespondError(c, http.StatusForbidden, "forbidden", "Only the event creator can view its holds")
+		return
+	}
+
+	var onlyActive bool
+	switch c.DefaultQuery("status", "active") {
+	case "active":
+		onlyActive = true
+	case "all":
+	default:
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", "status must be active or all",
+			[]model.FieldError{{Field: "status", Message: "status must be active or all"}})
+		return
+	}
+
+	page, ok := h.parsePage(c)
+	if !ok {
 		return
 	}
 
-	err = h.repo.ReleaseHold(holdID)
+	holds, total, err := h.repo.ListHoldsByEvent(eventID, onlyActive, page.Limit, page.Offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to release hold",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve holds")
 		return
 	}
 
-	// Invalidate seat-related caches since seats were released
-	h.cache.InvalidateAvailableSeats(hold.EventID)
-	h.cache.InvalidateAvailableSeatCount(hold.EventID)
+	response := model.EventHoldsResponse{
+		EventID:    eventID,
+		Holds:      make([]model.EventHoldResponse, len(holds)),
+		Pagination: page.Paginate(total),
+	}
+	for i := range holds {
+		response.Holds[i] = holds[i].ToEventHoldResponse()
+	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Hold released successfully"})
+	c.JSON(http.StatusOK, response)
 }
 
-// GetHoldDetails handles retrieving hold details by ID
-func (h *EventHandler) GetHoldDetails(c *gin.Context) {
-	holdID := c.Param("holdId")
+// GetSeatHistory handles listing an event's seat status transitions for
+// admins reconstructing what happened to a seat during a dispute
+func (h *EventHandler) GetSeatHistory(c *gin.Context) {
+	eventID := c.Param("id")
 
-	// Get hold details
-	hold, err := h.repo.GetHoldByID(holdID)
-	if err != nil {
-		if err.Error() == "hold not found" {
-			c.JSON(http.StatusNotFound, model.ErrorResponse{
-				Error:   "not_found",
-				Message: "Hold not found",
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get hold details",
-		})
+	if _, ok := h.loadEvent(c, eventID); !ok {
 		return
 	}
 
-	// Get event details for additional information
-	event, err := h.repo.GetEventByID(hold.EventID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get event details",
-		})
+	page, ok := h.parsePage(c)
+	if !ok {
 		return
 	}
 
-	// Calculate total price
-	totalPrice := event.PricePerSeat * float64(len(hold.SeatNumbers))
+	events, total, err := h.repo.GetSeatHistory(model.SeatHistoryFilter{
+		EventID:    eventID,
+		SeatNumber: c.Query("seat"),
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+	})
+	if err != nil {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve seat history")
+		return
+	}
 
-	// Create response
-	response := model.HoldDetailsResponse{
-		HoldID:     hold.ID,
-		UserID:     hold.UserID,
-		EventID:    hold.EventID,
-		EventName:  event.Name,
-		Venue:      event.Venue,
-		EventDate:  event.EventDate,
-		Seats:      hold.SeatNumbers,
-		TotalPrice: totalPrice,
-		ExpiresAt:  hold.ExpiresAt,
+	response := model.SeatHistoryResponse{
+		EventID:    eventID,
+		Events:     make([]model.SeatStatusEventResponse, len(events)),
+		Pagination: page.Paginate(total),
+	}
+	for i := range events {
+		response.Events[i] = events[i].ToSeatStatusEventResponse()
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
-// ConfirmHold handles confirming a seat hold (booking)
-func (h *EventHandler) ConfirmHold(c *gin.Context) {
-	holdID := c.Param("holdId")
+// UpdateSeatStatuses handles manually setting seats available or blocked so
+// operators can repair seat states after a data issue
+func (h *EventHandler) UpdateSeatStatuses(c *gin.Context) {
+	eventID := c.Param("id")
 
-	// Get hold first to know which event to invalidate cache for
-	hold, err := h.repo.GetHoldByID(holdID)
+	var req model.UpdateSeatStatusAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	if _, ok := h.loadEvent(c, eventID); !ok {
+		return
+	}
+
+	updated, err := h.repo.UpdateSeatStatuses(req.ToUpdateSeatStatusRequest(eventID))
 	if err != nil {
-		if err.Error() == "hold not found" {
-			c.JSON(http.StatusNotFound, model.ErrorResponse{
-				Error:   "not_found",
-				Message: "Hold not found",
-			})
-			return
+		switch {
+		case errors.Is(err, repository.ErrSeatsNotFound):
+			middleware.RespondError(c, http.StatusBadRequest, "invalid_seats", err.Error())
+		case errors.Is(err, repository.ErrSeatsHeld):
+			middleware.RespondError(c, http.StatusConflict, "seats_held", "Held seats can't be changed until their hold is released or expires: "+err.Error())
+		case errors.Is(err, repository.ErrSeatsBooked):
+			middleware.RespondError(c, http.StatusConflict, "seats_booked", "Set force to change booked seats: "+err.Error())
+		default:
+			log.Printf("Failed to update seat statuses for event %s: %v", eventID, err)
+			middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to update seat statuses")
 		}
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get hold details",
-		})
 		return
 	}
 
-	err = h.repo.ConfirmHold(holdID)
+	log.Printf("Admin %s set %d seats of event %s to %s", c.GetString("user_id"), updated, eventID, req.Status)
+	h.audit.RecordRequest(c, audit.ActionSeatsUpdated, audit.Target("event", eventID), map[string]string{
+		"status":  req.Status,
+		"seats":   strings.Join(req.SeatNumbers, ","),
+		"updated": strconv.Itoa(updated),
+		"force":   strconv.FormatBool(req.Force),
+	})
+
+	// Invalidate seat-related caches since seat availability changed
+	if updated > 0 {
+		h.cache.InvalidateAvailableSeats(eventID)
+		h.cache.InvalidateAvailableSeatCount(eventID)
+	}
+
+	c.JSON(http.StatusOK, model.UpdateSeatStatusResponse{
+		EventID: eventID,
+		Status:  req.Status,
+		Updated: updated,
+	})
+}
+
+// RegenerateSeats handles creating the seats an event is missing after seat
+// generation partially failed. Events whose seats are intact are unchanged.
+func (h *EventHandler) RegenerateSeats(c *gin.Context) {
+	eventID := c.Param("id")
+
+	added, err := h.repo.RegenerateMissingSeats(eventID, h.cfg.SeatGenerationStaleAfter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to confirm hold",
-		})
+		switch {
+		case errors.Is(err, repository.ErrEventNotFound):
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
+		case errors.Is(err, repository.ErrSeatsGenerating):
+			middleware.RespondError(c, http.StatusConflict, "seats_generating", "Seats are still being generated for this event")
+		case errors.Is(err, repository.ErrCustomSeatLabels):
+			middleware.RespondError(c, http.StatusConflict, "custom_seat_labels", "Seats with custom labels can't be regenerated")
+		default:
+			log.Printf("Failed to regenerate seats for event %s: %v", eventID, err)
+			middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to regenerate seats")
+		}
 		return
 	}
 
-	// Invalidate seat-related caches since seats were booked
-	h.cache.InvalidateAvailableSeats(hold.EventID)
-	h.cache.InvalidateAvailableSeatCount(hold.EventID)
+	if added > 0 {
+		log.Printf("Regenerated %d missing seats for event %s", added, eventID)
+	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Booking confirmed successfully"})
+	// The seat status may have moved from failed to ready even when nothing was added
+	h.cache.InvalidateEvent(eventID)
+	h.cache.InvalidateAvailableSeats(eventID)
+	h.cache.InvalidateAvailableSeatCount(eventID)
+	h.cache.InvalidateEventList("*")
+
+	event, err := h.repo.GetEventByID(eventID)
+	if err != nil {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve event")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.RegenerateSeatsResponse{
+		EventID:    eventID,
+		TotalSeats: event.TotalSeats,
+		SeatsAdded: added,
+	})
+}
+
+// loadEvent fetches an event from cache or the database, writing the error
+// response and returning false if it can't be loaded
+func (h *EventHandler) loadEvent(c *gin.Context, eventID string) (*model.Event, bool) {
+	// Try to get event from cache first
+	event, err := h.cache.GetEvent(eventID)
+	if err == nil && event != nil {
+		return event, true
+	}
+
+	// Cache miss, get from database
+	event, err = h.repo.GetEventByID(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrEventNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
+			return nil, false
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve event")
+		return nil, false
+	}
+
+	h.cache.SetEvent(eventID, event, h.cfg.Cache.EventCacheTTL)
+	return event, true
+}
+
+// availableSeatCount returns the number of available seats, preferring the cache
+func (h *EventHandler) availableSeatCount(eventID string) int {
+	// Try to get available seat count from cache first
+	availableSeats, err := h.cache.GetAvailableSeatCount(eventID)
+	if err == nil && availableSeats != -1 {
+		return availableSeats
+	}
+
+	// Cache miss, get from database
+	availableSeats, err = h.repo.GetAvailableSeatCount(eventID)
+	if err != nil {
+		return 0
+	}
+
+	h.cache.SetAvailableSeatCount(eventID, availableSeats, h.cfg.Cache.SeatCacheTTL)
+	return availableSeats
+}
+
+// parsePage reads the requested page of a list endpoint, responding with 400
+// for a malformed cursor
+func (h *EventHandler) parsePage(c *gin.Context) (pagination.Page, bool) {
+	page, err := pagination.FromQuery(c, h.cfg.Pagination.Limits())
+	if err != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", "cursor is invalid",
+			[]model.FieldError{{Field: "cursor", Message: "cursor must be a next_cursor from a previous page"}})
+		return pagination.Page{}, false
+	}
+	return page, true
+}
+
+// ListEvents handles event listing with filtering and pagination
+func (h *EventHandler) ListEvents(c *gin.Context) {
+	// Parse query parameters
+	page, ok := h.parsePage(c)
+	if !ok {
+		return
+	}
+
+	filter := model.EventFilter{
+		City:     c.Query("city"),
+		Category: c.Query("category"),
+		Name:     c.Query("name"),
+		Sort:     c.DefaultQuery("sort", h.cfg.DefaultEventSort),
+		Limit:    page.Limit,
+		Offset:   page.Offset,
+	}
+	if !model.ValidEventSort(filter.Sort) {
+		message := "sort must be one of " + strings.Join(model.EventSorts, ", ")
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", message,
+			[]model.FieldError{{Field: "sort", Message: message}})
+		return
+	}
+
+	// Parse date filters
+	if dateFromStr := c.Query("date_from"); dateFromStr != "" {
+		if dateFrom, err := time.Parse("2006-01-02", dateFromStr); err == nil {
+			filter.DateFrom = &dateFrom
+		}
+	}
+	if dateToStr := c.Query("date_to"); dateToStr != "" {
+		if dateTo, err := time.Parse("2006-01-02", dateToStr); err == nil {
+			filter.DateTo = &dateTo
+		}
+	}
+
+	// Parse price range filters
+	var fieldErr *model.FieldError
+	if filter.PriceMin, fieldErr = parsePriceBound(c.Query("price_min"), "price_min"); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
+		return
+	}
+	if filter.PriceMax, fieldErr = parsePriceBound(c.Query("price_max"), "price_max"); fieldErr != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", fieldErr.Message, []model.FieldError{*fieldErr})
+		return
+	}
+	if filter.PriceMin != nil && filter.PriceMax != nil && *filter.PriceMin > *filter.PriceMax {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", "price_min must not exceed price_max",
+			[]model.FieldError{{Field: "price_min", Message: "price_min must not exceed price_max"}})
+		return
+	}
+
+	// Try to get cached event list first
+	filterKey := redis.GenerateFilterKey(filter)
+	cachedResponse, err := h.cache.GetEventList(filterKey)
+	if err == nil && cachedResponse != nil {
+		// Cache hit
+		c.JSON(http.StatusOK, cachedResponse)
+		return
+	}
+
+	// Cache miss, get from database
+	events, total, err := h.repo.ListEvents(filter)
+	if err != nil {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve events")
+		return
+	}
+
+	// Convert to response format
+	var eventResponses []model.EventResponse
+	for _, event := range events {
+		eventResponses = append(eventResponses, *event.ToEventResponse(h.availableSeatCount(event.ID)))
+	}
+
+	response := model.EventListResponse{
+		Events:     eventResponses,
+		Pagination: page.Paginate(total),
+	}
+
+	h.cache.SetEventList(filterKey, &response, h.cfg.Cache.EventListCacheTTL)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// HoldSeats handles seat holding requests
+func (h *EventHandler) HoldSeats(c *gin.Context) {
+	eventID := c.Param("id")
+
+	var req model.HoldSeatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+	if !h.validateHoldSize(c, req.SeatNumbers) {
+		return
+	}
+
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		middleware.RespondError(c, http.StatusUnauthorized, "unauthorized", "User ID not found in token")
+		return
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Invalid user ID format")
+		return
+	}
+
+	holdReq := req.ToCreateHoldRequest(userIDStr, eventID, time.Now().UTC().Add(holdDuration))
+	holdReq.UserEmail = c.GetString("user_email")
+	holdReq.UserLocale = c.GetString(middleware.ContextUserLocale)
+
+	response, ok := h.createHold(c, holdReq)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// HoldSeatsMulti handles holding seats on several events in one request, e.g.
+// a festival bundle. The holds are placed together under a group hold ID, all
+// or nothing, and the group ID books them as a single hold.
+func (h *EventHandler) HoldSeatsMulti(c *gin.Context) {
+	var req model.MultiHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		middleware.RespondError(c, http.StatusUnauthorized, "unauthorized", "User ID not found in token")
+		return
+	}
+
+	group := model.HoldGroup{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		ExpiresAt: time.Now().UTC().Add(holdDuration),
+	}
+
+	// A group books as one payment, so every event must be priced in the
+	// same currency
+	events := make(map[string]*model.Event, len(req.Holds))
+	holdReqs := make([]model.CreateHoldRequest, len(req.Holds))
+	var currency string
+	for i, item := range req.Holds {
+		field := fmt.Sprintf("holds[%d]", i)
+		if events[item.EventID] != nil {
+			middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", "Each event may only be held once per request",
+				[]model.FieldError{{Field: field + ".event_id", Message: "duplicate event " + item.EventID}})
+			return
+		}
+		if !h.validateHoldSize(c, item.SeatNumbers) {
+			return
+		}
+
+		event, ok := h.loadEvent(c, item.EventID)
+		if !ok {
+			return
+		}
+		if currency == "" {
+			currency = event.Currency
+		} else if event.Currency != currency {
+			middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "currency_mismatch", "All events held together must share a currency",
+				[]model.FieldError{{Field: field + ".event_id", Message: fmt.Sprintf("priced in %s, not %s", event.Currency, currency)}})
+			return
+		}
+		events[item.EventID] = event
+
+		holdReqs[i] = item.ToCreateHoldRequest(userID, item.EventID, group.ExpiresAt)
+		holdReqs[i].ID = uuid.New().String()
+		holdReqs[i].UserEmail = c.GetString("user_email")
+		holdReqs[i].UserLocale = c.GetString(middleware.ContextUserLocale)
+	}
+
+	holds, err := h.repo.CreateHoldGroup(group, holdReqs)
+	if err != nil {
+		var groupErr *repository.HoldGroupError
+		if errors.As(err, &groupErr) {
+			for _, holdReq := range holdReqs {
+				if holdReq.EventID == groupErr.EventID {
+					h.respondHoldError(c, holdReq.EventID, holdReq.SeatNumbers, groupErr.Err, "Failed to hold seats")
+					return
+				}
+			}
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to hold seats")
+		return
+	}
+
+	response := model.MultiHoldResponse{
+		GroupHoldID: group.ID,
+		Holds:       make([]model.HoldResponse, len(holds)),
+		ExpiresAt:   group.ExpiresAt,
+	}
+	total := model.Money{Currency: currency}
+	for i, hold := range holds {
+		// Invalidate seat-related caches since seats were held
+		h.cache.InvalidateAvailableSeats(hold.EventID)
+		h.cache.InvalidateAvailableSeatCount(hold.EventID)
+		h.trackHoldExpiry(&hold)
+
+		price := events[hold.EventID].SeatPrice().Multiply(len(hold.SeatNumbers))
+		response.Holds[i] = *hold.ToHoldResponse(price)
+		total = total.Add(price)
+	}
+	response.TotalPrice = total.Decimal()
+	response.Currency = total.Currency
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// validateHoldSize rejects hold requests for more seats than one hold may
+// lock, writing the error response and returning false
+func (h *EventHandler) validateHoldSize(c *gin.Context, seatNumbers []string) bool {
+	if len(seatNumbers) <= h.cfg.MaxSeatsPerHold {
+		return true
+	}
+	message := fmt.Sprintf("at most %d seats can be held per request", h.cfg.MaxSeatsPerHold)
+	middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", message,
+		[]model.FieldError{{Field: "seat_numbers", Message: message}})
+	return false
+}
+
+// createHold places the hold and prices it, writing the error response and
+// returning false if the seats can't be held
+func (h *EventHandler) createHold(c *gin.Context, holdReq model.CreateHoldRequest) (*model.HoldResponse, bool) {
+	eventID := holdReq.EventID
+
+	// Turn requests for a sold out event away before locking any seats
+	soldOut, ok := h.soldOut(c, eventID)
+	if !ok {
+		return nil, false
+	}
+	if soldOut {
+		respondSoldOut(c)
+		return nil, false
+	}
+
+	// Create hold
+	holdReq.ID = uuid.New().String()
+	hold, err := h.repo.CreateHold(holdReq)
+	if err != nil {
+		h.respondHoldError(c, eventID, holdReq.SeatNumbers, err, "Failed to hold seats")
+		return nil, false
+	}
+
+	// Invalidate seat-related caches since seats were held
+	h.cache.InvalidateAvailableSeats(eventID)
+	h.cache.InvalidateAvailableSeatCount(eventID)
+	h.trackHoldExpiry(hold)
+
+	// Get event to calculate total price
+	event, err := h.repo.GetEventByID(eventID)
+	if err != nil {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve event details")
+		return nil, false
+	}
+
+	totalPrice := event.SeatPrice().Multiply(len(hold.SeatNumbers))
+	return hold.ToHoldResponse(totalPrice), true
+}
+
+// trackHoldExpiry has the hold released as soon as it expires when expiry
+// notifications are enabled. Holds that fail to be tracked are left to the
+// cleanup sweep.
+func (h *EventHandler) trackHoldExpiry(hold *model.Hold) {
+	if !h.cfg.HoldCleanup.ExpiryNotifications {
+		return
+	}
+	if err := h.cache.TrackHoldExpiry(hold.ID, hold.ExpiresAt); err != nil {
+		log.Printf("Failed to track expiry of hold %s: %v", hold.ID, err)
+	}
+}
+
+// respondHoldError maps a failure to place a hold on the event's seats to an
+// error response, using internalMessage for unexpected errors
+func (h *EventHandler) respondHoldError(c *gin.Context, eventID string, seatNumbers []string, err error, internalMessage string) {
+	switch {
+	case errors.Is(err, repository.ErrSeatsNotReady):
+		middleware.RespondError(c, http.StatusConflict, "seats_generating", "Seats for this event are still being generated, please try again shortly")
+	case errors.Is(err, repository.ErrEventNotFound):
+		middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
+	case errors.Is(err, repository.ErrSeatsUnavailable):
+		// The cache may have been stale, so read availability from the database
+		// to tell whether the event just sold out and what to offer instead
+		available, err := h.repo.GetAvailableSeats(eventID)
+		if err != nil {
+			middleware.RespondError(c, http.StatusConflict, "seats_unavailable", "Some requested seats are not available")
+			return
+		}
+		if len(available) == 0 {
+			respondSoldOut(c)
+			return
+		}
+		middleware.RespondErrorWithDetails(c, http.StatusConflict, "seats_unavailable", "Some requested seats are not available",
+			seatConflict(eventID, seatNumbers, available))
+	case errors.Is(err, repository.ErrSeatsNotFound):
+		details := model.InvalidSeatsDetails{EventID: eventID, NonexistentSeats: []string{}, UnavailableSeats: []string{}}
+		var checkErr *repository.SeatCheckError
+		if errors.As(err, &checkErr) {
+			details.NonexistentSeats = append(details.NonexistentSeats, checkErr.Missing...)
+			details.UnavailableSeats = append(details.UnavailableSeats, checkErr.Unavailable...)
+		}
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "invalid_seats", err.Error(), details)
+	case errors.Is(err, repository.ErrPresaleCodeRequired):
+		middleware.RespondError(c, http.StatusForbidden, "presale_code_required", "A presale code is required for these seats until general sale: "+err.Error())
+	case errors.Is(err, repository.ErrPresaleCodeInvalid):
+		middleware.RespondError(c, http.StatusForbidden, "presale_code_invalid", "The presale code is not valid for this event")
+	default:
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", internalMessage)
+	}
+}
+
+// seatConflict lists which requested seats are gone and suggests available
+// seats closest to the request to take instead
+func seatConflict(eventID string, requested, available []string) model.SeatsNotAvailableError {
+	availableSet := make(map[string]bool, len(available))
+	for _, seatNumber := range available {
+		availableSet[seatNumber] = true
+	}
+
+	conflict := model.SeatsNotAvailableError{EventID: eventID, UnavailableSeats: []string{}, AvailableAlternatives: []string{}}
+	for _, seatNumber := range requested {
+		if !availableSet[seatNumber] {
+			conflict.UnavailableSeats = append(conflict.UnavailableSeats, seatNumber)
+		}
+	}
+	if alternatives := model.SuggestAlternativeSeats(requested, available, maxSeatAlternatives); alternatives != nil {
+		conflict.AvailableAlternatives = alternatives
+	}
+	return conflict
+}
+
+// soldOut reports whether the cached seat count shows no seats left. A zero
+// count is only trusted once the event's seats are ready, since the count is
+// also zero while seats are still being generated. Returns false for ok after
+// writing the error response if the event can't be loaded.
+func (h *EventHandler) soldOut(c *gin.Context, eventID string) (soldOut bool, ok bool) {
+	available, err := h.cache.GetAvailableSeatCount(eventID)
+	if err != nil || available != 0 {
+		return false, true
+	}
+
+	event, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return false, false
+	}
+	return event.SeatStatus == model.SeatStatusReady, true
+}
+
+// respondSoldOut writes the conflict returned when an event has no seats left
+func respondSoldOut(c *gin.Context) {
+	middleware.RespondErrorWithDetails(c, http.StatusConflict, "event_sold_out", "This event is sold out",
+		model.SoldOutDetails{AvailableSeats: 0})
+}
+
+// ReserveSeats handles trusted, payment-free reservations such as comped
+// tickets, booking the seats directly instead of holding then confirming them.
+// The reservation is recorded as a confirmed hold owned by the caller.
+func (h *EventHandler) ReserveSeats(c *gin.Context) {
+	eventID := c.Param("id")
+
+	var req model.ReserveSeatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	hold, err := h.repo.ReserveSeats(model.CreateHoldRequest{
+		ID:          uuid.New().String(),
+		UserID:      c.GetString("user_id"),
+		UserEmail:   c.GetString("user_email"),
+		EventID:     eventID,
+		SeatNumbers: req.SeatNumbers,
+		ExpiresAt:   now,
+	})
+	if err != nil {
+		h.respondHoldError(c, eventID, req.SeatNumbers, err, "Failed to reserve seats")
+		return
+	}
+
+	log.Printf("User %s reserved %d seats for event %s (hold %s)", hold.UserID, len(hold.SeatNumbers), eventID, hold.ID)
+
+	// Invalidate seat-related caches since seats were booked
+	h.cache.InvalidateAvailableSeats(eventID)
+	h.cache.InvalidateAvailableSeatCount(eventID)
+
+	c.JSON(http.StatusCreated, model.ReservationResponse{
+		HoldID:      hold.ID,
+		EventID:     eventID,
+		BookedSeats: hold.SeatNumbers,
+		ReservedBy:  hold.UserID,
+		ReservedAt:  now,
+	})
+}
+
+// ReleaseHold handles releasing a seat hold, or all holds of a hold group
+func (h *EventHandler) ReleaseHold(c *gin.Context) {
+	holdID := c.Param("holdId")
+
+	// Get holds first to know which events to invalidate cache for
+	holds, ok := h.loadHolds(c, holdID)
+	if !ok {
+		return
+	}
+
+	err := h.repo.ReleaseHold(holdID)
+	if err != nil {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to release hold")
+		return
+	}
+
+	// Invalidate seat-related caches since seats were released
+	for _, hold := range holds {
+		h.cache.InvalidateAvailableSeats(hold.EventID)
+		h.cache.InvalidateAvailableSeatCount(hold.EventID)
+	}
+
+	if isAdmin(c) {
+		h.audit.RecordRequest(c, audit.ActionHoldReleased, audit.Target("hold", holdID),
+			map[string]string{"holder_id": holds[0].UserID})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Hold released successfully"})
+}
+
+// ReleaseMyHolds handles releasing all of the caller's active holds for an
+// event at once, so seats from an abandoned checkout return to sale quickly
+func (h *EventHandler) ReleaseMyHolds(c *gin.Context) {
+	eventID := c.Param("id")
+
+	if _, ok := h.loadEvent(c, eventID); !ok {
+		return
+	}
+
+	released, err := h.repo.ReleaseUserHolds(eventID, c.GetString("user_id"))
+	if err != nil {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to release holds")
+		return
+	}
+
+	// Invalidate seat-related caches since seats were released
+	if released > 0 {
+		h.cache.InvalidateAvailableSeats(eventID)
+		h.cache.InvalidateAvailableSeatCount(eventID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Holds released successfully", "released": released})
+}
+
+// GetHoldDetails handles retrieving hold details by ID. A hold group's
+// details sum its holds so the group can be booked like a single hold.
+func (h *EventHandler) GetHoldDetails(c *gin.Context) {
+	holdID := c.Param("holdId")
+
+	// Get hold details
+	holds, ok := h.loadHolds(c, holdID)
+	if !ok {
+		return
+	}
+
+	details := make([]model.HoldDetailsResponse, len(holds))
+	for i, hold := range holds {
+		// Get event details for additional information
+		event, err := h.repo.GetEventByID(hold.EventID)
+		if err != nil {
+			middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to get event details")
+			return
+		}
+		details[i] = holdDetails(&hold, event)
+	}
+
+	response := details[0]
+	if holds[0].ID != holdID {
+		response = groupHoldDetails(holdID, details)
+	}
+
+	// Guests have no account to look up. The name only personalizes emails, so
+	// the details are returned without it if the user service can't be reached.
+	if !holds[0].Guest {
+		response.UserName = h.userNames.Name(c.Request.Context(), holds[0].UserID)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetMyHold handles a user re-fetching their own hold on an event, e.g. to
+// resume checkout. Unlike GetHoldDetails it is only open to the holder and
+// admins, and turns expired holds away with 410.
+func (h *EventHandler) GetMyHold(c *gin.Context) {
+	eventID := c.Param("id")
+
+	hold, err := h.repo.GetHoldByID(c.Param("holdId"))
+	if err != nil {
+		if errors.Is(err, repository.ErrHoldNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Hold not found")
+			return
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to get hold details")
+		return
+	}
+	if hold.EventID != eventID {
+		middleware.RespondError(c, http.StatusNotFound, "not_found", "Hold not found")
+		return
+	}
+	if hold.UserID != c.GetString("user_id") && !isAdmin(c) {
+		middleware.RespondError(c, http.StatusForbidden, "forbidden", "This hold belongs to another user")
+		return
+	}
+
+	// Active holds past expiry are only waiting for the cleanup sweep
+	if hold.Status == "expired" || (hold.Status == "active" && time.Now().After(hold.ExpiresAt)) {
+		middleware.RespondError(c, http.StatusGone, "hold_expired", "Hold has expired or was released")
+		return
+	}
+
+	event, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	details := holdDetails(hold, event)
+	c.JSON(http.StatusOK, model.CheckoutHoldResponse{
+		HoldID:          details.HoldID,
+		EventID:         details.EventID,
+		EventName:       details.EventName,
+		Venue:           details.Venue,
+		EventDate:       details.EventDate,
+		Seats:           details.Seats,
+		TotalPrice:      details.TotalPrice,
+		TotalPriceCents: details.TotalPriceCents,
+		Currency:        details.Currency,
+		Status:          hold.Status,
+		ExpiresAt:       details.ExpiresAt,
+	})
+}
+
+// holdDetails describes a hold on the event. Times are sent in UTC so they
+// always serialize as RFC3339 with a "Z" offset, which the booking service
+// parses.
+func holdDetails(hold *model.Hold, event *model.Event) model.HoldDetailsResponse {
+	totalPrice := event.SeatPrice().Multiply(len(hold.SeatNumbers))
+	return model.HoldDetailsResponse{
+		HoldID:          hold.ID,
+		UserID:          hold.UserID,
+		EventID:         hold.EventID,
+		EventName:       event.Name,
+		Venue:           event.Venue,
+		EventDate:       event.EventDate.UTC(),
+		Seats:           hold.SeatNumbers,
+		TotalPrice:      totalPrice.Decimal(),
+		TotalPriceCents: totalPrice.Amount,
+		Currency:        totalPrice.Currency,
+		ExpiresAt:       hold.ExpiresAt.UTC(),
+	}
+}
+
+// groupHoldDetails sums the details of a hold group's holds, which all share
+// a currency
+func groupHoldDetails(groupID string, holds []model.HoldDetailsResponse) model.HoldDetailsResponse {
+	group := model.HoldDetailsResponse{
+		HoldID:    groupID,
+		UserID:    holds[0].UserID,
+		EventID:   holds[0].EventID,
+		EventDate: holds[0].EventDate,
+		Seats:     []string{},
+		Currency:  holds[0].Currency,
+		ExpiresAt: holds[0].ExpiresAt,
+		Holds:     holds,
+	}
+
+	var names, venues []string
+	for _, hold := range holds {
+		names = append(names, hold.EventName)
+		if !slices.Contains(venues, hold.Venue) {
+			venues = append(venues, hold.Venue)
+		}
+		group.Seats = append(group.Seats, hold.Seats...)
+		group.TotalPriceCents += hold.TotalPriceCents
+		if hold.EventDate.Before(group.EventDate) {
+			group.EventDate = hold.EventDate
+		}
+		if hold.ExpiresAt.Before(group.ExpiresAt) {
+			group.ExpiresAt = hold.ExpiresAt
+		}
+	}
+	group.EventName = strings.Join(names, " + ")
+	group.Venue = strings.Join(venues, ", ")
+	group.TotalPrice = model.FromMinorUnits(group.TotalPriceCents)
+	return group
+}
+
+// ConfirmHold handles confirming a seat hold (booking), or all holds of a
+// hold group
+func (h *EventHandler) ConfirmHold(c *gin.Context) {
+	holdID := c.Param("holdId")
+
+	// Get holds first to know which events to invalidate cache for
+	holds, ok := h.loadHolds(c, holdID)
+	if !ok {
+		return
+	}
+
+	err := h.repo.ConfirmHold(holdID, h.cfg.HoldConfirmGrace)
+	if err != nil {
+		if errors.Is(err, repository.ErrHoldExpired) {
+			middleware.RespondError(c, http.StatusConflict, "hold_expired", "Hold has expired or was released")
+			return
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to confirm hold")
+		return
+	}
+
+	// Invalidate seat-related caches since seats were booked
+	for _, hold := range holds {
+		h.cache.InvalidateAvailableSeats(hold.EventID)
+		h.cache.InvalidateAvailableSeatCount(hold.EventID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Booking confirmed successfully"})
+}
+
+// loadHolds fetches the hold with the given ID, or every hold of the hold
+// group with that ID, writing the error response and returning false if
+// there is none
+func (h *EventHandler) loadHolds(c *gin.Context, holdID string) ([]model.Hold, bool) {
+	hold, err := h.repo.GetHoldByID(holdID)
+	if err == nil {
+		return []model.Hold{*hold}, true
+	}
+
+	var holds []model.Hold
+	if errors.Is(err, repository.ErrHoldNotFound) {
+		holds, err = h.repo.GetGroupHolds(holdID)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrHoldNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Hold not found")
+			return nil, false
+		}
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to get hold details")
+		return nil, false
+	}
+	return holds, true
+}
+
+// validateEventDate rejects event dates earlier than now, allowing a small grace
+// window. It is time-relative so it cannot be expressed as a binding tag.
+func validateEventDate(eventDate, now time.Time) *model.FieldError {
+	if eventDate.Before(now.Add(-eventDateGracePeriod)) {
+		return &model.FieldError{
+			Field:   "event_date",
+			Message: "event_date must not be in the past",
+		}
+	}
+	return nil
+}
+
+// parsePriceBound parses an optional per-seat price filter in major units and
+// returns it in minor units
+func parsePriceBound(raw, field string) (*int64, *model.FieldError) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	amount, err := strconv.ParseFloat(raw, 64)
+	if err != nil || amount < 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return nil, &model.FieldError{
+			Field:   field,
+			Message: field + " must be a non-negative number",
+		}
+	}
+
+	cents := model.ToMinorUnits(amount)
+	return &cents, nil
+}
+
+// validateCurrency checks an optional currency code against the supported allowlist
+func validateCurrency(currency string) *model.FieldError {
+	if !model.IsSupportedCurrency(model.NormalizeCurrency(currency)) {
+		return &model.FieldError{
+			Field:   "currency",
+			Message: fmt.Sprintf("currency must be one of %s", strings.Join(model.SupportedCurrencies, ", ")),
+		}
+	}
+	return nil
+}
+
+// validateSeatLabels checks that custom seat labels, when supplied, are non-empty,
+// unique and match the event's total seat count
+func validateSeatLabels(labels []string, totalSeats int) *model.FieldError {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	if len(labels) != totalSeats {
+		return &model.FieldError{
+			Field:   "seat_labels",
+			Message: fmt.Sprintf("seat_labels must contain exactly %d labels (got %d)", totalSeats, len(labels)),
+		}
+	}
+
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if strings.TrimSpace(label) == "" {
+			return &model.FieldError{
+				Field:   "seat_labels",
+				Message: "seat_labels must not contain empty labels",
+			}
+		}
+		if seen[label] {
+			return &model.FieldError{
+				Field:   "seat_labels",
+				Message: fmt.Sprintf("seat_labels contains duplicate label %q", label),
+			}
+		}
+		seen[label] = true
+	}
+
+	return nil
 }
 
 // HealthCheck handles health check endpoint
@@ -442,25 +1499,19 @@ func (h *EventHandler) HealthCheck(c *gin.Context) {
 	// Check database connection
 	sqlDB, err := h.repo.GetDB().DB()
 	if err != nil {
-		c.JSON(http.StatusServiceUnavailable, model.ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Database connection failed",
-		})
+		middleware.RespondError(c, http.StatusServiceUnavailable, "service_unavailable", "Database connection failed")
 		return
 	}
 
 	if err := sqlDB.Ping(); err != nil {
-		c.JSON(http.StatusServiceUnavailable, model.ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Database ping failed",
-		})
+		middleware.RespondError(c, http.StatusServiceUnavailable, "service_unavailable", "Database ping failed")
 		return
 	}
 
 	response := model.HealthResponse{
 		Status:    "healthy",
 		Service:   "event-service",
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 	}
 
 	c.JSON(http.StatusOK, response)
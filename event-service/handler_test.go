@@ -0,0 +1,305 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/cache/redis"
+	"github.com/arunvm123/eventbooking/event-service/config"
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/gin-gonic/gin"
+)
+
+func TestValidateEventDate(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		eventDate time.Time
+		wantErr   bool
+	}{
+		{"now", now, false},
+		{"future", now.Add(time.Hour), false},
+		{"within grace", now.Add(-eventDateGracePeriod + time.Second), false},
+		{"at grace boundary", now.Add(-eventDateGracePeriod), false},
+		{"just past grace", now.Add(-eventDateGracePeriod - time.Second), true},
+		{"yesterday", now.AddDate(0, 0, -1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := validateEventDate(tt.eventDate, now)
+			if (fieldErr != nil) != tt.wantErr {
+				t.Fatalf("validateEventDate(%s) = %v, want error %v", tt.eventDate, fieldErr, tt.wantErr)
+			}
+			if fieldErr != nil && fieldErr.Field != "event_date" {
+				t.Errorf("field = %q, want event_date", fieldErr.Field)
+			}
+		})
+	}
+}
+
+func TestValidateSeatLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		labels     []string
+		totalSeats int
+		wantErr    bool
+	}{
+		{"no labels generates seats", nil, 100, false},
+		{"matching count", []string{"VIP-1", "VIP-2", "B7"}, 3, false},
+		{"too few", []string{"A1", "A2"}, 3, true},
+		{"too many", []string{"A1", "A2", "A3", "A4"}, 3, true},
+		{"blank label", []string{"A1", " ", "A3"}, 3, true},
+		{"duplicate label", []string{"A1", "A2", "A1"}, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErr := validateSeatLabels(tt.labels, tt.totalSeats)
+			if (fieldErr != nil) != tt.wantErr {
+				t.Fatalf("validateSeatLabels(%v, %d) = %v, want error %v", tt.labels, tt.totalSeats, fieldErr, tt.wantErr)
+			}
+			if fieldErr != nil && fieldErr.Field != "seat_labels" {
+				t.Errorf("field = %q, want seat_labels", fieldErr.Field)
+			}
+		})
+	}
+}
+
+func TestUpdateEventInvalidatesCachedLists(t *testing.T) {
+	event := &model.Event{
+		ID:         "event-1",
+		Name:       "Jazz Night",
+		Venue:      "Blue Note",
+		City:       "Boston",
+		Category:   "Concert",
+		EventDate:  time.Now().Add(24 * time.Hour),
+		TotalSeats: 100,
+		CreatedBy:  "organizer-1",
+	}
+	repo := newFakeEventRepository(event)
+	eventCache := newFakeCache()
+
+	bostonKey := redis.GenerateFilterKey(model.EventFilter{City: "Boston"})
+	eventCache.SetEventList(bostonKey, &model.EventListResponse{
+		Events: []model.EventResponse{*event.ToEventResponse(100)},
+	}, time.Minute)
+	eventCache.SetEvent(event.ID, event, time.Minute)
+
+	handler := NewEventHandler(&config.Config{}, repo, eventCache, nil, nil, nil)
+	w := serve("/events/:id", http.MethodPut, "/events/event-1", "organizer-1", `{"city":"Denver"}`, handler.UpdateEvent)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+
+	if list, _ := eventCache.GetEventList(bostonKey); list != nil {
+		t.Errorf("cached Boston list still lists the event after it moved to Denver")
+	}
+	if cached, _ := eventCache.GetEvent(event.ID); cached != nil {
+		t.Errorf("cached event still has city %q", cached.City)
+	}
+	if repo.events[event.ID].City != "Denver" {
+		t.Errorf("stored city = %q, want Denver", repo.events[event.ID].City)
+	}
+}
+
+func TestHoldDetailsSendsUTCTimes(t *testing.T) {
+	newYork := time.FixedZone("EDT", -4*60*60)
+	event := &model.Event{
+		ID:        "event-1",
+		EventDate: time.Date(2026, 7, 1, 15, 0, 0, 0, newYork),
+		Currency:  "USD",
+	}
+	hold := &model.Hold{
+		ID:          "hold-1",
+		EventID:     event.ID,
+		SeatNumbers: []string{"A1"},
+		ExpiresAt:   time.Date(2026, 6, 1, 8, 15, 0, 0, newYork),
+	}
+
+	wire, err := json.Marshal(holdDetails(hold, event))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent struct {
+		EventDate string `json:"event_date"`
+		ExpiresAt string `json:"expires_at"`
+	}
+	if err := json.Unmarshal(wire, &sent); err != nil {
+		t.Fatal(err)
+	}
+
+	if sent.EventDate != "2026-07-01T19:00:00Z" {
+		t.Errorf("event_date = %q, want 2026-07-01T19:00:00Z", sent.EventDate)
+	}
+	if sent.ExpiresAt != "2026-06-01T12:15:00Z" {
+		t.Errorf("expires_at = %q, want 2026-06-01T12:15:00Z", sent.ExpiresAt)
+	}
+}
+
+func TestValidateHoldSize(t *testing.T) {
+	handler := NewEventHandler(&config.Config{MaxSeatsPerHold: 3}, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name  string
+		seats []string
+		want  bool
+	}{
+		{"one seat", []string{"A1"}, true},
+		{"at the cap", []string{"A1", "A2", "A3"}, true},
+		{"one over the cap", []string{"A1", "A2", "A3", "A4"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/events/event-1/hold", nil)
+
+			if got := handler.validateHoldSize(c, tt.seats); got != tt.want {
+				t.Fatalf("validateHoldSize(%d seats) = %v, want %v", len(tt.seats), got, tt.want)
+			}
+			if !tt.want && w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", w.Code)
+			}
+		})
+	}
+}
+
+func TestUpdateEventRejectsUnknownCategory(t *testing.T) {
+	event := &model.Event{ID: "event-1", Category: "Concert", EventDate: time.Now().Add(24 * time.Hour), CreatedBy: "organizer-1"}
+	cfg := &config.Config{EventCategories: []string{"Concert", "Sports"}}
+	handler := NewEventHandler(cfg, newFakeEventRepository(event), newFakeCache(), nil, nil, nil)
+
+	w := serve("/events/:id", http.MethodPut, "/events/event-1", "organizer-1", `{"category":"concret"}`, handler.UpdateEvent)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), "category must be one of Concert, Sports") {
+		t.Errorf("response %s doesn't list the valid categories", w.Body)
+	}
+
+	w = serve("/events/:id", http.MethodPut, "/events/event-1", "organizer-1", `{"category":"sports"}`, handler.UpdateEvent)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), `"category":"Sports"`) {
+		t.Errorf("response %s doesn't store the configured spelling", w.Body)
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		want       string
+	}{
+		{"restricted", []string{"Concert", "Sports"}, `{"categories":["Concert","Sports"],"restricted":true}`},
+		{"free-form", nil, `{"categories":[],"restricted":false}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewEventHandler(&config.Config{EventCategories: tt.categories}, nil, nil, nil, nil, nil)
+			w := serve("/events/categories", http.MethodGet, "/events/categories", "", "", handler.ListCategories)
+			if w.Body.String() != tt.want {
+				t.Errorf("body = %s, want %s", w.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoldConflictSuggestsAvailableSeats(t *testing.T) {
+	repo := newFakeEventRepository()
+	repo.availableSeats = map[string][]string{"event-1": {"A3", "A4", "C1"}}
+	handler := NewEventHandler(&config.Config{}, repo, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/events/event-1/hold", nil)
+	handler.respondHoldError(c, "event-1", []string{"A1", "A2"}, repository.ErrSeatsUnavailable, "Failed to hold seats")
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", w.Code)
+	}
+	var resp struct {
+		Error   string                       `json:"error"`
+		Details model.SeatsNotAvailableError `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding %s: %v", w.Body, err)
+	}
+	if resp.Error != "seats_unavailable" {
+		t.Errorf("error = %q, want seats_unavailable", resp.Error)
+	}
+	if strings.Join(resp.Details.UnavailableSeats, ",") != "A1,A2" {
+		t.Errorf("unavailable_seats = %v, want A1 and A2", resp.Details.UnavailableSeats)
+	}
+	if len(resp.Details.AvailableAlternatives) != 2 {
+		t.Fatalf("available_alternatives = %v, want 2 seats", resp.Details.AvailableAlternatives)
+	}
+	for _, seatNumber := range resp.Details.AvailableAlternatives {
+		if !slices.Contains(repo.availableSeats["event-1"], seatNumber) {
+			t.Errorf("suggested seat %s isn't available", seatNumber)
+		}
+	}
+}
+
+func TestCreateEventSeatFailureIsRetryable(t *testing.T) {
+	repo := newFakeEventRepository()
+	repo.createErr = fmt.Errorf("%w: gave up after 3 attempts", repository.ErrSeatCreationFailed)
+	handler := NewEventHandler(&config.Config{AsyncSeatThreshold: 1000}, repo, newFakeCache(), nil, nil, nil)
+
+	body := fmt.Sprintf(`{"name":"Jazz Night","venue":"Blue Note","city":"New York","category":"Concert","event_date":%q,"total_seats":10,"price_per_seat":25}`,
+		time.Now().Add(24*time.Hour).UTC().Format(time.RFC3339))
+	w := serve("/events", http.MethodPost, "/events", "organizer-1", body, handler.CreateEvent)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503: %s", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), `"error":"seat_creation_failed"`) {
+		t.Errorf("response %s lacks seat_creation_failed", w.Body)
+	}
+}
+
+func TestHoldOnNonexistentSeatsListsThem(t *testing.T) {
+	handler := NewEventHandler(&config.Config{}, newFakeEventRepository(), nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/events/event-1/hold", nil)
+	err := &repository.SeatCheckError{Missing: []string{"Z9"}, Unavailable: []string{"A2"}}
+	handler.respondHoldError(c, "event-1", []string{"A1", "A2", "Z9"}, err, "Failed to hold seats")
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	var resp struct {
+		Error   string                    `json:"error"`
+		Details model.InvalidSeatsDetails `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding %s: %v", w.Body, err)
+	}
+	if resp.Error != "invalid_seats" {
+		t.Errorf("error = %q, want invalid_seats", resp.Error)
+	}
+	if !slices.Equal(resp.Details.NonexistentSeats, []string{"Z9"}) || !slices.Equal(resp.Details.UnavailableSeats, []string{"A2"}) {
+		t.Errorf("details = %+v, want Z9 nonexistent and A2 unavailable", resp.Details)
+	}
+}
+
+func TestListEventsRejectsUnknownSort(t *testing.T) {
+	handler := NewEventHandler(&config.Config{DefaultEventSort: model.EventSortDate}, nil, nil, nil, nil, nil)
+
+	w := serve("/events", http.MethodGet, "/events?sort=price", "", "", handler.ListEvents)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), "sort must be one of date, name, created_at") {
+		t.Errorf("response %s doesn't list the valid sorts", w.Body)
+	}
+}
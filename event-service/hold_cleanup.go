@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/cache"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+)
+
+// HoldCleanupJob expires stale holds and returns their seats to the pool. A
+// backlog is worked through in small batches so no single transaction locks
+// enough rows to stall live hold and booking traffic.
+type HoldCleanupJob struct {
+	repo      repository.EventRepository
+	cache     cache.CacheRepository
+	interval  time.Duration
+	batchSize int
+}
+
+func NewHoldCleanupJob(repo repository.EventRepository, cache cache.CacheRepository, interval time.Duration, batchSize int) *HoldCleanupJob {
+	return &HoldCleanupJob{
+		repo:      repo,
+		cache:     cache,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run sweeps expired holds on every interval until the context is cancelled
+func (j *HoldCleanupJob) Run(ctx context.Context) {
+	log.Printf("Starting hold cleanup (interval %s, batch size %d)", j.interval, j.batchSize)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.sweep(ctx)
+		}
+	}
+}
+
+// sweep processes batches until no expired holds remain or the context is cancelled
+func (j *HoldCleanupJob) sweep(ctx context.Context) {
+	total := 0
+	for batch := 1; ctx.Err() == nil; batch++ {
+		holds, err := j.repo.CleanupExpiredHolds(j.batchSize)
+		if err != nil {
+			log.Printf("Hold cleanup batch %d failed: %v", batch, err)
+			return
+		}
+		if len(holds) == 0 {
+			break
+		}
+
+		// Released seats change availability for each affected event
+		events := make(map[string]struct{})
+		for _, hold := range holds {
+			events[hold.EventID] = struct{}{}
+		}
+		for eventID := range events {
+			j.cache.InvalidateAvailableSeats(eventID)
+			j.cache.InvalidateAvailableSeatCount(eventID)
+		}
+
+		total += len(holds)
+		log.Printf("Hold cleanup batch %d expired %d holds across %d events", batch, len(holds), len(events))
+
+		if len(holds) < j.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Hold cleanup expired %d holds in total", total)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/cache"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+)
+
+// holdExpiryResubscribeDelay is the wait before subscribing again after the
+// subscription to expiry notifications failed or ended
+const holdExpiryResubscribeDelay = 5 * time.Second
+
+// HoldExpiryListener releases holds' seats as soon as Redis expires the keys
+// tracking them, instead of waiting for the next cleanup sweep. Notifications
+// are delivered at most once, so the sweep still expires any hold missed here.
+// Every instance receives every notification; only one of them expires the
+// hold and the rest find it already settled.
+type HoldExpiryListener struct {
+	repo  repository.EventRepository
+	cache cache.CacheRepository
+}
+
+func NewHoldExpiryListener(repo repository.EventRepository, cache cache.CacheRepository) *HoldExpiryListener {
+	return &HoldExpiryListener{repo: repo, cache: cache}
+}
+
+// Run expires holds as notifications arrive until the context is cancelled,
+// subscribing again whenever the subscription is lost
+func (l *HoldExpiryListener) Run(ctx context.Context) {
+	log.Println("Starting hold expiry listener")
+
+	for ctx.Err() == nil {
+		holdIDs, err := l.cache.ExpiredHolds(ctx)
+		if err != nil {
+			log.Printf("Failed to subscribe to hold expiries, retrying in %s: %v", holdExpiryResubscribeDelay, err)
+		} else {
+			for holdID := range holdIDs {
+				l.expire(holdID)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(holdExpiryResubscribeDelay):
+		}
+	}
+}
+
+// expire releases the hold's seats if it is still active
+func (l *HoldExpiryListener) expire(holdID string) {
+	hold, err := l.repo.ExpireHold(holdID)
+	if err != nil {
+		log.Printf("Failed to expire hold %s, leaving it to the cleanup sweep: %v", holdID, err)
+		return
+	}
+	if hold == nil {
+		return
+	}
+
+	l.cache.InvalidateAvailableSeats(hold.EventID)
+	l.cache.InvalidateAvailableSeatCount(hold.EventID)
+	log.Printf("Expired hold %s on event %s", hold.ID, hold.EventID)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/segmentio/kafka-go"
+)
+
+// holdWarningBatchSize caps how many holds are claimed per check
+const holdWarningBatchSize = 100
+
+// HoldWarningJob emails holders whose seats are about to be released. Holds are
+// marked warned before the notification is published, so a publish failure
+// drops that hold's warning rather than risking repeat emails.
+type HoldWarningJob struct {
+	repo          repository.EventRepository
+	mq            queue.MessageQueue
+	topic         string
+	leadTime      time.Duration
+	checkInterval time.Duration
+	checkoutURL   string
+}
+
+func NewHoldWarningJob(repo repository.EventRepository, mq queue.MessageQueue, topic string, leadTime, checkInterval time.Duration, checkoutURL string) *HoldWarningJob {
+	return &HoldWarningJob{
+		repo:          repo,
+		mq:            mq,
+		topic:         topic,
+		leadTime:      leadTime,
+		checkInterval: checkInterval,
+		checkoutURL:   checkoutURL,
+	}
+}
+
+// Run checks for expiring holds until the context is cancelled
+func (j *HoldWarningJob) Run(ctx context.Context) {
+	log.Printf("Starting hold expiry warnings (lead time %s, check interval %s)", j.leadTime, j.checkInterval)
+
+	ticker := time.NewTicker(j.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.sendWarnings(ctx)
+		}
+	}
+}
+
+// sendWarnings claims batches of expiring holds and publishes a warning for each
+func (j *HoldWarningJob) sendWarnings(ctx context.Context) {
+	for ctx.Err() == nil {
+		holds, err := j.repo.ClaimExpiringHolds(j.leadTime, holdWarningBatchSize)
+		if err != nil {
+			log.Printf("Failed to claim expiring holds: %v", err)
+			return
+		}
+		if len(holds) == 0 {
+			return
+		}
+
+		messages := make([]kafka.Message, 0, len(holds))
+		for _, hold := range holds {
+			// Holds placed together are checked out together
+			checkoutID := hold.ID
+			if hold.GroupID != nil {
+				checkoutID = *hold.GroupID
+			}
+			checkoutURL := strings.ReplaceAll(j.checkoutURL, "{hold_id}", checkoutID)
+			msgBytes, err := json.Marshal(hold.ToHoldExpiringNotification(checkoutURL))
+			if err != nil {
+				log.Printf("Failed to marshal expiry warning for hold %s: %v", hold.ID, err)
+				continue
+			}
+			messages = append(messages, kafka.Message{
+				Topic: j.topic,
+				Key:   []byte(hold.UserEmail),
+				Value: msgBytes,
+			})
+		}
+
+		// The holds are already claimed, so publish their warnings even if
+		// shutdown starts meanwhile
+		writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err = j.mq.Produce(writeCtx, messages...)
+		cancel()
+		if err != nil {
+			log.Printf("Failed to publish %d hold expiry warnings: %v", len(messages), err)
+			return
+		}
+		log.Printf("Sent %d hold expiry warnings", len(messages))
+
+		if len(holds) < holdWarningBatchSize {
+			return
+		}
+	}
+}
@@ -1,12 +1,25 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/arunvm123/eventbooking/event-service/config"
 )
 
+// shutdownTimeout bounds how long in-flight requests may finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
 func main() {
+	// Times scanned from the database use the local zone; run in UTC so API
+	// responses never carry the host's offset
+	time.Local = time.UTC
+
 	// Initialize configuration
 	// Try to load from config.yaml first, fallback to environment variables
 	cfg, err := config.Initialise("config.yaml", false)
@@ -19,7 +32,27 @@ func main() {
 		}
 	}
 
-	r := SetupRouter(cfg)
-	log.Printf("Event Service running on port %s", cfg.Port)
-	r.Run(":" + cfg.Port)
+	// Stopping the server also stops the background jobs
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: SetupRouter(ctx, cfg),
+	}
+	go func() {
+		log.Printf("Event Service running on port %s", cfg.Port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("Server failed:", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down event service...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Event service shutdown error: %v", err)
+	}
 }
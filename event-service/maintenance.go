@@ -0,0 +1,52 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// GetMaintenance handles reporting whether maintenance mode is on
+func (h *EventHandler) GetMaintenance(c *gin.Context) {
+	runtime, err := h.cache.MaintenanceMode()
+	if err != nil {
+		log.Printf("Failed to read maintenance mode: %v", err)
+		middleware.RespondError(c, http.StatusServiceUnavailable, "service_unavailable", "Failed to read maintenance mode")
+		return
+	}
+	c.JSON(http.StatusOK, h.maintenanceResponse(runtime))
+}
+
+// SetMaintenance handles switching maintenance mode on or off at runtime for
+// every instance sharing the Redis cache. Admin endpoints stay writable during
+// maintenance so it can be switched off again.
+func (h *EventHandler) SetMaintenance(c *gin.Context) {
+	var req model.SetMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	if err := h.cache.SetMaintenanceMode(*req.Enabled); err != nil {
+		log.Printf("Failed to set maintenance mode: %v", err)
+		middleware.RespondError(c, http.StatusServiceUnavailable, "service_unavailable", "Failed to set maintenance mode")
+		return
+	}
+	h.audit.RecordRequest(c, audit.ActionMaintenanceChanged, audit.Target("service", "event-service"),
+		map[string]string{"enabled": strconv.FormatBool(*req.Enabled)})
+
+	c.JSON(http.StatusOK, h.maintenanceResponse(*req.Enabled))
+}
+
+func (h *EventHandler) maintenanceResponse(runtime bool) model.MaintenanceResponse {
+	return model.MaintenanceResponse{
+		Enabled:    h.cfg.MaintenanceMode || runtime,
+		Configured: h.cfg.MaintenanceMode,
+		Runtime:    runtime,
+	}
+}
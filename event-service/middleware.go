@@ -2,136 +2,58 @@ package main
 
 import (
 	"net/http"
-	"strings"
-	"time"
 
-	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-// JWTClaims represents the JWT claims structure
-type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	jwt.RegisteredClaims
+// AuthMiddleware authenticates requests with the shared JWT middleware,
+// reporting failures in this service's error format
+func AuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
+	return middleware.Auth(jwtService, middleware.RespondError, middleware.DefaultAuthErrorCodes)
 }
 
-// JWTService handles JWT operations
-type JWTService struct {
-	secretKey string
-}
-
-func NewJWTService(secretKey string) *JWTService {
-	return &JWTService{secretKey: secretKey}
-}
-
-// ValidateToken validates a JWT token and returns the claims
-func (j *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.secretKey), nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
-	}
+// roleAdmin is the JWT role allowed to use the admin endpoints
+const roleAdmin = "admin"
 
-	return nil, jwt.ErrInvalidKey
+// isAdmin reports whether the authenticated caller has the admin role
+func isAdmin(c *gin.Context) bool {
+	return c.GetString(middleware.ContextUserRole) == roleAdmin
 }
 
-// AuthMiddleware is a Gin middleware for JWT authentication
-func AuthMiddleware(jwtService *JWTService) gin.HandlerFunc {
+// RequireAdmin rejects authenticated callers without the admin role
+func RequireAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "Authorization header is required",
-			})
-			c.Abort()
-			return
-		}
-
-		// Check if the header starts with "Bearer "
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "Invalid authorization header format",
-			})
-			c.Abort()
-			return
-		}
-
-		tokenString := tokenParts[1]
-		claims, err := jwtService.ValidateToken(tokenString)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "Invalid or expired token",
-			})
+		if !isAdmin(c) {
+			middleware.RespondError(c, http.StatusForbidden, "forbidden", "Admin role required")
 			c.Abort()
 			return
 		}
-
-		// Store user information in context for use in handlers
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-
-		// Add X-User-ID header for downstream services
-		c.Header("X-User-ID", claims.UserID)
-
 		c.Next()
 	}
 }
 
-// CORSMiddleware handles CORS
-func CORSMiddleware() gin.HandlerFunc {
+// RequireService rejects callers that aren't another service
+func RequireService() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		if c.GetString(middleware.ContextUserRole) != auth.RoleService {
+			middleware.RespondError(c, http.StatusForbidden, "forbidden", "Service role required")
+			c.Abort()
 			return
 		}
-
 		c.Next()
 	}
 }
 
-// LoggingMiddleware logs HTTP requests
-func LoggingMiddleware() gin.HandlerFunc {
+// RequireTrusted rejects callers that are neither admins nor internal services
+func RequireTrusted() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Start timer
-		start := time.Now()
-		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
-
-		// Process request
-		c.Next()
-
-		// Log format
-		end := time.Now()
-		latency := end.Sub(start)
-
-		clientIP := c.ClientIP()
-		method := c.Request.Method
-		statusCode := c.Writer.Status()
-
-		if raw != "" {
-			path = path + "?" + raw
+		if !isAdmin(c) && c.GetString(middleware.ContextUserRole) != auth.RoleService {
+			middleware.RespondError(c, http.StatusForbidden, "forbidden", "Admin or service role required")
+			c.Abort()
+			return
 		}
-
-		// Simple log format - in production, use structured logging
-		gin.DefaultWriter.Write([]byte("[GIN] " + end.Format("2006/01/02 - 15:04:05") + " | " +
-			clientIP + " | " + method + " " + path + " | " +
-			string(rune(statusCode)) + " | " + latency.String() + "\n"))
+		c.Next()
 	}
 }
@@ -1,8 +1,10 @@
 package model
 
 import (
+	"slices"
 	"time"
 
+	"github.com/arunvm123/eventbooking/shared/pagination"
 	"github.com/lib/pq"
 )
 
@@ -12,27 +14,44 @@ import (
 
 // Event represents the event entity in the database
 type Event struct {
-	ID           string `gorm:"type:text;primary_key"`
-	Name         string `gorm:"not null"`
-	Description  string
-	Venue        string    `gorm:"not null"`
-	City         string    `gorm:"not null"`
-	Category     string    `gorm:"not null"`
-	EventDate    time.Time `gorm:"not null"`
-	TotalSeats   int       `gorm:"not null"`
-	PricePerSeat float64   `gorm:"not null"`
-	CreatedBy    string    `gorm:"type:text;not null"` // User ID from User Service
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID                string `gorm:"type:text;primary_key"`
+	Name              string `gorm:"not null"`
+	Description       string
+	Venue             string    `gorm:"not null"`
+	City              string    `gorm:"not null"`
+	Category          string    `gorm:"not null"`
+	EventDate         time.Time `gorm:"not null"`
+	TotalSeats        int       `gorm:"not null"`
+	PricePerSeatCents int64     `gorm:"not null;default:0"` // Minor units of Currency
+	Currency          string    `gorm:"type:varchar(3);not null;default:'USD'"`
+	ImageURL          string    `gorm:"type:text"`
+	BannerURL         string    `gorm:"type:text"`
+	SeatStatus        string    `gorm:"type:varchar(20);not null;default:'ready'"` // ready, generating, failed
+	CreatedBy         string    `gorm:"type:text;not null"`                        // User ID from User Service
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	// PresaleEndsAt is when general sale starts. Until then seats flagged
+	// presale can only be held with one of the event's presale codes.
+	PresaleEndsAt *time.Time
 }
 
+// Seat generation states for Event.SeatStatus. Large events get their seats
+// generated in the background and can't be held until they are ready.
+const (
+	SeatStatusReady      = "ready"
+	SeatStatusGenerating = "generating"
+	SeatStatusFailed     = "failed"
+)
+
 // Seat represents the seat entity in the database
 type Seat struct {
 	ID         string  `gorm:"type:text;primary_key"`
 	EventID    string  `gorm:"type:text;not null"`
 	SeatNumber string  `gorm:"not null"`
-	Status     string  `gorm:"default:'available'"` // available, held, booked
+	Status     string  `gorm:"default:'available'"` // available, held, booked, blocked
 	HoldID     *string `gorm:"type:text"`
+	Presale    bool    `gorm:"not null;default:false"` // Needs a presale code before general sale
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 
@@ -43,16 +62,67 @@ type Seat struct {
 type Hold struct {
 	ID          string         `gorm:"type:text;primary_key"`
 	UserID      string         `gorm:"type:text;not null"` // User ID from User Service
+	UserEmail   string         `gorm:"type:text"`          // Recipient for hold expiry warnings
+	UserLocale  string         `gorm:"type:varchar(16)"`   // Language of the expiry warning
 	EventID     string         `gorm:"type:text;not null"`
 	SeatNumbers pq.StringArray `gorm:"type:text[]"`
 	ExpiresAt   time.Time      `gorm:"not null"`
 	Status      string         `gorm:"default:'active'"` // active, confirmed, expired
+	WarningSent bool           `gorm:"not null;default:false"`
+	Guest       bool           `gorm:"not null;default:false"` // Placed without an account; UserID is a one-off guest ID
+	GroupID     *string        `gorm:"type:text;index"`        // Set on holds placed together across events
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 
 	Event Event `gorm:"foreignKey:EventID"`
 }
 
+// HoldGroup is the parent of holds placed together on several events, e.g.
+// for a festival bundle. Its ID confirms or releases all of them at once.
+type HoldGroup struct {
+	ID        string    `gorm:"type:text;primary_key"`
+	UserID    string    `gorm:"type:text;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// Reasons recorded for seat status transitions
+const (
+	SeatTransitionHoldCreated   = "hold_created"
+	SeatTransitionHoldReleased  = "hold_released"
+	SeatTransitionHoldConfirmed = "hold_confirmed"
+	SeatTransitionHoldExpired   = "hold_expired"
+	SeatTransitionAdminUpdate   = "admin_update"
+	SeatTransitionReserved      = "reserved"
+)
+
+// SeatStatusEvent records one seat status transition. Rows are written in the
+// same transaction as the seat update so the history is always consistent.
+type SeatStatusEvent struct {
+	ID         uint64  `gorm:"primaryKey"`
+	EventID    string  `gorm:"type:text;not null"`
+	SeatID     string  `gorm:"type:text;not null"`
+	SeatNumber string  `gorm:"not null"`
+	FromStatus string  `gorm:"not null"`
+	ToStatus   string  `gorm:"not null"`
+	HoldID     *string `gorm:"type:text"`
+	Reason     string  `gorm:"not null"`
+	CreatedAt  time.Time
+}
+
+// SeatHistoryFilter represents filters for a seat status history query
+type SeatHistoryFilter struct {
+	EventID    string
+	SeatNumber string
+	Limit      int
+	Offset     int
+}
+
+// SeatPrice returns the per-seat price as Money
+func (e *Event) SeatPrice() Money {
+	return Money{Amount: e.PricePerSeatCents, Currency: e.Currency}
+}
+
 // Conversion methods to API DTOs
 func (e *Event) ToEventResponse(availableSeats int) *EventResponse {
 	return &EventResponse{
@@ -65,19 +135,38 @@ func (e *Event) ToEventResponse(availableSeats int) *EventResponse {
 		EventDate:      e.EventDate,
 		TotalSeats:     e.TotalSeats,
 		AvailableSeats: availableSeats,
-		PricePerSeat:   e.PricePerSeat,
+		PricePerSeat:   FromMinorUnits(e.PricePerSeatCents),
+		Currency:       e.Currency,
+		ImageURL:       e.ImageURL,
+		BannerURL:      e.BannerURL,
+		SeatStatus:     e.SeatStatus,
+		PresaleEndsAt:  e.activePresaleEnd(time.Now()),
 		CreatedAt:      e.CreatedAt,
 		CreatedBy:      e.CreatedBy,
 	}
 }
 
-func (h *Hold) ToHoldResponse(totalPrice float64) *HoldResponse {
+// ToEventHoldResponse converts a Hold to its entry in an event's hold listing
+func (h *Hold) ToEventHoldResponse() EventHoldResponse {
+	return EventHoldResponse{
+		HoldID:    h.ID,
+		UserID:    h.UserID,
+		Guest:     h.Guest,
+		Seats:     h.SeatNumbers,
+		Status:    h.Status,
+		ExpiresAt: h.ExpiresAt,
+		CreatedAt: h.CreatedAt,
+	}
+}
+
+func (h *Hold) ToHoldResponse(totalPrice Money) *HoldResponse {
 	return &HoldResponse{
 		HoldID:     h.ID,
 		EventID:    h.EventID,
 		HeldSeats:  h.SeatNumbers,
 		ExpiresAt:  h.ExpiresAt,
-		TotalPrice: totalPrice,
+		TotalPrice: totalPrice.Decimal(),
+		Currency:   totalPrice.Currency,
 	}
 }
 
@@ -87,29 +176,37 @@ func (h *Hold) ToHoldResponse(totalPrice float64) *HoldResponse {
 
 // CreateEventRequest represents input for creating an event in repository layer
 type CreateEventRequest struct {
-	ID           string
-	Name         string
-	Description  string
-	Venue        string
-	City         string
-	Category     string
-	EventDate    time.Time
-	TotalSeats   int
-	PricePerSeat float64
-	CreatedBy    string
+	ID                 string
+	Name               string
+	Description        string
+	Venue              string
+	City               string
+	Category           string
+	EventDate          time.Time
+	TotalSeats         int
+	PricePerSeatCents  int64
+	Currency           string
+	ImageURL           string
+	BannerURL          string
+	CreatedBy          string
+	SeatLabels         []string // Optional explicit seat labels; generated when empty
+	GenerateSeatsAsync bool     // Create the event immediately and populate seats with GenerateSeats
 }
 
 // UpdateEventRequest represents input for updating an event in repository layer
 type UpdateEventRequest struct {
-	ID           string
-	Name         string
-	Description  string
-	Venue        string
-	City         string
-	Category     string
-	EventDate    time.Time
-	TotalSeats   int
-	PricePerSeat float64
+	ID                string
+	Name              string
+	Description       string
+	Venue             string
+	City              string
+	Category          string
+	EventDate         time.Time
+	TotalSeats        int
+	PricePerSeatCents int64
+	Currency          string
+	ImageURL          string
+	BannerURL         string
 }
 
 // EventFilter represents filtering options for repository layer
@@ -119,17 +216,48 @@ type EventFilter struct {
 	DateTo   *time.Time
 	Category string
 	Name     string
+	PriceMin *int64 // Per-seat price bounds in minor units, inclusive
+	PriceMax *int64
+	Sort     string // One of EventSorts
 	Limit    int
 	Offset   int
 }
 
+// Event list orders: soonest first, by name, or newest first. Events that
+// sort equal are ordered by ID so they don't shift between pages.
+const (
+	EventSortDate      = "date"
+	EventSortName      = "name"
+	EventSortCreatedAt = "created_at"
+)
+
+// EventSorts lists the orders event lists can be sorted in
+var EventSorts = []string{EventSortDate, EventSortName, EventSortCreatedAt}
+
+// ValidEventSort reports whether sort is one of EventSorts
+func ValidEventSort(sort string) bool {
+	return slices.Contains(EventSorts, sort)
+}
+
 // CreateHoldRequest represents input for creating a hold in repository layer
 type CreateHoldRequest struct {
 	ID          string
 	UserID      string
+	UserEmail   string
+	UserLocale  string
+	Guest       bool
 	EventID     string
 	SeatNumbers []string
 	ExpiresAt   time.Time
+	PresaleCode string
+}
+
+// UpdateSeatStatusRequest represents a manual seat status change in the repository layer
+type UpdateSeatStatusRequest struct {
+	EventID     string
+	SeatNumbers []string
+	Status      string // available or blocked
+	Force       bool   // Allow changing booked seats
 }
 
 // ===============================
@@ -146,26 +274,229 @@ type CreateEventAPIRequest struct {
 	EventDate    time.Time `json:"event_date" binding:"required"`
 	TotalSeats   int       `json:"total_seats" binding:"required,min=1,max=1000000"`
 	PricePerSeat float64   `json:"price_per_seat" binding:"required,min=0.01"`
+	Currency     string    `json:"currency"`              // ISO 4217 code, defaults to USD
+	SeatLabels   []string  `json:"seat_labels,omitempty"` // Optional custom seat map; must contain total_seats unique labels
+	ImageURL     string    `json:"image_url" binding:"omitempty,http_url"`
+	BannerURL    string    `json:"banner_url" binding:"omitempty,http_url"`
 }
 
 // ToCreateEventRequest converts API request to repository request
 func (r *CreateEventAPIRequest) ToCreateEventRequest(userID string) CreateEventRequest {
+	price := NewMoney(r.PricePerSeat, r.Currency)
+	return CreateEventRequest{
+		Name:              r.Name,
+		Description:       r.Description,
+		Venue:             r.Venue,
+		City:              r.City,
+		Category:          r.Category,
+		EventDate:         r.EventDate,
+		TotalSeats:        r.TotalSeats,
+		PricePerSeatCents: price.Amount,
+		Currency:          price.Currency,
+		ImageURL:          r.ImageURL,
+		BannerURL:         r.BannerURL,
+		CreatedBy:         userID,
+		SeatLabels:        r.SeatLabels,
+	}
+}
+
+// UpdateEventAPIRequest represents the API request for updating an event. Only
+// the fields present are changed; seat counts are fixed once seats are generated.
+type UpdateEventAPIRequest struct {
+	Name         *string    `json:"name" binding:"omitempty,min=1"`
+	Description  *string    `json:"description"`
+	Venue        *string    `json:"venue" binding:"omitempty,min=1"`
+	City         *string    `json:"city" binding:"omitempty,min=1"`
+	Category     *string    `json:"category" binding:"omitempty,min=1"`
+	EventDate    *time.Time `json:"event_date"`
+	PricePerSeat *float64   `json:"price_per_seat" binding:"omitempty,min=0.01"`
+	Currency     *string    `json:"currency"`
+	ImageURL     *string    `json:"image_url" binding:"omitempty,http_url"`
+	BannerURL    *string    `json:"banner_url" binding:"omitempty,http_url"`
+}
+
+// ToUpdateEventRequest applies the changed fields on top of the current event
+func (r *UpdateEventAPIRequest) ToUpdateEventRequest(event *Event) UpdateEventRequest {
+	req := UpdateEventRequest{
+		ID:                event.ID,
+		Name:              event.Name,
+		Description:       event.Description,
+		Venue:             event.Venue,
+		City:              event.City,
+		Category:          event.Category,
+		EventDate:         event.EventDate,
+		TotalSeats:        event.TotalSeats,
+		PricePerSeatCents: event.PricePerSeatCents,
+		Currency:          event.Currency,
+		ImageURL:          event.ImageURL,
+		BannerURL:         event.BannerURL,
+	}
+
+	if r.Name != nil {
+		req.Name = *r.Name
+	}
+	if r.Description != nil {
+		req.Description = *r.Description
+	}
+	if r.Venue != nil {
+		req.Venue = *r.Venue
+	}
+	if r.City != nil {
+		req.City = *r.City
+	}
+	if r.Category != nil {
+		req.Category = *r.Category
+	}
+	if r.EventDate != nil {
+		req.EventDate = *r.EventDate
+	}
+	if r.Currency != nil || r.PricePerSeat != nil {
+		currency := event.Currency
+		if r.Currency != nil {
+			currency = *r.Currency
+		}
+		amount := event.SeatPrice().Decimal()
+		if r.PricePerSeat != nil {
+			amount = *r.PricePerSeat
+		}
+		price := NewMoney(amount, currency)
+		req.PricePerSeatCents = price.Amount
+		req.Currency = price.Currency
+	}
+	if r.ImageURL != nil {
+		req.ImageURL = *r.ImageURL
+	}
+	if r.BannerURL != nil {
+		req.BannerURL = *r.BannerURL
+	}
+
+	return req
+}
+
+// DuplicateEventAPIRequest represents the API request for copying an event to
+// a new date. The other fields override the copied values when present.
+type DuplicateEventAPIRequest struct {
+	UpdateEventAPIRequest
+	EventDate time.Time `json:"event_date" binding:"required"`
+}
+
+// ToCreateEventRequest builds the copy of the source event owned by userID
+func (r *DuplicateEventAPIRequest) ToCreateEventRequest(source *Event, userID string) CreateEventRequest {
+	fields := r.ToUpdateEventRequest(source)
 	return CreateEventRequest{
-		Name:         r.Name,
-		Description:  r.Description,
-		Venue:        r.Venue,
-		City:         r.City,
-		Category:     r.Category,
-		EventDate:    r.EventDate,
-		TotalSeats:   r.TotalSeats,
-		PricePerSeat: r.PricePerSeat,
-		CreatedBy:    userID,
+		Name:              fields.Name,
+		Description:       fields.Description,
+		Venue:             fields.Venue,
+		City:              fields.City,
+		Category:          fields.Category,
+		EventDate:         r.EventDate,
+		TotalSeats:        fields.TotalSeats,
+		PricePerSeatCents: fields.PricePerSeatCents,
+		Currency:          fields.Currency,
+		ImageURL:          fields.ImageURL,
+		BannerURL:         fields.BannerURL,
+		CreatedBy:         userID,
+	}
+}
+
+// PatchEventAPIRequest represents the API request for patching an event. Only
+// the fields present are written, so concurrent edits to other fields survive.
+// total_seats is accepted only when unchanged since seats can't be resized.
+type PatchEventAPIRequest struct {
+	UpdateEventAPIRequest
+	TotalSeats *int `json:"total_seats"`
+}
+
+// ToPatchFields returns the columns to update for the fields present in the request
+func (r *PatchEventAPIRequest) ToPatchFields() map[string]interface{} {
+	fields := make(map[string]interface{})
+	if r.Name != nil {
+		fields["name"] = *r.Name
+	}
+	if r.Description != nil {
+		fields["description"] = *r.Description
+	}
+	if r.Venue != nil {
+		fields["venue"] = *r.Venue
+	}
+	if r.City != nil {
+		fields["city"] = *r.City
+	}
+	if r.Category != nil {
+		fields["category"] = *r.Category
+	}
+	if r.EventDate != nil {
+		fields["event_date"] = *r.EventDate
+	}
+	if r.PricePerSeat != nil {
+		fields["price_per_seat_cents"] = ToMinorUnits(*r.PricePerSeat)
 	}
+	if r.Currency != nil {
+		fields["currency"] = NormalizeCurrency(*r.Currency)
+	}
+	if r.ImageURL != nil {
+		fields["image_url"] = *r.ImageURL
+	}
+	if r.BannerURL != nil {
+		fields["banner_url"] = *r.BannerURL
+	}
+	return fields
 }
 
 // HoldSeatsRequest represents the API request for holding seats
 type HoldSeatsRequest struct {
 	SeatNumbers []string `json:"seat_numbers" binding:"required,min=1"`
+	PresaleCode string   `json:"presale_code,omitempty" binding:"max=64"`
+}
+
+// MultiHoldRequest represents a request to hold seats on several events at
+// once. Each event may appear only once.
+type MultiHoldRequest struct {
+	Holds []MultiHoldItem `json:"holds" binding:"required,min=1,max=10,dive"`
+}
+
+// MultiHoldItem is one event's seats in a MultiHoldRequest
+type MultiHoldItem struct {
+	EventID string `json:"event_id" binding:"required"`
+	HoldSeatsRequest
+}
+
+// ReserveSeatsRequest represents a trusted request to book seats directly,
+// without a hold or payment
+type ReserveSeatsRequest struct {
+	SeatNumbers []string `json:"seat_numbers" binding:"required,min=1,max=1000"`
+}
+
+// GuestHoldSeatsRequest represents a hold request from a buyer without an
+// account. The email receives the hold warning and booking confirmation.
+type GuestHoldSeatsRequest struct {
+	HoldSeatsRequest
+	Email  string `json:"email" binding:"required,email"`
+	Locale string `json:"locale" binding:"omitempty,bcp47_language_tag"`
+}
+
+// SoldOutDetails accompanies the event_sold_out error so clients can tell an
+// event with no seats left apart from a conflict on specific seats
+type SoldOutDetails struct {
+	AvailableSeats int `json:"available_seats"`
+}
+
+// UpdateSeatStatusAPIRequest represents the admin request to set seats
+// available or blocked. Booked seats are only changed when force is set.
+type UpdateSeatStatusAPIRequest struct {
+	SeatNumbers []string `json:"seat_numbers" binding:"required,min=1,max=1000"`
+	Status      string   `json:"status" binding:"required,oneof=available blocked"`
+	Force       bool     `json:"force"`
+}
+
+// ToUpdateSeatStatusRequest converts API request to repository request
+func (r *UpdateSeatStatusAPIRequest) ToUpdateSeatStatusRequest(eventID string) UpdateSeatStatusRequest {
+	return UpdateSeatStatusRequest{
+		EventID:     eventID,
+		SeatNumbers: r.SeatNumbers,
+		Status:      r.Status,
+		Force:       r.Force,
+	}
 }
 
 // ToCreateHoldRequest converts API request to repository request
@@ -175,24 +506,31 @@ func (r *HoldSeatsRequest) ToCreateHoldRequest(userID, eventID string, expiresAt
 		EventID:     eventID,
 		SeatNumbers: r.SeatNumbers,
 		ExpiresAt:   expiresAt,
+		PresaleCode: NormalizePresaleCode(r.PresaleCode),
 	}
 }
 
 // EventResponse represents event data in API responses
 type EventResponse struct {
-	EventID              string    `json:"event_id"`
-	Name                 string    `json:"name"`
-	Description          string    `json:"description,omitempty"`
-	Venue                string    `json:"venue"`
-	City                 string    `json:"city"`
-	Category             string    `json:"category"`
-	EventDate            time.Time `json:"event_date"`
-	TotalSeats           int       `json:"total_seats"`
-	AvailableSeats       int       `json:"available_seats"`
-	PricePerSeat         float64   `json:"price_per_seat"`
-	AvailableSeatNumbers []string  `json:"available_seat_numbers,omitempty"` // Only in detail view
-	CreatedAt            time.Time `json:"created_at"`
-	CreatedBy            string    `json:"created_by"`
+	EventID              string      `json:"event_id"`
+	Name                 string      `json:"name"`
+	Description          string      `json:"description,omitempty"`
+	Venue                string      `json:"venue"`
+	City                 string      `json:"city"`
+	Category             string      `json:"category"`
+	EventDate            time.Time   `json:"event_date"`
+	TotalSeats           int         `json:"total_seats"`
+	AvailableSeats       int         `json:"available_seats"`
+	PricePerSeat         float64     `json:"price_per_seat"`
+	Currency             string      `json:"currency"`
+	ImageURL             string      `json:"image_url,omitempty"`
+	BannerURL            string      `json:"banner_url,omitempty"`
+	SeatStatus           string      `json:"seat_status"`                      // ready, generating or failed
+	PresaleEndsAt        *time.Time  `json:"presale_ends_at,omitempty"`        // Set while presale seats need a code
+	AvailableSeatNumbers []string    `json:"available_seat_numbers,omitempty"` // Only in detail view when requested
+	SeatPagination       *Pagination `json:"seat_pagination,omitempty"`
+	CreatedAt            time.Time   `json:"created_at"`
+	CreatedBy            string      `json:"created_by"`
 }
 
 // EventListResponse represents the response for listing events
@@ -201,14 +539,34 @@ type EventListResponse struct {
 	Pagination Pagination      `json:"pagination"`
 }
 
-// Pagination represents pagination information
-type Pagination struct {
-	Total   int  `json:"total"`
-	Limit   int  `json:"limit"`
-	Offset  int  `json:"offset"`
-	HasMore bool `json:"has_more"`
+// CategoriesResponse lists the categories events may use
+type CategoriesResponse struct {
+	Categories []string `json:"categories"`
+	Restricted bool     `json:"restricted"` // False when any category is accepted
+}
+
+// EventViews is an event's view count over the popular events window
+type EventViews struct {
+	EventID string
+	Views   int64
+}
+
+// PopularEventResponse is an upcoming event with its recent views
+type PopularEventResponse struct {
+	EventResponse
+	Views int64 `json:"views"`
 }
 
+// PopularEventsResponse lists upcoming events by views over the window, most
+// viewed first
+type PopularEventsResponse struct {
+	Events []PopularEventResponse `json:"events"`
+	Window string                 `json:"window"`
+}
+
+// Pagination represents pagination information, shared with the other services
+type Pagination = pagination.Pagination
+
 // HoldResponse represents the response for seat hold operations
 type HoldResponse struct {
 	HoldID     string    `json:"hold_id"`
@@ -216,19 +574,95 @@ type HoldResponse struct {
 	HeldSeats  []string  `json:"held_seats"`
 	ExpiresAt  time.Time `json:"expires_at"`
 	TotalPrice float64   `json:"total_price"`
+	Currency   string    `json:"currency"`
+}
+
+// MultiHoldResponse represents seats held on several events at once. The
+// group hold ID can be booked, confirmed and released like a single hold.
+type MultiHoldResponse struct {
+	GroupHoldID string         `json:"group_hold_id"`
+	Holds       []HoldResponse `json:"holds"`
+	ExpiresAt   time.Time      `json:"expires_at"`
+	TotalPrice  float64        `json:"total_price"`
+	Currency    string         `json:"currency"`
+}
+
+// ReservationResponse represents seats booked through a trusted reservation
+type ReservationResponse struct {
+	HoldID      string    `json:"hold_id"`
+	EventID     string    `json:"event_id"`
+	BookedSeats []string  `json:"booked_seats"`
+	ReservedBy  string    `json:"reserved_by"`
+	ReservedAt  time.Time `json:"reserved_at"`
 }
 
-// SeatsNotAvailableError represents error when seats are not available
+// GuestHoldResponse represents a guest hold along with the token that lets
+// the guest complete or release it
+type GuestHoldResponse struct {
+	HoldResponse
+	GuestToken          string    `json:"guest_token"`
+	GuestTokenExpiresAt time.Time `json:"guest_token_expires_at"`
+}
+
+// EventHoldResponse represents a hold in an event's hold listing
+type EventHoldResponse struct {
+	HoldID    string    `json:"hold_id"`
+	UserID    string    `json:"user_id"`
+	Guest     bool      `json:"guest"`
+	Seats     []string  `json:"seats"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EventHoldsResponse represents the response for listing an event's holds
+type EventHoldsResponse struct {
+	EventID    string              `json:"event_id"`
+	Holds      []EventHoldResponse `json:"holds"`
+	Pagination Pagination          `json:"pagination"`
+}
+
+// SeatsNotAvailableError accompanies the seats_unavailable error. Alternatives
+// are a full replacement for the requested seats, or empty when none fits.
 type SeatsNotAvailableError struct {
+	EventID               string   `json:"event_id"`
 	UnavailableSeats      []string `json:"unavailable_seats"`
 	AvailableAlternatives []string `json:"available_alternatives"`
 }
 
-// ErrorResponse represents error responses
-type ErrorResponse struct {
-	Error   string      `json:"error"`
-	Message string      `json:"message"`
-	Details interface{} `json:"details,omitempty"`
+// InvalidSeatsDetails accompanies the invalid_seats error when holding seats,
+// listing the seats the event doesn't have and, of the rest, those taken
+type InvalidSeatsDetails struct {
+	EventID          string   `json:"event_id"`
+	NonexistentSeats []string `json:"nonexistent_seats"`
+	UnavailableSeats []string `json:"unavailable_seats"`
+}
+
+// SeatCountResponse represents the lightweight seat availability response
+type SeatCountResponse struct {
+	EventID        string `json:"event_id"`
+	TotalSeats     int    `json:"total_seats"`
+	AvailableSeats int    `json:"available_seats"`
+}
+
+// UpdateSeatStatusResponse reports the seats changed by a manual status update
+type UpdateSeatStatusResponse struct {
+	EventID string `json:"event_id"`
+	Status  string `json:"status"`
+	Updated int    `json:"updated"`
+}
+
+// RegenerateSeatsResponse reports the seats created by a seat repair
+type RegenerateSeatsResponse struct {
+	EventID    string `json:"event_id"`
+	TotalSeats int    `json:"total_seats"`
+	SeatsAdded int    `json:"seats_added"`
+}
+
+// FieldError describes a validation failure for a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // HealthResponse represents health check response
@@ -238,16 +672,72 @@ type HealthResponse struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// SeatStatusEventResponse represents one seat status transition
+type SeatStatusEventResponse struct {
+	SeatNumber string    `json:"seat_number"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	HoldID     *string   `json:"hold_id,omitempty"`
+	Reason     string    `json:"reason"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// SeatHistoryResponse represents the seat status history of an event, oldest first
+type SeatHistoryResponse struct {
+	EventID    string                    `json:"event_id"`
+	Events     []SeatStatusEventResponse `json:"events"`
+	Pagination Pagination                `json:"pagination"`
+}
+
+// ToSeatStatusEventResponse converts a SeatStatusEvent to its API response
+func (e *SeatStatusEvent) ToSeatStatusEventResponse() SeatStatusEventResponse {
+	return SeatStatusEventResponse{
+		SeatNumber: e.SeatNumber,
+		FromStatus: e.FromStatus,
+		ToStatus:   e.ToStatus,
+		HoldID:     e.HoldID,
+		Reason:     e.Reason,
+		Timestamp:  e.CreatedAt,
+	}
+}
+
+// CheckoutHoldResponse represents a user's own hold, to resume checkout
+type CheckoutHoldResponse struct {
+	HoldID          string    `json:"hold_id"`
+	EventID         string    `json:"event_id"`
+	EventName       string    `json:"event_name"`
+	Venue           string    `json:"venue"`
+	EventDate       time.Time `json:"event_date"`
+	Seats           []string  `json:"seats"`
+	TotalPrice      float64   `json:"total_price"`
+	TotalPriceCents int64     `json:"total_price_cents"`
+	Currency        string    `json:"currency"`
+	Status          string    `json:"status"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
 // HoldDetailsResponse represents hold details for external services
 type HoldDetailsResponse struct {
-	HoldID     string    `json:"hold_id"`
-	UserID     string    `json:"user_id"`
-	UserName   string    `json:"user_name,omitempty"` // Optional, requires user service lookup
-	EventID    string    `json:"event_id"`
-	EventName  string    `json:"event_name"`
-	Venue      string    `json:"venue"`
-	EventDate  time.Time `json:"event_date"`
-	Seats      []string  `json:"seats"`
-	TotalPrice float64   `json:"total_price"`
-	ExpiresAt  time.Time `json:"expires_at"`
+	HoldID          string    `json:"hold_id"`
+	UserID          string    `json:"user_id"`
+	UserName        string    `json:"user_name,omitempty"` // Optional, requires user service lookup
+	EventID         string    `json:"event_id"`
+	EventName       string    `json:"event_name"`
+	Venue           string    `json:"venue"`
+	EventDate       time.Time `json:"event_date"`
+	Seats           []string  `json:"seats"`
+	TotalPrice      float64   `json:"total_price"`
+	TotalPriceCents int64     `json:"total_price_cents"`
+	Currency        string    `json:"currency"`
+	ExpiresAt       time.Time `json:"expires_at"`
+
+	// Holds breaks a hold group down by event. The fields above then sum the
+	// group: the first event's ID, every event's name and seats, the earliest
+	// date and expiry and the total price.
+	Holds []HoldDetailsResponse `json:"holds,omitempty"`
+}
+
+// AnonymizeUserHoldsResponse reports how many holds had personal data removed
+type AnonymizeUserHoldsResponse struct {
+	AnonymizedHolds int `json:"anonymized_holds"`
 }
@@ -0,0 +1,15 @@
+package model
+
+// SetMaintenanceRequest turns the runtime maintenance switch on or off
+type SetMaintenanceRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// MaintenanceResponse reports whether writes are rejected. Configured is set
+// when configuration enables maintenance mode, which the runtime switch can't
+// turn off.
+type MaintenanceResponse struct {
+	Enabled    bool `json:"enabled"`
+	Configured bool `json:"configured"`
+	Runtime    bool `json:"runtime"`
+}
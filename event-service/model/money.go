@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultCurrency is used when a request doesn't specify a currency
+const DefaultCurrency = "USD"
+
+// SupportedCurrencies lists the ISO 4217 codes accepted for event pricing.
+// All of them use two decimal places of minor units.
+var SupportedCurrencies = []string{"USD", "EUR", "GBP", "CAD", "AUD", "INR"}
+
+// Money represents an amount in integer minor units (e.g. cents) of a currency,
+// avoiding floating-point drift when computing totals
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// NewMoney creates a Money value from a decimal amount as received over the API
+func NewMoney(amount float64, currency string) Money {
+	return Money{Amount: ToMinorUnits(amount), Currency: NormalizeCurrency(currency)}
+}
+
+// Multiply returns the amount multiplied by a quantity (e.g. number of seats)
+func (m Money) Multiply(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Add returns the sum of two amounts in the same currency
+func (m Money) Add(other Money) Money {
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}
+}
+
+// Decimal returns the amount in major units for API responses
+func (m Money) Decimal() float64 {
+	return FromMinorUnits(m.Amount)
+}
+
+// String formats the amount with its currency code, e.g. "12.50 USD"
+func (m Money) String() string {
+	return fmt.Sprintf("%.2f %s", m.Decimal(), m.Currency)
+}
+
+// ToMinorUnits converts a decimal amount to minor units, rounding to the nearest unit
+func ToMinorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// FromMinorUnits converts minor units to a decimal amount
+func FromMinorUnits(units int64) float64 {
+	return float64(units) / 100
+}
+
+// NormalizeCurrency upper-cases a currency code, defaulting to DefaultCurrency when empty
+func NormalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency
+	}
+	return code
+}
+
+// IsSupportedCurrency reports whether the currency code is in the allowlist
+func IsSupportedCurrency(code string) bool {
+	for _, supported := range SupportedCurrencies {
+		if supported == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package model
+
+import "testing"
+
+func TestToMinorUnits(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   int64
+	}{
+		{0, 0},
+		{12.5, 1250},
+		{0.1 + 0.2, 30},
+		{19.999, 2000},
+	}
+	for _, tt := range tests {
+		if got := ToMinorUnits(tt.amount); got != tt.want {
+			t.Errorf("ToMinorUnits(%v) = %d, want %d", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyMultiplyKeepsMinorUnits(t *testing.T) {
+	price := NewMoney(0.1, "usd")
+	total := price.Multiply(3)
+	if total.Amount != 30 || total.Currency != "USD" {
+		t.Fatalf("Multiply(3) = %+v, want 30 USD", total)
+	}
+	if total.Decimal() != 0.3 {
+		t.Errorf("Decimal() = %v, want 0.3", total.Decimal())
+	}
+	if total.String() != "0.30 USD" {
+		t.Errorf("String() = %q, want %q", total.String(), "0.30 USD")
+	}
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	tests := map[string]string{
+		"":      DefaultCurrency,
+		"  ":    DefaultCurrency,
+		"eur":   "EUR",
+		" gbp ": "GBP",
+	}
+	for code, want := range tests {
+		if got := NormalizeCurrency(code); got != want {
+			t.Errorf("NormalizeCurrency(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestIsSupportedCurrency(t *testing.T) {
+	for _, code := range SupportedCurrencies {
+		if !IsSupportedCurrency(code) {
+			t.Errorf("IsSupportedCurrency(%q) = false", code)
+		}
+	}
+	for _, code := range []string{"", "usd", "XYZ", "BTC"} {
+		if IsSupportedCurrency(code) {
+			t.Errorf("IsSupportedCurrency(%q) = true", code)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// NotificationTypeHoldExpiring warns a holder that their seats are about to be released
+const NotificationTypeHoldExpiring = "hold_expiring"
+
+// NotificationRequest represents the message sent to the notification topic.
+// It mirrors the booking service message so the notification worker can
+// consume both.
+type NotificationRequest struct {
+	Type           string                  `json:"type"`
+	RecipientEmail string                  `json:"recipient_email"`
+	BookingData    NotificationBookingData `json:"booking_data"`
+	HoldData       *NotificationHoldData   `json:"hold_data,omitempty"`
+	Timestamp      time.Time               `json:"timestamp"`
+	Locale         string                  `json:"locale,omitempty"`
+}
+
+// NotificationBookingData represents event details for notifications
+type NotificationBookingData struct {
+	EventName string    `json:"event_name"`
+	Venue     string    `json:"venue"`
+	EventDate time.Time `json:"event_date"`
+	Seats     []string  `json:"seats"`
+}
+
+// NotificationHoldData represents hold details for expiry warnings
+type NotificationHoldData struct {
+	HoldID      string    `json:"hold_id"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CheckoutURL string    `json:"checkout_url"`
+}
+
+// ToHoldExpiringNotification builds the expiry warning for this hold
+func (h *Hold) ToHoldExpiringNotification(checkoutURL string) *NotificationRequest {
+	return &NotificationRequest{
+		Type:           NotificationTypeHoldExpiring,
+		RecipientEmail: h.UserEmail,
+		BookingData: NotificationBookingData{
+			EventName: h.Event.Name,
+			Venue:     h.Event.Venue,
+			EventDate: h.Event.EventDate,
+			Seats:     h.SeatNumbers,
+		},
+		HoldData: &NotificationHoldData{
+			HoldID:      h.ID,
+			ExpiresAt:   h.ExpiresAt,
+			CheckoutURL: checkoutURL,
+		},
+		Timestamp: time.Now().UTC(),
+		Locale:    h.UserLocale,
+	}
+}
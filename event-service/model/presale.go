@@ -0,0 +1,81 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// PresaleCode is a code that lets its holder hold an event's presale seats
+// before general sale
+type PresaleCode struct {
+	ID        uint64 `gorm:"primaryKey"`
+	EventID   string `gorm:"type:text;not null;uniqueIndex:idx_presale_codes_event_code"`
+	Code      string `gorm:"type:text;not null;uniqueIndex:idx_presale_codes_event_code"`
+	CreatedAt time.Time
+}
+
+// NormalizePresaleCode trims and upper-cases a code so buyers don't have to
+// match the case it was handed out in
+func NormalizePresaleCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// PresaleActive reports whether the event's presale seats still need a code at
+// now. The restriction lifts at PresaleEndsAt itself.
+func (e *Event) PresaleActive(now time.Time) bool {
+	return e.PresaleEndsAt != nil && now.Before(*e.PresaleEndsAt)
+}
+
+// activePresaleEnd returns PresaleEndsAt while the presale is running and nil
+// once general sale has started
+func (e *Event) activePresaleEnd(now time.Time) *time.Time {
+	if !e.PresaleActive(now) {
+		return nil
+	}
+	return e.PresaleEndsAt
+}
+
+// SetPresaleRequest replaces an event's presale in the repository layer
+type SetPresaleRequest struct {
+	EventID     string
+	SeatNumbers []string
+	Codes       []string
+	EndsAt      time.Time
+}
+
+// SetPresaleAPIRequest represents the request to run a presale on an event.
+// It replaces any presale seats and codes set before.
+type SetPresaleAPIRequest struct {
+	SeatNumbers []string  `json:"seat_numbers" binding:"required,min=1,max=1000"`
+	Codes       []string  `json:"codes" binding:"required,min=1,max=1000,dive,min=4,max=64"`
+	EndsAt      time.Time `json:"ends_at" binding:"required"`
+}
+
+// ToSetPresaleRequest converts API request to repository request, dropping
+// codes that only differ by case or surrounding spaces
+func (r *SetPresaleAPIRequest) ToSetPresaleRequest(eventID string) SetPresaleRequest {
+	seen := make(map[string]bool, len(r.Codes))
+	codes := make([]string, 0, len(r.Codes))
+	for _, code := range r.Codes {
+		code = NormalizePresaleCode(code)
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+
+	return SetPresaleRequest{
+		EventID:     eventID,
+		SeatNumbers: r.SeatNumbers,
+		Codes:       codes,
+		EndsAt:      r.EndsAt.UTC(),
+	}
+}
+
+// PresaleResponse summarizes an event's presale. Codes aren't echoed back.
+type PresaleResponse struct {
+	EventID      string    `json:"event_id"`
+	PresaleSeats int       `json:"presale_seats"`
+	Codes        int       `json:"codes"`
+	EndsAt       time.Time `json:"ends_at"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPresaleActive(t *testing.T) {
+	endsAt := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		endsAt  *time.Time
+		now     time.Time
+		want    bool
+		wantEnd bool
+	}{
+		{"no presale", nil, endsAt, false, false},
+		{"an hour before general sale", &endsAt, endsAt.Add(-time.Hour), true, true},
+		{"a nanosecond before general sale", &endsAt, endsAt.Add(-time.Nanosecond), true, true},
+		{"when general sale starts", &endsAt, endsAt, false, false},
+		{"after general sale starts", &endsAt, endsAt.Add(time.Second), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &Event{PresaleEndsAt: tt.endsAt}
+			if got := event.PresaleActive(tt.now); got != tt.want {
+				t.Errorf("PresaleActive() = %v, want %v", got, tt.want)
+			}
+			if got := event.activePresaleEnd(tt.now); (got != nil) != tt.wantEnd {
+				t.Errorf("activePresaleEnd() = %v, want set %v", got, tt.wantEnd)
+			}
+		})
+	}
+}
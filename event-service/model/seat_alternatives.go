@@ -0,0 +1,94 @@
+package model
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// generatedSeatPattern matches generated seat numbers such as A12 or AB3
+var generatedSeatPattern = regexp.MustCompile(`^([A-Z]+)([0-9]{1,9})$`)
+
+// seatPosition locates a generated seat by row index and seat number
+type seatPosition struct {
+	row, seat int
+}
+
+// parseSeatPosition reads a generated seat number, with rows counted like
+// spreadsheet columns (A=1, Z=26, AA=27). Custom labels don't parse.
+func parseSeatPosition(seatNumber string) (seatPosition, bool) {
+	match := generatedSeatPattern.FindStringSubmatch(seatNumber)
+	if match == nil {
+		return seatPosition{}, false
+	}
+
+	row := 0
+	for _, letter := range match[1] {
+		row = row*26 + int(letter-'A') + 1
+		if row > math.MaxInt32 {
+			return seatPosition{}, false
+		}
+	}
+	seat, _ := strconv.Atoi(match[2])
+	return seatPosition{row: row, seat: seat}, true
+}
+
+// SuggestAlternativeSeats picks as many available seats as were requested,
+// closest to the requested ones: seats in the same row come first, then
+// adjacent rows, nearest seat number first within a row. Requested seats that
+// are still available are kept. available is expected in venue order, which
+// also breaks ties and orders custom labels, placed last. Returns nil when more
+// than limit seats were requested or too few are available to replace them all.
+func SuggestAlternativeSeats(requested, available []string, limit int) []string {
+	count := len(requested)
+	if count == 0 || count > limit || len(available) < count {
+		return nil
+	}
+
+	var positions []seatPosition
+	for _, seatNumber := range requested {
+		if position, ok := parseSeatPosition(seatNumber); ok {
+			positions = append(positions, position)
+		}
+	}
+
+	type candidate struct {
+		seatNumber          string
+		rowDistance, offset int
+	}
+	candidates := make([]candidate, len(available))
+	for i, seatNumber := range available {
+		best := candidate{seatNumber: seatNumber, rowDistance: math.MaxInt, offset: math.MaxInt}
+		if position, ok := parseSeatPosition(seatNumber); ok {
+			for _, target := range positions {
+				rowDistance := abs(position.row - target.row)
+				offset := abs(position.seat - target.seat)
+				if rowDistance < best.rowDistance || (rowDistance == best.rowDistance && offset < best.offset) {
+					best.rowDistance, best.offset = rowDistance, offset
+				}
+			}
+		}
+		candidates[i] = best
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].rowDistance != candidates[j].rowDistance {
+			return candidates[i].rowDistance < candidates[j].rowDistance
+		}
+		return candidates[i].offset < candidates[j].offset
+	})
+
+	suggestions := make([]string, count)
+	for i := range suggestions {
+		suggestions[i] = candidates[i].seatNumber
+	}
+	return suggestions
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
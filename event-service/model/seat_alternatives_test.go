@@ -0,0 +1,67 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggestAlternativeSeats(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested []string
+		available []string
+		limit     int
+		want      []string
+	}{
+		{
+			name:      "same row first",
+			requested: []string{"B5", "B6"},
+			available: []string{"A5", "A6", "B1", "B8", "C5"},
+			limit:     50,
+			want:      []string{"B8", "B1"},
+		},
+		{
+			name:      "adjacent rows when the row is full",
+			requested: []string{"B5"},
+			available: []string{"A9", "C5", "D5"},
+			limit:     50,
+			want:      []string{"C5"},
+		},
+		{
+			name:      "keeps requested seats still available",
+			requested: []string{"A1", "A2"},
+			available: []string{"A2", "A3", "F1"},
+			limit:     50,
+			want:      []string{"A2", "A3"},
+		},
+		{
+			name:      "custom labels come last",
+			requested: []string{"A1"},
+			available: []string{"BOX", "C1"},
+			limit:     50,
+			want:      []string{"C1"},
+		},
+		{
+			name:      "too few available",
+			requested: []string{"A1", "A2", "A3"},
+			available: []string{"B1", "B2"},
+			limit:     50,
+			want:      nil,
+		},
+		{
+			name:      "more requested than the limit",
+			requested: []string{"A1", "A2"},
+			available: []string{"B1", "B2"},
+			limit:     1,
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestAlternativeSeats(tt.requested, tt.available, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestAlternativeSeats() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// popularCandidates is how many times the requested number of events are read
+// from the view ranking, leaving room for events that have already happened
+const popularCandidates = 3
+
+// GetPopularEvents handles listing upcoming events by how often they were
+// viewed over the configured window. The list is cached briefly since the
+// ranking only changes gradually.
+func (h *EventHandler) GetPopularEvents(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	limit = h.cfg.Pagination.Limits().Clamp(limit)
+
+	if cached, err := h.cache.GetPopularEvents(limit); err == nil && cached != nil {
+		c.JSON(http.StatusOK, cached)
+		return
+	}
+
+	window := h.cfg.Cache.Popular.Window
+	views, err := h.cache.TopViewedEvents(window, limit*popularCandidates)
+	if err != nil {
+		log.Printf("Failed to rank popular events: %v", err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve popular events")
+		return
+	}
+
+	ids := make([]string, len(views))
+	for i, v := range views {
+		ids[i] = v.EventID
+	}
+	events, err := h.repo.GetUpcomingEventsByIDs(ids)
+	if err != nil {
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to retrieve popular events")
+		return
+	}
+	upcoming := make(map[string]*model.Event, len(events))
+	for i := range events {
+		upcoming[events[i].ID] = &events[i]
+	}
+
+	response := model.PopularEventsResponse{
+		Events: make([]model.PopularEventResponse, 0, limit),
+		Window: window.String(),
+	}
+	for _, v := range views {
+		event, ok := upcoming[v.EventID]
+		if !ok {
+			continue
+		}
+		response.Events = append(response.Events, model.PopularEventResponse{
+			EventResponse: *event.ToEventResponse(h.availableSeatCount(event.ID)),
+			Views:         v.Views,
+		})
+		if len(response.Events) == limit {
+			break
+		}
+	}
+
+	h.cache.SetPopularEvents(limit, &response, h.cfg.Cache.Popular.CacheTTL)
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetPresale handles starting a presale: the given seats can only be held with
+// one of the codes until ends_at, after which they go on general sale.
+// Setting a presale again replaces the previous seats and codes.
+func (h *EventHandler) SetPresale(c *gin.Context) {
+	eventID := c.Param("id")
+
+	var req model.SetPresaleAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+	if !req.EndsAt.After(time.Now()) {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed", "ends_at must be in the future",
+			[]model.FieldError{{Field: "ends_at", Message: "ends_at must be in the future"}})
+		return
+	}
+
+	if !h.authorizeOrganizer(c, eventID, "Only the event creator can manage its presale") {
+		return
+	}
+
+	presaleReq := req.ToSetPresaleRequest(eventID)
+	if err := h.repo.SetPresale(presaleReq); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEventNotFound):
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
+		case errors.Is(err, repository.ErrSeatsNotFound):
+			middleware.RespondError(c, http.StatusBadRequest, "invalid_seats", err.Error())
+		default:
+			log.Printf("Failed to set presale for event %s: %v", eventID, err)
+			middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to set presale")
+		}
+		return
+	}
+
+	log.Printf("User %s set a presale of %d seats on event %s until %s",
+		c.GetString("user_id"), len(presaleReq.SeatNumbers), eventID, presaleReq.EndsAt.Format(time.RFC3339))
+
+	h.cache.InvalidateEvent(eventID)
+	h.cache.InvalidateEventList("*")
+
+	c.JSON(http.StatusOK, model.PresaleResponse{
+		EventID:      eventID,
+		PresaleSeats: len(presaleReq.SeatNumbers),
+		Codes:        len(presaleReq.Codes),
+		EndsAt:       presaleReq.EndsAt,
+	})
+}
+
+// ClearPresale handles ending a presale early, opening its seats to everyone
+func (h *EventHandler) ClearPresale(c *gin.Context) {
+	eventID := c.Param("id")
+
+	if !h.authorizeOrganizer(c, eventID, "Only the event creator can manage its presale") {
+		return
+	}
+
+	if err := h.repo.ClearPresale(eventID); err != nil {
+		if errors.Is(err, repository.ErrEventNotFound) {
+			middleware.RespondError(c, http.StatusNotFound, "not_found", "Event not found")
+			return
+		}
+		log.Printf("Failed to clear presale for event %s: %v", eventID, err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to clear presale")
+		return
+	}
+
+	h.cache.InvalidateEvent(eventID)
+	h.cache.InvalidateEventList("*")
+
+	c.Status(http.StatusNoContent)
+}
+
+// authorizeOrganizer checks the caller created the event or is an admin,
+// writing the error response and returning false otherwise
+func (h *EventHandler) authorizeOrganizer(c *gin.Context, eventID, forbiddenMessage string) bool {
+	event, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return false
+	}
+	if event.CreatedBy != c.GetString("user_id") && !isAdmin(c) {
+		middleware.RespondError(c, http.StatusForbidden, "forbidden", forbiddenMessage)
+		return false
+	}
+	return true
+}
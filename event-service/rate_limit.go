@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/arunvm123/eventbooking/event-service/cache"
+	"github.com/arunvm123/eventbooking/event-service/config"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit rejects callers that have used up their request quota with 429 and
+// a Retry-After header. Authenticated callers get their role's quota per user
+// ID, so it must run after authentication on those routes; anonymous callers
+// get the guest quota per client IP. Service tokens pass through, and so does
+// every request while Redis is unavailable, since limiting shouldn't take the
+// API down with it.
+func RateLimit(cacheRepo cache.CacheRepository, cfg config.RateLimitConfig) gin.HandlerFunc {
+	if !cfg.Enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		role := c.GetString(middleware.ContextUserRole)
+		if role == auth.RoleService {
+			c.Next()
+			return
+		}
+
+		bucket, quota := "user:"+c.GetString(middleware.ContextUserID), cfg.QuotaFor(role)
+		if _, authenticated := c.Get(middleware.ContextUserID); !authenticated {
+			bucket, quota = "ip:"+c.ClientIP(), cfg.AnonymousPerMinute
+		}
+
+		allowed, retryAfter, err := cacheRepo.TakeRequestToken(bucket, quota)
+		if err != nil {
+			log.Printf("Rate limit check for %s failed, allowing request: %v", bucket, err)
+			c.Next()
+			return
+		}
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			middleware.RespondError(c, http.StatusTooManyRequests, "rate_limited", "Too many requests, please retry later")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by EventRepository implementations. Handlers match them with
+// errors.Is; errors about specific seats wrap these with the offending seat
+// numbers appended to the message.
+var (
+	ErrEventNotFound    = errors.New("event not found")
+	ErrEventHasBookings = errors.New("event has bookings")
+
+	ErrHoldNotFound = errors.New("hold not found")
+	ErrHoldExpired  = errors.New("hold expired")
+
+	ErrSeatsUnavailable = errors.New("seats not available")
+	ErrSeatsNotFound    = errors.New("seat numbers do not exist")
+	ErrSeatsHeld        = errors.New("seats held")
+	ErrSeatsBooked      = errors.New("seats booked")
+
+	// Presale seats held before general sale
+	ErrPresaleCodeRequired = errors.New("presale code required")
+	ErrPresaleCodeInvalid  = errors.New("presale code invalid")
+
+	// Seat generation state
+	ErrSeatsNotReady    = errors.New("seats not ready")
+	ErrSeatsGenerating  = errors.New("seats generating")
+	ErrCustomSeatLabels = errors.New("custom seat labels")
+
+	// Creating an event's seats kept failing with transient database errors;
+	// the event wasn't created
+	ErrSeatCreationFailed = errors.New("seat creation failed")
+)
+
+// HoldGroupError reports which event's seats stopped a hold group from being
+// placed. It unwraps to the error for that event.
+type HoldGroupError struct {
+	EventID string
+	Err     error
+}
+
+func (e *HoldGroupError) Error() string {
+	return fmt.Sprintf("event %s: %v", e.EventID, e.Err)
+}
+
+func (e *HoldGroupError) Unwrap() error {
+	return e.Err
+}
+
+// SeatCheckError lists the requested seats that can't be held: those the
+// event doesn't have and those taken. It matches ErrSeatsNotFound when any
+// seat is missing, otherwise ErrSeatsUnavailable.
+type SeatCheckError struct {
+	Missing     []string
+	Unavailable []string
+}
+
+func (e *SeatCheckError) Error() string {
+	if len(e.Missing) == 0 {
+		return fmt.Sprintf("%v: %v", ErrSeatsUnavailable, e.Unavailable)
+	}
+	if len(e.Unavailable) == 0 {
+		return fmt.Sprintf("%v: %v", ErrSeatsNotFound, e.Missing)
+	}
+	return fmt.Sprintf("%v: %v; %v: %v", ErrSeatsNotFound, e.Missing, ErrSeatsUnavailable, e.Unavailable)
+}
+
+func (e *SeatCheckError) Unwrap() error {
+	if len(e.Missing) > 0 {
+		return ErrSeatsNotFound
+	}
+	return ErrSeatsUnavailable
+}
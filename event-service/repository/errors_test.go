@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSeatCheckError(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             *SeatCheckError
+		wantNotFound    bool
+		wantUnavailable bool
+		wantMessage     string
+	}{
+		{
+			name:         "missing only",
+			err:          &SeatCheckError{Missing: []string{"Z9"}},
+			wantNotFound: true,
+			wantMessage:  "seat numbers do not exist: [Z9]",
+		},
+		{
+			name:            "unavailable only",
+			err:             &SeatCheckError{Unavailable: []string{"A2"}},
+			wantUnavailable: true,
+			wantMessage:     "seats not available: [A2]",
+		},
+		{
+			name:         "missing and unavailable",
+			err:          &SeatCheckError{Missing: []string{"Z9"}, Unavailable: []string{"A2", "A3"}},
+			wantNotFound: true,
+			wantMessage:  "seat numbers do not exist: [Z9]; seats not available: [A2 A3]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, ErrSeatsNotFound); got != tt.wantNotFound {
+				t.Errorf("errors.Is(ErrSeatsNotFound) = %v, want %v", got, tt.wantNotFound)
+			}
+			if got := errors.Is(tt.err, ErrSeatsUnavailable); got != tt.wantUnavailable {
+				t.Errorf("errors.Is(ErrSeatsUnavailable) = %v, want %v", got, tt.wantUnavailable)
+			}
+			if tt.err.Error() != tt.wantMessage {
+				t.Errorf("Error() = %q, want %q", tt.err.Error(), tt.wantMessage)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/arunvm123/eventbooking/event-service/model"
 	"gorm.io/gorm"
 )
@@ -10,22 +12,89 @@ type EventRepository interface {
 	CreateEvent(req model.CreateEventRequest) (*model.Event, error)
 	GetEventByID(id string) (*model.Event, error)
 	UpdateEvent(req model.UpdateEventRequest) (*model.Event, error)
+	// PatchEvent updates only the given columns, which must be patchable
+	PatchEvent(id string, fields map[string]interface{}) (*model.Event, error)
 	DeleteEvent(id string) error
 	ListEvents(filter model.EventFilter) ([]model.Event, int, error)
+	// GetUpcomingEventsByIDs returns the given events that haven't happened
+	// yet, in no particular order
+	GetUpcomingEventsByIDs(ids []string) ([]model.Event, error)
 
 	// Seat operations
 	GetAvailableSeats(eventID string) ([]string, error)
 	GetAvailableSeatCount(eventID string) (int, error)
+	// GetPrimaryAvailableSeats and GetPrimaryAvailableSeatCount read from the
+	// primary, for refilling caches right after a write the replica may not
+	// have applied yet
+	GetPrimaryAvailableSeats(eventID string) ([]string, error)
+	GetPrimaryAvailableSeatCount(eventID string) (int, error)
 	GetAvailableSeatNumbers(eventID string) ([]string, error)
-	CheckSeatsAvailability(eventID string, seatNumbers []string) error
-	CheckSeatsExist(eventID string, seatNumbers []string) error
+	// CheckSeats verifies in one query that the event has every requested seat
+	// and that each is free, returning a *repository.SeatCheckError listing the
+	// missing and the unavailable seats otherwise
+	CheckSeats(eventID string, seatNumbers []string) error
+	// GenerateSeats populates seats for an event created with GenerateSeatsAsync
+	// and marks its seat status ready, or failed on error
+	GenerateSeats(eventID string, totalSeats int, labels []string) error
+	// GetCustomSeatLabels returns an event's seat labels when they were supplied
+	// by the organizer, or nil when its seats follow the generated layout
+	GetCustomSeatLabels(eventID string, totalSeats int) ([]string, error)
+	// RegenerateMissingSeats creates the generated seats an event is missing
+	// without touching existing ones and returns how many were added. Events
+	// still generating seats are refused until generation has made no progress
+	// for staleAfter.
+	RegenerateMissingSeats(eventID string, staleAfter time.Duration) (int, error)
+	// UpdateSeatStatuses sets seats available or blocked, refusing held seats
+	// and booked ones unless forced, and returns how many changed
+	UpdateSeatStatuses(req model.UpdateSeatStatusRequest) (int, error)
+	// GetSeatHistory returns an event's seat status transitions, oldest first,
+	// with the total matching the filter
+	GetSeatHistory(filter model.SeatHistoryFilter) ([]model.SeatStatusEvent, int, error)
+	// SetPresale flags the given seats presale-only until req.EndsAt and
+	// replaces the event's presale codes, clearing any earlier presale seats
+	SetPresale(req model.SetPresaleRequest) error
+	// ClearPresale opens an event's presale seats to everyone and drops its codes
+	ClearPresale(eventID string) error
 
 	// Hold operations
+	// CreateHold holds free seats. Presale seats need req.PresaleCode to match
+	// one of the event's codes until its presale ends.
 	CreateHold(req model.CreateHoldRequest) (*model.Hold, error)
+	// ReserveSeats creates a confirmed hold with its seats booked in one step,
+	// validating availability like CreateHold but ignoring any presale
+	ReserveSeats(req model.CreateHoldRequest) (*model.Hold, error)
+	// CreateHoldGroup places a hold per request under one hold group, in a
+	// single transaction: either every event's seats are held or none are. A
+	// failure is reported as a *repository.HoldGroupError naming the event.
+	CreateHoldGroup(group model.HoldGroup, reqs []model.CreateHoldRequest) ([]model.Hold, error)
 	GetHoldByID(id string) (*model.Hold, error)
+	// GetGroupHolds returns the holds of a hold group, or "hold not found"
+	GetGroupHolds(groupID string) ([]model.Hold, error)
+	// ListHoldsByEvent returns an event's holds, newest first, with the total.
+	// onlyActive excludes holds that are no longer active or already past expiry.
+	ListHoldsByEvent(eventID string, onlyActive bool, limit, offset int) ([]model.Hold, int, error)
+	// ReleaseHold releases a hold, or every hold of a hold group given its ID
 	ReleaseHold(id string) error
-	ConfirmHold(id string) error
-	CleanupExpiredHolds() error
+	// ReleaseUserHolds releases all of a user's active holds for an event and
+	// returns how many were released
+	ReleaseUserHolds(eventID, userID string) (int, error)
+	// ConfirmHold books the held seats. Confirming an already confirmed hold is a
+	// no-op success; expired holds return "hold expired" unless they expired
+	// within grace and their seats are still free. A hold group ID confirms all
+	// of the group's holds, or none if any of them can't be confirmed.
+	ConfirmHold(id string, grace time.Duration) error
+	// CleanupExpiredHolds expires a batch of up to limit holds past their expiry,
+	// releases their seats and returns the holds processed
+	CleanupExpiredHolds(limit int) ([]model.Hold, error)
+	// ExpireHold expires one hold past its expiry and releases its seats. It
+	// returns nil if the hold was already settled or hasn't expired yet.
+	ExpireHold(id string) (*model.Hold, error)
+	// AnonymizeUserHolds erases a deleted user's email from their holds and
+	// returns how many holds were changed
+	AnonymizeUserHolds(userID string) (int, error)
+	// ClaimExpiringHolds marks and returns active holds expiring within the window
+	// whose holder has not been warned yet
+	ClaimExpiringHolds(within time.Duration, limit int) ([]model.Hold, error)
 
 	// Database access for health checks
 	GetDB() *gorm.DB
@@ -4,29 +4,105 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/arunvm123/eventbooking/shared/startup"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const (
+	// seatsPerRow is the number of generated seats in each lettered row
+	seatsPerRow = 500
+
+	// seatGenerationBatchSize is the insert batch size for background seat generation
+	seatGenerationBatchSize = 1000
+
+	// seatInsertBatchSize is the insert batch size for seats created with their event
+	seatInsertBatchSize = 100
+
+	// A seat batch failing with a transient error is tried this many times in
+	// total, waiting a little longer before each retry
+	seatInsertAttempts   = 3
+	seatInsertRetryDelay = 50 * time.Millisecond
+)
+
+// transientSQLStates are Postgres errors that may not recur on retry:
+// serialization failures, deadlocks and lock timeouts
+var transientSQLStates = map[string]bool{
+	"40001": true,
+	"40P01": true,
+	"55P03": true,
+}
+
+// utcNow is gorm's clock for CreatedAt and UpdatedAt, keeping stored times in UTC
+func utcNow() time.Time {
+	return time.Now().UTC()
+}
+
+// open connects to the database at url, which gorm checks with a ping
+func open(retry startup.Retry, name, url string) (*gorm.DB, error) {
+	var db *gorm.DB
+	err := retry.Connect(name, func() (err error) {
+		db, err = gorm.Open(postgres.Open(url), &gorm.Config{NowFunc: utcNow})
+		return err
+	})
+	return db, err
+}
+
 type PostgresEventRepository struct {
 	db *gorm.DB
+
+	// readDB serves read-only queries. It points at the read replica when one
+	// is configured and at the primary otherwise.
+	readDB *gorm.DB
+
+	// holdDurations records how long holds last, by outcome
+	holdDurations *prometheus.HistogramVec
+
+	// seatQueryDurations times the seat availability queries; ones slower
+	// than slowQueryThreshold are also logged
+	seatQueryDurations *prometheus.HistogramVec
+	slowQueryThreshold time.Duration
 }
 
-func NewEventRepository(databaseURL string) (*PostgresEventRepository, error) {
-	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
+// NewEventRepository connects to the primary database and, when replicaURL is
+// non-empty, to a read replica used for read-only queries, retrying either
+// connection within the retry budget. Seat availability queries slower than
+// slowQueryThreshold are logged.
+func NewEventRepository(databaseURL, replicaURL string, slowQueryThreshold time.Duration, retry startup.Retry) (*PostgresEventRepository, error) {
+	db, err := open(retry, "postgres", databaseURL)
 	if err != nil {
 		return nil, err
 	}
 
+	readDB := db
+	if replicaURL != "" {
+		readDB, err = open(retry, "postgres read replica", replicaURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to read replica: %w", err)
+		}
+		log.Println("Read replica connected, read-only queries will use the replica")
+	}
+
 	// Auto-migrate all models
-	if err := db.AutoMigrate(&model.Event{}, &model.Seat{}, &model.Hold{}); err != nil {
+	if err := db.AutoMigrate(&model.Event{}, &model.Seat{}, &model.Hold{}, &model.HoldGroup{}, &model.SeatStatusEvent{}, &model.PresaleCode{}); err != nil {
 		return nil, err
 	}
 
+	// Move legacy float prices to integer minor units
+	if err := migrateLegacyPrices(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate legacy event prices: %w", err)
+	}
+
 	// Create performance indexes for high-load scenarios
 	if err := createPerformanceIndexes(db); err != nil {
 		log.Printf("Warning: Failed to create some performance indexes: %v", err)
@@ -35,53 +111,129 @@ func NewEventRepository(databaseURL string) (*PostgresEventRepository, error) {
 
 	log.Println("Database connected and Event tables migrated successfully")
 
-	return &PostgresEventRepository{db: db}, nil
+	return &PostgresEventRepository{
+		db:                 db,
+		readDB:             readDB,
+		holdDurations:      newHoldDurationHistogram(),
+		seatQueryDurations: newSeatQueryHistogram(),
+		slowQueryThreshold: slowQueryThreshold,
+	}, nil
 }
 
-// Event operations
-func (r *PostgresEventRepository) CreateEvent(req model.CreateEventRequest) (*model.Event, error) {
+// WithTransaction runs fn in a transaction, committing if it returns nil and
+// rolling back if it returns an error or panics. A panic is rolled back and
+// returned as an error instead of leaving the caller with a half-done result.
+func (r *PostgresEventRepository) WithTransaction(fn func(tx *gorm.DB) error) (err error) {
 	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
 	defer func() {
-		if r := recover(); r != nil {
+		if p := recover(); p != nil {
 			tx.Rollback()
+			err = fmt.Errorf("transaction panicked: %v", p)
 		}
 	}()
 
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// Event operations
+func (r *PostgresEventRepository) CreateEvent(req model.CreateEventRequest) (*model.Event, error) {
 	// Create event
 	event := model.Event{
-		ID:           req.ID,
-		Name:         req.Name,
-		Description:  req.Description,
-		Venue:        req.Venue,
-		City:         req.City,
-		Category:     req.Category,
-		EventDate:    req.EventDate,
-		TotalSeats:   req.TotalSeats,
-		PricePerSeat: req.PricePerSeat,
-		CreatedBy:    req.CreatedBy,
-	}
-
-	if err := tx.Create(&event).Error; err != nil {
-		tx.Rollback()
-		return nil, err
+		ID:                req.ID,
+		Name:              req.Name,
+		Description:       req.Description,
+		Venue:             req.Venue,
+		City:              req.City,
+		Category:          req.Category,
+		EventDate:         req.EventDate,
+		TotalSeats:        req.TotalSeats,
+		PricePerSeatCents: req.PricePerSeatCents,
+		Currency:          req.Currency,
+		ImageURL:          req.ImageURL,
+		BannerURL:         req.BannerURL,
+		SeatStatus:        model.SeatStatusReady,
+		CreatedBy:         req.CreatedBy,
+	}
+	if req.GenerateSeatsAsync {
+		event.SeatStatus = model.SeatStatusGenerating
 	}
 
-	// Generate seats (A1, A2, ... B1, B2, ...)
-	seats := r.generateSeats(event.ID, req.TotalSeats)
-	if err := tx.CreateInBatches(seats, 100).Error; err != nil {
-		tx.Rollback()
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+
+		// Seats for large events are populated later by GenerateSeats
+		if req.GenerateSeatsAsync {
+			return nil
+		}
+
+		// Use the organizer's seat labels when provided, otherwise generate (A1, A2, ... B1, B2, ...)
+		var seats []model.Seat
+		if len(req.SeatLabels) > 0 {
+			seats = r.buildSeats(event.ID, req.SeatLabels)
+		} else {
+			seats = r.generateSeats(event.ID, req.TotalSeats)
+		}
+		return insertSeatBatches(tx, seats)
+	})
+	if err != nil {
 		return nil, err
 	}
-
-	tx.Commit()
 	return &event, nil
 }
 
+// insertSeatBatches inserts seats in batches within tx. A failed statement
+// aborts a Postgres transaction, so each batch runs behind a savepoint that a
+// transient failure rolls back to before retrying. Any other failure, or one
+// that persists, is returned and aborts the whole transaction.
+func insertSeatBatches(tx *gorm.DB, seats []model.Seat) error {
+	for start := 0; start < len(seats); start += seatInsertBatchSize {
+		batch := seats[start:min(start+seatInsertBatchSize, len(seats))]
+
+		for attempt := 1; ; attempt++ {
+			if err := tx.SavePoint("seat_batch").Error; err != nil {
+				return fmt.Errorf("failed to create seats: %w", err)
+			}
+			err := tx.Create(&batch).Error
+			if err == nil {
+				break
+			}
+			if !isTransientError(err) {
+				return fmt.Errorf("failed to create seats: %w", err)
+			}
+			if attempt == seatInsertAttempts {
+				return fmt.Errorf("%w: gave up after %d attempts: %v", repository.ErrSeatCreationFailed, attempt, err)
+			}
+			if err := tx.RollbackTo("seat_batch").Error; err != nil {
+				return fmt.Errorf("failed to create seats: %w", err)
+			}
+
+			log.Printf("Seat batch insert failed, retrying (attempt %d/%d): %v", attempt, seatInsertAttempts, err)
+			time.Sleep(time.Duration(attempt) * seatInsertRetryDelay)
+		}
+	}
+	return nil
+}
+
+// isTransientError reports whether err is a Postgres error worth retrying
+func isTransientError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && transientSQLStates[pgErr.Code]
+}
+
 func (r *PostgresEventRepository) GetEventByID(eventID string) (*model.Event, error) {
 	var event model.Event
-	if err := r.db.Where("id = ?", eventID).First(&event).Error; err != nil {
+	if err := r.readDB.Where("id = ?", eventID).First(&event).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("event not found")
+			return nil, repository.ErrEventNotFound
 		}
 		return nil, err
 	}
@@ -92,17 +244,17 @@ func (r *PostgresEventRepository) ListEvents(filter model.EventFilter) ([]model.
 	var events []model.Event
 	var total int64
 
-	query := r.db.Model(&model.Event{})
+	query := r.readDB.Model(&model.Event{})
 
 	// Apply filters
 	if filter.City != "" {
-		query = query.Where("city ILIKE ?", "%"+filter.City+"%")
+		query = query.Where("city ILIKE ?", containsPattern(filter.City))
 	}
 	if filter.Category != "" {
 		query = query.Where("category = ?", filter.Category)
 	}
 	if filter.Name != "" {
-		query = query.Where("name ILIKE ?", "%"+filter.Name+"%")
+		query = query.Where("name ILIKE ?", containsPattern(filter.Name))
 	}
 	if filter.DateFrom != nil {
 		query = query.Where("event_date >= ?", *filter.DateFrom)
@@ -110,6 +262,12 @@ func (r *PostgresEventRepository) ListEvents(filter model.EventFilter) ([]model.
 	if filter.DateTo != nil {
 		query = query.Where("event_date <= ?", *filter.DateTo)
 	}
+	if filter.PriceMin != nil {
+		query = query.Where("price_per_seat_cents >= ?", *filter.PriceMin)
+	}
+	if filter.PriceMax != nil {
+		query = query.Where("price_per_seat_cents <= ?", *filter.PriceMax)
+	}
 
 	// Get total count
 	if err := query.Count(&total).Error; err != nil {
@@ -117,18 +275,39 @@ func (r *PostgresEventRepository) ListEvents(filter model.EventFilter) ([]model.
 	}
 
 	// Apply pagination and get results
-	if err := query.Offset(filter.Offset).Limit(filter.Limit).Order("event_date ASC").Find(&events).Error; err != nil {
+	order, ok := eventOrders[filter.Sort]
+	if !ok {
+		order = eventOrders[model.EventSortDate]
+	}
+	if err := query.Offset(filter.Offset).Limit(filter.Limit).Order(order).Find(&events).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return events, int(total), nil
 }
 
+// eventOrders maps event list sorts to their ORDER BY, ending on the ID as a
+// tiebreaker so pages are stable
+var eventOrders = map[string]string{
+	model.EventSortDate:      "event_date ASC, id",
+	model.EventSortName:      "name ASC, id",
+	model.EventSortCreatedAt: "created_at DESC, id",
+}
+
+// likeEscaper escapes LIKE metacharacters using Postgres' default backslash escape
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern builds an ILIKE pattern matching value literally anywhere in
+// the column, so a search for "100%" doesn't act as a wildcard
+func containsPattern(value string) string {
+	return "%" + likeEscaper.Replace(value) + "%"
+}
+
 func (r *PostgresEventRepository) UpdateEvent(req model.UpdateEventRequest) (*model.Event, error) {
 	var event model.Event
 	if err := r.db.Where("id = ?", req.ID).First(&event).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("event not found")
+			return nil, repository.ErrEventNotFound
 		}
 		return nil, err
 	}
@@ -141,7 +320,10 @@ func (r *PostgresEventRepository) UpdateEvent(req model.UpdateEventRequest) (*mo
 	event.Category = req.Category
 	event.EventDate = req.EventDate
 	event.TotalSeats = req.TotalSeats
-	event.PricePerSeat = req.PricePerSeat
+	event.PricePerSeatCents = req.PricePerSeatCents
+	event.Currency = req.Currency
+	event.ImageURL = req.ImageURL
+	event.BannerURL = req.BannerURL
 
 	if err := r.db.Save(&event).Error; err != nil {
 		return nil, err
@@ -150,19 +332,120 @@ func (r *PostgresEventRepository) UpdateEvent(req model.UpdateEventRequest) (*mo
 	return &event, nil
 }
 
-func (r *PostgresEventRepository) DeleteEvent(eventID string) error {
-	result := r.db.Delete(&model.Event{}, eventID)
+// patchableEventColumns are the event columns PatchEvent may write. Seat counts
+// and ownership are deliberately absent.
+var patchableEventColumns = map[string]bool{
+	"name":                 true,
+	"description":          true,
+	"venue":                true,
+	"city":                 true,
+	"category":             true,
+	"event_date":           true,
+	"price_per_seat_cents": true,
+	"currency":             true,
+	"image_url":            true,
+	"banner_url":           true,
+}
+
+// PatchEvent writes only the given columns in a single UPDATE, so concurrent
+// changes to other columns aren't overwritten
+func (r *PostgresEventRepository) PatchEvent(eventID string, fields map[string]interface{}) (*model.Event, error) {
+	for column := range fields {
+		if !patchableEventColumns[column] {
+			return nil, fmt.Errorf("event field %s cannot be patched", column)
+		}
+	}
+
+	result := r.db.Model(&model.Event{}).Where("id = ?", eventID).Updates(fields)
 	if result.Error != nil {
-		return result.Error
+		return nil, result.Error
 	}
 	if result.RowsAffected == 0 {
-		return errors.New("event not found")
+		return nil, repository.ErrEventNotFound
 	}
-	return nil
+
+	return r.GetEventByID(eventID)
 }
 
+func (r *PostgresEventRepository) DeleteEvent(eventID string) error {
+	return r.WithTransaction(func(tx *gorm.DB) error {
+		// Events with live holds or confirmed bookings must be cancelled, not deleted
+		var activeHolds int64
+		if err := tx.Model(&model.Hold{}).
+			Where("event_id = ? AND status IN ('active', 'confirmed')", eventID).
+			Count(&activeHolds).Error; err != nil {
+			return err
+		}
+		if activeHolds > 0 {
+			return repository.ErrEventHasBookings
+		}
+
+		if err := tx.Where("event_id = ?", eventID).Delete(&model.Seat{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", eventID).Delete(&model.Hold{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", eventID).Delete(&model.SeatStatusEvent{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", eventID).Delete(&model.PresaleCode{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", eventID).Delete(&model.Event{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrEventNotFound
+		}
+		return nil
+	})
+}
+
+func (r *PostgresEventRepository) GetUpcomingEventsByIDs(ids []string) ([]model.Event, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var events []model.Event
+	if err := r.readDB.Where("id IN ? AND event_date > NOW()", ids).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// seatOrder sorts seat numbers the way they appear in a venue: by row letters
+// (shorter rows first, so Z precedes AA) and then numerically by seat, so A2
+// comes before A10. The full seat number breaks ties for custom labels, whose
+// digit runs are capped so they always fit in a bigint.
+const seatOrder = `
+		length(substring(seat_number from '^[^0-9]*')),
+		substring(seat_number from '^[^0-9]*'),
+		substring(seat_number from '[0-9]{1,18}')::bigint NULLS FIRST,
+		seat_number`
+
 // Seat operations
 func (r *PostgresEventRepository) GetAvailableSeats(eventID string) ([]string, error) {
+	return r.availableSeats(r.readDB, eventID)
+}
+
+func (r *PostgresEventRepository) GetAvailableSeatCount(eventID string) (int, error) {
+	return r.availableSeatCount(r.readDB, eventID)
+}
+
+func (r *PostgresEventRepository) GetPrimaryAvailableSeats(eventID string) ([]string, error) {
+	return r.availableSeats(r.db, eventID)
+}
+
+func (r *PostgresEventRepository) GetPrimaryAvailableSeatCount(eventID string) (int, error) {
+	return r.availableSeatCount(r.db, eventID)
+}
+
+func (r *PostgresEventRepository) availableSeats(db *gorm.DB, eventID string) ([]string, error) {
+	defer r.observeSeatQuery(seatQueryAvailableSeats, eventID, time.Now())
+
 	var seats []string
 	query := `
 		SELECT seat_number FROM seats s
@@ -170,15 +453,17 @@ func (r *PostgresEventRepository) GetAvailableSeats(eventID string) ([]string, e
 		WHERE s.event_id = ? 
 		AND (s.status = 'available' 
 			 OR (s.status = 'held' AND h.expires_at < NOW()))
-		ORDER BY seat_number
+		ORDER BY ` + seatOrder + `
 	`
-	if err := r.db.Raw(query, eventID).Scan(&seats).Error; err != nil {
+	if err := db.Raw(query, eventID).Scan(&seats).Error; err != nil {
 		return nil, err
 	}
 	return seats, nil
 }
 
-func (r *PostgresEventRepository) GetAvailableSeatCount(eventID string) (int, error) {
+func (r *PostgresEventRepository) availableSeatCount(db *gorm.DB, eventID string) (int, error) {
+	defer r.observeSeatQuery(seatQueryAvailableCount, eventID, time.Now())
+
 	var count int64
 	query := `
 		SELECT COUNT(*) FROM seats s
@@ -187,7 +472,7 @@ func (r *PostgresEventRepository) GetAvailableSeatCount(eventID string) (int, er
 		AND (s.status = 'available' 
 			 OR (s.status = 'held' AND h.expires_at < NOW()))
 	`
-	if err := r.db.Raw(query, eventID).Count(&count).Error; err != nil {
+	if err := db.Raw(query, eventID).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return int(count), nil
@@ -197,233 +482,748 @@ func (r *PostgresEventRepository) GetAvailableSeatNumbers(eventID string) ([]str
 	return r.GetAvailableSeats(eventID)
 }
 
-func (r *PostgresEventRepository) CheckSeatsAvailability(eventID string, seatNumbers []string) error {
-	var unavailableSeats []string
-	query := `
-		SELECT seat_number FROM seats s
-		LEFT JOIN holds h ON s.hold_id = h.id
-		WHERE s.event_id = ? AND s.seat_number = ANY(?)
-		AND s.status != 'available' 
-		AND NOT (s.status = 'held' AND h.expires_at < NOW())
-	`
-	if err := r.db.Raw(query, eventID, pq.Array(seatNumbers)).Scan(&unavailableSeats).Error; err != nil {
-		return err
-	}
-
-	if len(unavailableSeats) > 0 {
-		return fmt.Errorf("seats not available")
-	}
+func (r *PostgresEventRepository) CheckSeats(eventID string, seatNumbers []string) error {
+	return r.checkSeats(r.db, eventID, seatNumbers)
+}
 
-	return nil
+// seatCheck is a requested seat's row in the seat check: whether the event has
+// it and its status, with holds past their expiry counting as available
+type seatCheck struct {
+	SeatNumber string
+	Found      bool
+	Status     string
 }
 
-// CheckSeatsExist validates that all requested seat numbers exist for the given event
-func (r *PostgresEventRepository) CheckSeatsExist(eventID string, seatNumbers []string) error {
-	var existingSeats []string
+// checkSeats looks every requested seat up in a single query, reporting the
+// missing and the unavailable ones together
+func (r *PostgresEventRepository) checkSeats(db *gorm.DB, eventID string, seatNumbers []string) error {
+	defer r.observeSeatQuery(seatQueryCheckAvailability, eventID, time.Now())
+
+	var checks []seatCheck
 	query := `
-		SELECT seat_number FROM seats 
-		WHERE event_id = ? AND seat_number = ANY(?)
+		SELECT requested.seat_number,
+			s.id IS NOT NULL AS found,
+			CASE WHEN s.status = 'held' AND h.expires_at < NOW() THEN 'available'
+				ELSE COALESCE(s.status, '') END AS status
+		FROM unnest(?::text[]) AS requested(seat_number)
+		LEFT JOIN seats s ON s.event_id = ? AND s.seat_number = requested.seat_number
+		LEFT JOIN holds h ON s.hold_id = h.id
 	`
-	if err := r.db.Raw(query, eventID, pq.Array(seatNumbers)).Scan(&existingSeats).Error; err != nil {
-		return fmt.Errorf("failed to check seat existence: %w", err)
+	if err := db.Raw(query, pq.Array(seatNumbers), eventID).Scan(&checks).Error; err != nil {
+		return fmt.Errorf("failed to check seats: %w", err)
 	}
 
-	// Check if all requested seats exist
-	if len(existingSeats) != len(seatNumbers) {
-		// Find which seats don't exist
-		existingSet := make(map[string]bool)
-		for _, seat := range existingSeats {
-			existingSet[seat] = true
+	var checkErr repository.SeatCheckError
+	for _, check := range checks {
+		switch {
+		case !check.Found:
+			checkErr.Missing = append(checkErr.Missing, check.SeatNumber)
+		case check.Status != "available":
+			checkErr.Unavailable = append(checkErr.Unavailable, check.SeatNumber)
 		}
-
-		var nonExistentSeats []string
-		for _, seat := range seatNumbers {
-			if !existingSet[seat] {
-				nonExistentSeats = append(nonExistentSeats, seat)
-			}
-		}
-
-		return fmt.Errorf("seat numbers do not exist: %v", nonExistentSeats)
 	}
-
+	if len(checkErr.Missing) > 0 || len(checkErr.Unavailable) > 0 {
+		return &checkErr
+	}
 	return nil
 }
 
 // Hold operations
 func (r *PostgresEventRepository) CreateHold(req model.CreateHoldRequest) (*model.Hold, error) {
-	tx := r.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
+	return r.placeHold(req, true, "active", "held", model.SeatTransitionHoldCreated)
+}
+
+// ReserveSeats creates an already confirmed hold and books its seats in one
+// transaction, for trusted reservations that skip payment
+func (r *PostgresEventRepository) ReserveSeats(req model.CreateHoldRequest) (*model.Hold, error) {
+	return r.placeHold(req, false, "confirmed", "booked", model.SeatTransitionReserved)
+}
 
-	// First check if seats exist
-	err := r.CheckSeatsExist(req.EventID, req.SeatNumbers)
+// placeHold checks the requested seats are free, and with enforcePresale that
+// the buyer may hold them, then creates a hold with holdStatus and moves its
+// seats to seatStatus
+func (r *PostgresEventRepository) placeHold(req model.CreateHoldRequest, enforcePresale bool, holdStatus, seatStatus, reason string) (*model.Hold, error) {
+	hold := newHold(req, holdStatus)
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		return r.placeHoldTx(tx, &hold, req, enforcePresale, seatStatus, reason)
+	})
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
+	return &hold, nil
+}
 
-	// Then check seat availability
-	err = r.CheckSeatsAvailability(req.EventID, req.SeatNumbers)
+// CreateHoldGroup places every hold of a group in one transaction, so a
+// failure on any event rolls back the holds already placed on the others
+func (r *PostgresEventRepository) CreateHoldGroup(group model.HoldGroup, reqs []model.CreateHoldRequest) ([]model.Hold, error) {
+	holds := make([]model.Hold, len(reqs))
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&group).Error; err != nil {
+			return err
+		}
+		for i, req := range reqs {
+			holds[i] = newHold(req, "active")
+			holds[i].GroupID = &group.ID
+			if err := r.placeHoldTx(tx, &holds[i], req, true, "held", model.SeatTransitionHoldCreated); err != nil {
+				return &repository.HoldGroupError{EventID: req.EventID, Err: err}
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
+	return holds, nil
+}
 
-	// Create hold
-	hold := model.Hold{
+// newHold builds the hold entity for a request
+func newHold(req model.CreateHoldRequest, status string) model.Hold {
+	return model.Hold{
 		ID:          req.ID,
 		UserID:      req.UserID,
+		UserEmail:   req.UserEmail,
+		UserLocale:  req.UserLocale,
+		Guest:       req.Guest,
 		EventID:     req.EventID,
 		SeatNumbers: req.SeatNumbers,
 		ExpiresAt:   req.ExpiresAt,
-		Status:      "active",
+		Status:      status,
 	}
+}
 
-	if err := tx.Create(&hold).Error; err != nil {
-		tx.Rollback()
-		return nil, err
+// placeHoldTx creates hold within tx once its seats are found free
+func (r *PostgresEventRepository) placeHoldTx(tx *gorm.DB, hold *model.Hold, req model.CreateHoldRequest, enforcePresale bool, seatStatus, reason string) error {
+	// Seats of large events can't be held until background generation completes
+	var event model.Event
+	if err := tx.Select("seat_status", "presale_ends_at").Where("id = ?", req.EventID).First(&event).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repository.ErrEventNotFound
+		}
+		return err
+	}
+	if event.SeatStatus != model.SeatStatusReady {
+		return repository.ErrSeatsNotReady
+	}
+
+	if enforcePresale && event.PresaleActive(time.Now()) {
+		if err := checkPresaleCode(tx, req); err != nil {
+			return err
+		}
+	}
+
+	// Check the seats exist and are free in one round trip
+	if err := r.checkSeats(tx, req.EventID, req.SeatNumbers); err != nil {
+		return err
+	}
+
+	// Create hold
+	if err := tx.Create(hold).Error; err != nil {
+		return err
 	}
 
 	// Update seat status
+	return transitionSeats(tx, map[string]interface{}{
+		"status":  seatStatus,
+		"hold_id": hold.ID,
+	}, reason, "event_id = ? AND seat_number IN (?)", req.EventID, []string(req.SeatNumbers))
+}
+
+// checkPresaleCode requires a valid presale code when any of the requested
+// seats are presale seats. Errors list the presale seats requested.
+func checkPresaleCode(tx *gorm.DB, req model.CreateHoldRequest) error {
+	var presaleSeats []string
 	if err := tx.Model(&model.Seat{}).
-		Where("event_id = ? AND seat_number IN (?)", req.EventID, req.SeatNumbers).
-		Updates(map[string]interface{}{
-			"status":  "held",
-			"hold_id": hold.ID,
-		}).Error; err != nil {
-		tx.Rollback()
-		return nil, err
+		Where("event_id = ? AND seat_number IN ? AND presale", req.EventID, req.SeatNumbers).
+		Pluck("seat_number", &presaleSeats).Error; err != nil {
+		return err
+	}
+	if len(presaleSeats) == 0 {
+		return nil
+	}
+	if req.PresaleCode == "" {
+		return fmt.Errorf("%w: %v", repository.ErrPresaleCodeRequired, presaleSeats)
 	}
 
-	tx.Commit()
-	return &hold, nil
+	var matches int64
+	if err := tx.Model(&model.PresaleCode{}).
+		Where("event_id = ? AND code = ?", req.EventID, req.PresaleCode).
+		Count(&matches).Error; err != nil {
+		return err
+	}
+	if matches == 0 {
+		return fmt.Errorf("%w: %v", repository.ErrPresaleCodeInvalid, presaleSeats)
+	}
+	return nil
 }
 
 func (r *PostgresEventRepository) GetHoldByID(holdID string) (*model.Hold, error) {
 	var hold model.Hold
 	if err := r.db.Where("id = ?", holdID).First(&hold).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("hold not found")
+			return nil, repository.ErrHoldNotFound
 		}
 		return nil, err
 	}
 	return &hold, nil
 }
 
+func (r *PostgresEventRepository) GetGroupHolds(groupID string) ([]model.Hold, error) {
+	var holds []model.Hold
+	if err := r.db.Where("group_id = ?", groupID).Order("created_at, id").Find(&holds).Error; err != nil {
+		return nil, err
+	}
+	if len(holds) == 0 {
+		return nil, repository.ErrHoldNotFound
+	}
+	return holds, nil
+}
+
+// lockHolds locks and returns the hold with the given ID or, given a hold
+// group ID, every hold of the group
+func lockHolds(tx *gorm.DB, holdID string) ([]model.Hold, error) {
+	var holds []model.Hold
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? OR group_id = ?", holdID, holdID).
+		Order("id").
+		Find(&holds).Error; err != nil {
+		return nil, err
+	}
+	if len(holds) == 0 {
+		return nil, repository.ErrHoldNotFound
+	}
+	return holds, nil
+}
+
+func (r *PostgresEventRepository) ListHoldsByEvent(eventID string, onlyActive bool, limit, offset int) ([]model.Hold, int, error) {
+	query := r.db.Model(&model.Hold{}).Where("event_id = ?", eventID)
+	if onlyActive {
+		// Expired holds stay active until the cleanup job sweeps them
+		query = query.Where("status = 'active' AND expires_at > NOW()")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Break created_at ties on the ID so holds placed together don't shift
+	// between pages
+	var holds []model.Hold
+	if err := query.Order("created_at DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&holds).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return holds, int(total), nil
+}
+
 func (r *PostgresEventRepository) ReleaseHold(holdID string) error {
-	tx := r.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	var released []model.Hold
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		holds, err := lockHolds(tx, holdID)
+		if err != nil {
+			return err
 		}
-	}()
 
-	// Get hold
-	var hold model.Hold
-	if err := tx.Where("id = ?", holdID).First(&hold).Error; err != nil {
-		tx.Rollback()
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("hold not found")
+		for i := range holds {
+			hold := &holds[i]
+			// Confirmed or expired holds no longer own their seats, which may
+			// have been sold or held again since
+			if hold.Status != "active" {
+				continue
+			}
+			released = append(released, *hold)
+
+			// Update seat status back to available
+			if err := transitionSeats(tx, map[string]interface{}{
+				"status":  "available",
+				"hold_id": nil,
+			}, model.SeatTransitionHoldReleased, "hold_id = ? AND status = 'held'", hold.ID); err != nil {
+				return err
+			}
+
+			// Update hold status
+			if err := tx.Model(hold).Update("status", "expired").Error; err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
-	// Update seat status back to available
-	if err := tx.Model(&model.Seat{}).
-		Where("event_id = ? AND seat_number IN (?)", hold.EventID, hold.SeatNumbers).
-		Updates(map[string]interface{}{
-			"status":  "available",
-			"hold_id": nil,
-		}).Error; err != nil {
-		tx.Rollback()
-		return err
+	for _, hold := range released {
+		r.observeHoldEnd(holdOutcomeReleased, hold.CreatedAt, time.Now())
 	}
+	return nil
+}
 
-	// Update hold status
-	if err := tx.Model(&hold).Update("status", "expired").Error; err != nil {
-		tx.Rollback()
+func (r *PostgresEventRepository) ConfirmHold(holdID string, grace time.Duration) error {
+	var confirmed []model.Hold
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		// Lock the holds so concurrent confirms are serialized
+		holds, err := lockHolds(tx, holdID)
+		if err != nil {
+			return err
+		}
+
+		for i := range holds {
+			changed, err := confirmHold(tx, &holds[i], grace)
+			if err != nil {
+				return err
+			}
+			if changed {
+				confirmed = append(confirmed, holds[i])
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
-	tx.Commit()
+	// Holds revived within the grace window were also counted as expired
+	for _, hold := range confirmed {
+		r.observeHoldEnd(holdOutcomeConfirmed, hold.CreatedAt, time.Now())
+	}
 	return nil
 }
 
-func (r *PostgresEventRepository) ConfirmHold(holdID string) error {
-	tx := r.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+// confirmHold books a locked hold's seats within tx, reporting false for a
+// hold that was already confirmed
+func confirmHold(tx *gorm.DB, hold *model.Hold, grace time.Duration) (bool, error) {
+	// Confirming is idempotent so retries after a partial success are safe;
+	// only active holds transition to confirmed
+	switch hold.Status {
+	case "confirmed":
+		return false, nil
+	case "expired":
+		// Holds released by their owner are also marked expired, but before
+		// their expiry time; only holds swept after expiring can be revived
+		expiredAgo := time.Since(hold.ExpiresAt)
+		if hold.UpdatedAt.Before(hold.ExpiresAt) || expiredAgo > grace {
+			return false, repository.ErrHoldExpired
 		}
-	}()
 
-	// Get hold
-	var hold model.Hold
-	if err := tx.Where("id = ?", holdID).First(&hold).Error; err != nil {
-		tx.Rollback()
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("hold not found")
+		// The seats may have been taken by someone else since they were released
+		var takenSeats []string
+		if err := tx.Model(&model.Seat{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ? AND seat_number IN (?) AND status <> 'available'", hold.EventID, []string(hold.SeatNumbers)).
+			Pluck("seat_number", &takenSeats).Error; err != nil {
+			return false, err
 		}
-		return err
+		if len(takenSeats) > 0 {
+			return false, repository.ErrHoldExpired
+		}
+		log.Printf("Confirming hold %s %s after expiry within the %s grace window", hold.ID, expiredAgo.Round(time.Millisecond), grace)
+	case "active":
+		// The sweep may not have reached a hold that expired long ago yet
+		if time.Since(hold.ExpiresAt) > grace {
+			return false, repository.ErrHoldExpired
+		}
+
+		// Past its expiry the hold's seats count as free, so someone else may
+		// have held them already; only confirm seats this hold still has
+		if time.Now().After(hold.ExpiresAt) {
+			var stillHeld []string
+			if err := tx.Model(&model.Seat{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("event_id = ? AND seat_number IN (?) AND status = 'held' AND hold_id = ?", hold.EventID, []string(hold.SeatNumbers), hold.ID).
+				Pluck("seat_number", &stillHeld).Error; err != nil {
+				return false, err
+			}
+			if len(stillHeld) != len(hold.SeatNumbers) {
+				return false, repository.ErrHoldExpired
+			}
+		}
+	default:
+		return false, fmt.Errorf("hold cannot be confirmed from status %s", hold.Status)
 	}
 
 	// Update seat status to booked
-	if err := tx.Model(&model.Seat{}).
-		Where("event_id = ? AND seat_number IN (?)", hold.EventID, hold.SeatNumbers).
-		Update("status", "booked").Error; err != nil {
-		tx.Rollback()
-		return err
+	if err := transitionSeats(tx, map[string]interface{}{
+		"status":  "booked",
+		"hold_id": hold.ID,
+	}, model.SeatTransitionHoldConfirmed, "event_id = ? AND seat_number IN (?)", hold.EventID, []string(hold.SeatNumbers)); err != nil {
+		return false, err
 	}
 
 	// Update hold status
-	if err := tx.Model(&hold).Update("status", "confirmed").Error; err != nil {
-		tx.Rollback()
+	return true, tx.Model(hold).Update("status", "confirmed").Error
+}
+
+// ReleaseUserHolds releases all of a user's active holds for an event in one
+// transaction and returns how many were released
+func (r *PostgresEventRepository) ReleaseUserHolds(eventID, userID string) (int, error) {
+	var holds []model.Hold
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "created_at").
+			Where("event_id = ? AND user_id = ? AND status = 'active'", eventID, userID).
+			Find(&holds).Error; err != nil {
+			return err
+		}
+		if len(holds) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(holds))
+		for i, hold := range holds {
+			ids[i] = hold.ID
+		}
+
+		// Release only seats still held by these holds
+		if err := transitionSeats(tx, map[string]interface{}{
+			"status":  "available",
+			"hold_id": nil,
+		}, model.SeatTransitionHoldReleased, "hold_id IN ? AND status = 'held'", ids); err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Hold{}).Where("id IN ?", ids).Update("status", "expired").Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, hold := range holds {
+		r.observeHoldEnd(holdOutcomeReleased, hold.CreatedAt, now)
+	}
+	return len(holds), nil
+}
+
+// CleanupExpiredHolds expires up to limit active holds past their expiry and
+// releases their seats in one short transaction. Holds locked by a concurrent
+// confirm or release are skipped and picked up by a later batch.
+func (r *PostgresEventRepository) CleanupExpiredHolds(limit int) ([]model.Hold, error) {
+	var expiredHolds []model.Hold
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Select("id", "event_id", "expires_at", "created_at").
+			Where("expires_at < NOW() AND status = 'active'").
+			Order("expires_at").
+			Limit(limit).
+			Find(&expiredHolds).Error; err != nil {
+			return err
+		}
+		if len(expiredHolds) == 0 {
+			return nil
+		}
+		return expireHolds(tx, expiredHolds)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Holds end at their expiry time, not when the sweep gets to them
+	for _, hold := range expiredHolds {
+		r.observeHoldEnd(holdOutcomeExpired, hold.CreatedAt, hold.ExpiresAt)
+	}
+	return expiredHolds, nil
+}
+
+// ExpireHold expires a single active hold past its expiry and releases its
+// seats. It returns nil if the hold isn't active, hasn't expired yet, or is
+// locked by a concurrent confirm or release, which then settles it instead.
+func (r *PostgresEventRepository) ExpireHold(id string) (*model.Hold, error) {
+	var holds []model.Hold
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Select("id", "event_id", "expires_at", "created_at").
+			Where("id = ? AND expires_at < NOW() AND status = 'active'", id).
+			Find(&holds).Error; err != nil {
+			return err
+		}
+		if len(holds) == 0 {
+			return nil
+		}
+		return expireHolds(tx, holds)
+	})
+	if err != nil || len(holds) == 0 {
+		return nil, err
+	}
+
+	r.observeHoldEnd(holdOutcomeExpired, holds[0].CreatedAt, holds[0].ExpiresAt)
+	return &holds[0], nil
+}
+
+// expireHolds marks locked holds expired and releases the seats they still hold
+func expireHolds(tx *gorm.DB, holds []model.Hold) error {
+	ids := make([]string, len(holds))
+	for i, hold := range holds {
+		ids[i] = hold.ID
+	}
+
+	// Release only seats still held by these holds
+	if err := transitionSeats(tx, map[string]interface{}{
+		"status":  "available",
+		"hold_id": nil,
+	}, model.SeatTransitionHoldExpired, "hold_id IN ? AND status = 'held'", ids); err != nil {
 		return err
 	}
 
-	tx.Commit()
-	return nil
+	return tx.Model(&model.Hold{}).Where("id IN ?", ids).Update("status", "expired").Error
 }
 
-func (r *PostgresEventRepository) CleanupExpiredHolds() error {
-	tx := r.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+// AnonymizeUserHolds erases the email holds keep for expiry warnings, which
+// also stops warnings for the user's active holds. Seats and statuses are kept.
+func (r *PostgresEventRepository) AnonymizeUserHolds(userID string) (int, error) {
+	result := r.db.Model(&model.Hold{}).
+		Where("user_id = ? AND user_email <> ''", userID).
+		Updates(map[string]interface{}{
+			"user_email":  "",
+			"user_locale": "",
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to anonymize holds: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+// ClaimExpiringHolds returns active holds expiring within the given window that
+// have not been warned yet, marking them warning_sent in the same transaction so
+// concurrent instances never pick up the same hold
+func (r *PostgresEventRepository) ClaimExpiringHolds(within time.Duration, limit int) ([]model.Hold, error) {
+	var holds []model.Hold
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = 'active' AND warning_sent = false AND user_email <> ''").
+			Where("expires_at > NOW() AND expires_at <= ?", time.Now().UTC().Add(within)).
+			Order("expires_at").
+			Limit(limit).
+			Find(&holds).Error; err != nil {
+			return err
+		}
+		if len(holds) == 0 {
+			return nil
 		}
-	}()
 
-	// Get expired holds
-	var expiredHolds []model.Hold
-	if err := tx.Where("expires_at < NOW() AND status = 'active'").Find(&expiredHolds).Error; err != nil {
-		tx.Rollback()
+		ids := make([]string, len(holds))
+		for i, hold := range holds {
+			ids[i] = hold.ID
+		}
+		return tx.Model(&model.Hold{}).Where("id IN ?", ids).Update("warning_sent", true).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Load event details for the notification body
+	for i := range holds {
+		if err := r.db.First(&holds[i].Event, "id = ?", holds[i].EventID).Error; err != nil {
+			log.Printf("Failed to load event %s for hold %s: %v", holds[i].EventID, holds[i].ID, err)
+		}
+	}
+	return holds, nil
+}
+
+// transitionSeats applies updates to the seats matching the condition and
+// records every status change in seat_status_events within the same
+// transaction. The seats are locked first so the recorded from-status is exact.
+func transitionSeats(tx *gorm.DB, updates map[string]interface{}, reason string, query string, args ...interface{}) error {
+	var seats []model.Seat
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "event_id", "seat_number", "status", "hold_id").
+		Where(query, args...).
+		Find(&seats).Error; err != nil {
 		return err
 	}
+	if len(seats) == 0 {
+		return nil
+	}
 
-	for _, hold := range expiredHolds {
-		// Release seats
+	if err := tx.Model(&model.Seat{}).Where(query, args...).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	toStatus, _ := updates["status"].(string)
+	var newHoldID *string
+	if holdID, ok := updates["hold_id"].(string); ok {
+		newHoldID = &holdID
+	}
+
+	events := make([]model.SeatStatusEvent, 0, len(seats))
+	for _, seat := range seats {
+		if seat.Status == toStatus {
+			continue
+		}
+		// Record the hold that caused the change: the new one when seats are
+		// held, otherwise the one they belonged to
+		holdID := seat.HoldID
+		if newHoldID != nil {
+			holdID = newHoldID
+		}
+		events = append(events, model.SeatStatusEvent{
+			EventID:    seat.EventID,
+			SeatID:     seat.ID,
+			SeatNumber: seat.SeatNumber,
+			FromStatus: seat.Status,
+			ToStatus:   toStatus,
+			HoldID:     holdID,
+			Reason:     reason,
+		})
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(events, 1000).Error
+}
+
+// UpdateSeatStatuses sets seats available or blocked for manual repairs and
+// records each change in the seat audit log. Held seats belong to an active
+// checkout and are refused; booked seats are refused unless forced.
+func (r *PostgresEventRepository) UpdateSeatStatuses(req model.UpdateSeatStatusRequest) (int, error) {
+	changed := 0
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		var seats []model.Seat
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("seat_number", "status").
+			Where("event_id = ? AND seat_number IN ?", req.EventID, req.SeatNumbers).
+			Find(&seats).Error; err != nil {
+			return err
+		}
+
+		statuses := make(map[string]string, len(seats))
+		for _, seat := range seats {
+			statuses[seat.SeatNumber] = seat.Status
+		}
+
+		var missing, held, booked []string
+		for _, seatNumber := range req.SeatNumbers {
+			switch status, ok := statuses[seatNumber]; {
+			case !ok:
+				missing = append(missing, seatNumber)
+			case status == "held":
+				held = append(held, seatNumber)
+			case status == "booked" && !req.Force:
+				booked = append(booked, seatNumber)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %v", repository.ErrSeatsNotFound, missing)
+		}
+		if len(held) > 0 {
+			return fmt.Errorf("%w: %v", repository.ErrSeatsHeld, held)
+		}
+		if len(booked) > 0 {
+			return fmt.Errorf("%w: %v", repository.ErrSeatsBooked, booked)
+		}
+
+		for _, seat := range seats {
+			if seat.Status != req.Status {
+				changed++
+			}
+		}
+
+		return transitionSeats(tx, map[string]interface{}{
+			"status":  req.Status,
+			"hold_id": nil,
+		}, model.SeatTransitionAdminUpdate, "event_id = ? AND seat_number IN ? AND status <> ?", req.EventID, req.SeatNumbers, req.Status)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return changed, nil
+}
+
+// SetPresale replaces an event's presale seats, codes and end time
+func (r *PostgresEventRepository) SetPresale(req model.SetPresaleRequest) error {
+	return r.WithTransaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Event{}).Where("id = ?", req.EventID).Update("presale_ends_at", req.EndsAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrEventNotFound
+		}
+
+		var existing []string
 		if err := tx.Model(&model.Seat{}).
-			Where("event_id = ? AND seat_number IN (?)", hold.EventID, hold.SeatNumbers).
-			Updates(map[string]interface{}{
-				"status":  "available",
-				"hold_id": nil,
-			}).Error; err != nil {
-			tx.Rollback()
+			Where("event_id = ? AND seat_number IN ?", req.EventID, req.SeatNumbers).
+			Pluck("seat_number", &existing).Error; err != nil {
 			return err
 		}
+		found := make(map[string]bool, len(existing))
+		for _, seatNumber := range existing {
+			found[seatNumber] = true
+		}
+		var missing []string
+		for _, seatNumber := range req.SeatNumbers {
+			if !found[seatNumber] {
+				missing = append(missing, seatNumber)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %v", repository.ErrSeatsNotFound, missing)
+		}
 
-		// Update hold status
-		if err := tx.Model(&hold).Update("status", "expired").Error; err != nil {
-			tx.Rollback()
+		if err := clearPresale(tx, req.EventID); err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Seat{}).
+			Where("event_id = ? AND seat_number IN ?", req.EventID, req.SeatNumbers).
+			Update("presale", true).Error; err != nil {
 			return err
 		}
+
+		codes := make([]model.PresaleCode, len(req.Codes))
+		for i, code := range req.Codes {
+			codes[i] = model.PresaleCode{EventID: req.EventID, Code: code}
+		}
+		return tx.CreateInBatches(codes, 500).Error
+	})
+}
+
+// ClearPresale ends an event's presale early
+func (r *PostgresEventRepository) ClearPresale(eventID string) error {
+	return r.WithTransaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Event{}).Where("id = ?", eventID).Update("presale_ends_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrEventNotFound
+		}
+		return clearPresale(tx, eventID)
+	})
+}
+
+// clearPresale unflags an event's presale seats and deletes its codes
+func clearPresale(tx *gorm.DB, eventID string) error {
+	if err := tx.Model(&model.Seat{}).
+		Where("event_id = ? AND presale", eventID).
+		Update("presale", false).Error; err != nil {
+		return err
 	}
+	return tx.Where("event_id = ?", eventID).Delete(&model.PresaleCode{}).Error
+}
 
-	tx.Commit()
-	return nil
+// GetSeatHistory returns an event's seat status transitions, oldest first
+func (r *PostgresEventRepository) GetSeatHistory(filter model.SeatHistoryFilter) ([]model.SeatStatusEvent, int, error) {
+	query := r.db.Model(&model.SeatStatusEvent{}).Where("event_id = ?", filter.EventID)
+	if filter.SeatNumber != "" {
+		query = query.Where("seat_number = ?", filter.SeatNumber)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []model.SeatStatusEvent
+	if err := query.Order("created_at, id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return events, int(total), nil
 }
 
 func (r *PostgresEventRepository) GetDB() *gorm.DB {
@@ -441,8 +1241,8 @@ func (r *PostgresEventRepository) generateSeats(eventID string, totalSeats int)
 		seatNum := 1
 		rowName := generateRowName(rowIndex)
 
-		// Generate up to 500 seats per row (configurable)
-		for seatNum <= 500 && seatCount < totalSeats {
+		// Generate up to seatsPerRow seats per row
+		for seatNum <= seatsPerRow && seatCount < totalSeats {
 			seatNumber := fmt.Sprintf("%s%d", rowName, seatNum)
 			seats = append(seats, model.Seat{
 				ID:         uuid.New().String(),
@@ -459,6 +1259,171 @@ func (r *PostgresEventRepository) generateSeats(eventID string, totalSeats int)
 	return seats
 }
 
+// GenerateSeats inserts seats in batches without holding them all in memory,
+// then marks the event's seats ready. On failure the event is marked failed.
+func (r *PostgresEventRepository) GenerateSeats(eventID string, totalSeats int, labels []string) error {
+	if len(labels) > 0 {
+		totalSeats = len(labels)
+	}
+
+	batch := make([]model.Seat, 0, seatGenerationBatchSize)
+	for i := 0; i < totalSeats; i++ {
+		seatNumber := seatNumberAt(i)
+		if len(labels) > 0 {
+			seatNumber = labels[i]
+		}
+		batch = append(batch, model.Seat{
+			ID:         uuid.New().String(),
+			EventID:    eventID,
+			SeatNumber: seatNumber,
+			Status:     "available",
+		})
+
+		if len(batch) == seatGenerationBatchSize || i == totalSeats-1 {
+			if err := r.db.Create(&batch).Error; err != nil {
+				r.db.Model(&model.Event{}).Where("id = ?", eventID).Update("seat_status", model.SeatStatusFailed)
+				return fmt.Errorf("failed to generate seats: %w", err)
+			}
+			batch = batch[:0]
+		}
+	}
+
+	return r.db.Model(&model.Event{}).Where("id = ?", eventID).Update("seat_status", model.SeatStatusReady).Error
+}
+
+// RegenerateMissingSeats creates the generated seats an event is missing, e.g.
+// after a seat batch failed to insert, and marks the event's seats ready.
+// Existing seats, including held and booked ones, are left untouched.
+func (r *PostgresEventRepository) RegenerateMissingSeats(eventID string, staleAfter time.Duration) (int, error) {
+	added := 0
+	err := r.WithTransaction(func(tx *gorm.DB) error {
+		// Lock the event so concurrent repairs can't insert the same seats twice
+		var event model.Event
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "total_seats", "seat_status", "updated_at").
+			Where("id = ?", eventID).
+			First(&event).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return repository.ErrEventNotFound
+			}
+			return err
+		}
+		if event.SeatStatus == model.SeatStatusGenerating {
+			// A generator that died, e.g. with its instance, leaves the event
+			// generating for good; once it has stopped adding seats, take over
+			var lastSeatAt *time.Time
+			if err := tx.Model(&model.Seat{}).Where("event_id = ?", eventID).
+				Select("MAX(created_at)").Scan(&lastSeatAt).Error; err != nil {
+				return fmt.Errorf("failed to check seat generation progress: %w", err)
+			}
+			lastProgress := event.UpdatedAt
+			if lastSeatAt != nil && lastSeatAt.After(lastProgress) {
+				lastProgress = *lastSeatAt
+			}
+			if time.Since(lastProgress) < staleAfter {
+				return repository.ErrSeatsGenerating
+			}
+			log.Printf("Seat generation for event %s made no progress since %s, regenerating", eventID, lastProgress.Format(time.RFC3339))
+		}
+
+		var existing []string
+		if err := tx.Model(&model.Seat{}).Where("event_id = ?", eventID).Pluck("seat_number", &existing).Error; err != nil {
+			return fmt.Errorf("failed to load seats: %w", err)
+		}
+		existingSet := make(map[string]bool, len(existing))
+		for _, seatNumber := range existing {
+			existingSet[seatNumber] = true
+		}
+
+		var seats []model.Seat
+		matched := 0
+		for i := 0; i < event.TotalSeats; i++ {
+			seatNumber := seatNumberAt(i)
+			if existingSet[seatNumber] {
+				matched++
+				continue
+			}
+			seats = append(seats, model.Seat{
+				ID:         uuid.New().String(),
+				EventID:    eventID,
+				SeatNumber: seatNumber,
+				Status:     "available",
+			})
+		}
+
+		// Custom labels aren't stored, so seats outside the generated layout
+		// mean the missing ones can't be named
+		if matched != len(existingSet) {
+			return repository.ErrCustomSeatLabels
+		}
+
+		if len(seats) > 0 {
+			if err := tx.CreateInBatches(seats, seatGenerationBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to create seats: %w", err)
+			}
+		}
+		if event.SeatStatus != model.SeatStatusReady {
+			if err := tx.Model(&model.Event{}).Where("id = ?", eventID).Update("seat_status", model.SeatStatusReady).Error; err != nil {
+				return err
+			}
+		}
+
+		added = len(seats)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// GetCustomSeatLabels reads the event's seat numbers from the primary, since
+// custom labels are only recorded on the seats themselves
+func (r *PostgresEventRepository) GetCustomSeatLabels(eventID string, totalSeats int) ([]string, error) {
+	var labels []string
+	if err := r.db.Model(&model.Seat{}).
+		Where("event_id = ?", eventID).
+		Order("seat_number").
+		Pluck("seat_number", &labels).Error; err != nil {
+		return nil, fmt.Errorf("failed to load seats: %w", err)
+	}
+
+	if len(labels) == totalSeats {
+		existing := make(map[string]bool, len(labels))
+		for _, label := range labels {
+			existing[label] = true
+		}
+		generated := true
+		for i := 0; i < totalSeats && generated; i++ {
+			generated = existing[seatNumberAt(i)]
+		}
+		if generated {
+			return nil, nil
+		}
+	}
+	return labels, nil
+}
+
+// seatNumberAt returns the generated seat number at a zero-based index,
+// matching the row layout used by generateSeats
+func seatNumberAt(index int) string {
+	return fmt.Sprintf("%s%d", generateRowName(index/seatsPerRow), index%seatsPerRow+1)
+}
+
+// buildSeats creates seats using explicit labels supplied by the organizer
+func (r *PostgresEventRepository) buildSeats(eventID string, labels []string) []model.Seat {
+	seats := make([]model.Seat, 0, len(labels))
+	for _, label := range labels {
+		seats = append(seats, model.Seat{
+			ID:         uuid.New().String(),
+			EventID:    eventID,
+			SeatNumber: label,
+			Status:     "available",
+		})
+	}
+	return seats
+}
+
 // generateRowName converts row index to Excel-style column names
 // 0 -> A, 1 -> B, ..., 25 -> Z, 26 -> AA, 27 -> AB, etc.
 func generateRowName(index int) string {
@@ -476,9 +1441,25 @@ func generateRowName(index int) string {
 	return result
 }
 
+// migrateLegacyPrices backfills price_per_seat_cents from the legacy float
+// price_per_seat column and then drops it
+func migrateLegacyPrices(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&model.Event{}, "price_per_seat") {
+		return nil
+	}
+
+	log.Println("Migrating legacy event prices to minor units...")
+	if err := db.Exec(`UPDATE events SET price_per_seat_cents = ROUND(price_per_seat * 100)
+		WHERE price_per_seat_cents = 0`).Error; err != nil {
+		return err
+	}
+
+	return db.Migrator().DropColumn(&model.Event{}, "price_per_seat")
+}
+
 // createPerformanceIndexes creates critical indexes for high-performance operations
 func createPerformanceIndexes(db *gorm.DB) error {
-	// Critical indexes for CheckSeatsAvailability performance
+	// Critical indexes for seat check performance
 	indexes := []string{
 		// 1. MOST CRITICAL: Event + Seat lookup (fixes 80% of performance issues)
 		`CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_seats_event_seat_status 
@@ -488,7 +1469,7 @@ func createPerformanceIndexes(db *gorm.DB) error {
 		`CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_holds_id_expires 
 		 ON holds (id, expires_at)`,
 
-		// 3. COMPLETE OPTIMIZATION: Covers the full CheckSeatsAvailability query
+		// 3. COMPLETE OPTIMIZATION: Covers the full seat check query
 		`CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_seats_availability_check 
 		 ON seats (event_id, seat_number, status, hold_id)`,
 
@@ -519,6 +1500,14 @@ func createPerformanceIndexes(db *gorm.DB) error {
 		// 10. User's holds lookup
 		`CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_holds_user_id 
 		 ON holds (user_id)`,
+
+		// 11. Seat history lookups for disputes
+		`CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_seat_status_events_event_seat
+		 ON seat_status_events (event_id, seat_number, created_at)`,
+
+		// 12. Organizer hold listing
+		`CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_holds_event_status
+		 ON holds (event_id, status, created_at)`,
 	}
 
 	log.Println("Creating performance indexes for high-load scenarios...")
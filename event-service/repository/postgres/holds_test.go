@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/google/uuid"
+)
+
+// createTestHold holds seats on the event for a new user, expiring at expiresAt
+func createTestHold(t *testing.T, repo *PostgresEventRepository, eventID string, seats []string, expiresAt time.Time) *model.Hold {
+	t.Helper()
+	hold, err := repo.CreateHold(model.CreateHoldRequest{
+		ID:          uuid.NewString(),
+		UserID:      "user-" + uuid.NewString(),
+		EventID:     eventID,
+		SeatNumbers: seats,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("CreateHold() error = %v", err)
+	}
+	return hold
+}
+
+func TestHoldExpiryIgnoresServerTimeZone(t *testing.T) {
+	repo := newTestRepository(t)
+
+	// Zones far from UTC in both directions, where a local time mistaken for
+	// UTC would be off by half a day
+	zones := []*time.Location{
+		time.FixedZone("UTC+14", 14*60*60),
+		time.FixedZone("UTC-12", -12*60*60),
+	}
+	local := time.Local
+	t.Cleanup(func() { time.Local = local })
+
+	for _, zone := range zones {
+		t.Run(zone.String(), func(t *testing.T) {
+			time.Local = zone
+			event := createTestEvent(t, repo, 2, []string{"A1", "A2"})
+
+			live := createTestHold(t, repo, event.ID, []string{"A1"}, time.Now().Add(time.Minute))
+			if expired, err := repo.ExpireHold(live.ID); err != nil || expired != nil {
+				t.Fatalf("ExpireHold() on a hold with a minute left = %v, %v, want nil", expired, err)
+			}
+			if err := repo.ConfirmHold(live.ID, 0); err != nil {
+				t.Errorf("ConfirmHold() on a hold with a minute left error = %v", err)
+			}
+
+			lapsed := createTestHold(t, repo, event.ID, []string{"A2"}, time.Now().Add(-time.Second))
+			if err := repo.ConfirmHold(lapsed.ID, 0); !errors.Is(err, repository.ErrHoldExpired) {
+				t.Errorf("ConfirmHold() on a lapsed hold error = %v, want %v", err, repository.ErrHoldExpired)
+			}
+			if expired, err := repo.ExpireHold(lapsed.ID); err != nil || expired == nil {
+				t.Errorf("ExpireHold() on a lapsed hold = %v, %v, want the hold", expired, err)
+			}
+		})
+	}
+}
+
+func TestCheckSeatsReportsMissingAndUnavailable(t *testing.T) {
+	repo := newTestRepository(t)
+	event := createTestEvent(t, repo, 4, []string{"A1", "A2", "A3", "A4"})
+	createTestHold(t, repo, event.ID, []string{"A2"}, time.Now().Add(10*time.Minute))
+	expired := createTestHold(t, repo, event.ID, []string{"A3"}, time.Now().Add(10*time.Minute))
+	if err := repo.db.Exec("UPDATE holds SET expires_at = ? WHERE id = ?", time.Now().Add(-time.Minute), expired.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		seats           []string
+		wantMissing     []string
+		wantUnavailable []string
+	}{
+		{"available and expired hold", []string{"A1", "A3"}, nil, nil},
+		{"held", []string{"A1", "A2"}, nil, []string{"A2"}},
+		{"nonexistent", []string{"A1", "Z9"}, []string{"Z9"}, nil},
+		{"mixed", []string{"A1", "A2", "A3", "Z9", "B1"}, []string{"B1", "Z9"}, []string{"A2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.CheckSeats(event.ID, tt.seats)
+			if tt.wantMissing == nil && tt.wantUnavailable == nil {
+				if err != nil {
+					t.Fatalf("CheckSeats() error = %v", err)
+				}
+				return
+			}
+
+			var checkErr *repository.SeatCheckError
+			if !errors.As(err, &checkErr) {
+				t.Fatalf("CheckSeats() error = %v, want a SeatCheckError", err)
+			}
+			sort.Strings(checkErr.Missing)
+			sort.Strings(checkErr.Unavailable)
+			if !reflect.DeepEqual(checkErr.Missing, tt.wantMissing) || !reflect.DeepEqual(checkErr.Unavailable, tt.wantUnavailable) {
+				t.Errorf("CheckSeats() missing %v, unavailable %v, want %v and %v",
+					checkErr.Missing, checkErr.Unavailable, tt.wantMissing, tt.wantUnavailable)
+			}
+		})
+	}
+}
+
+// expireTestHold moves a hold's expiry into the past without sweeping it
+func expireTestHold(t *testing.T, repo *PostgresEventRepository, hold *model.Hold) {
+	t.Helper()
+	if err := repo.db.Model(&model.Hold{}).Where("id = ?", hold.ID).
+		Update("expires_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// seatHolder returns the seat's status and the hold it belongs to
+func seatHolder(t *testing.T, repo *PostgresEventRepository, eventID, seatNumber string) (string, string) {
+	t.Helper()
+	var seat model.Seat
+	if err := repo.db.Where("event_id = ? AND seat_number = ?", eventID, seatNumber).First(&seat).Error; err != nil {
+		t.Fatal(err)
+	}
+	if seat.HoldID == nil {
+		return seat.Status, ""
+	}
+	return seat.Status, *seat.HoldID
+}
+
+func TestConfirmUnsweptExpiredHold(t *testing.T) {
+	repo := newTestRepository(t)
+	event := createTestEvent(t, repo, 2, []string{"A1", "A2"})
+
+	t.Run("seats still held", func(t *testing.T) {
+		hold := createTestHold(t, repo, event.ID, []string{"A1"}, time.Now().Add(10*time.Minute))
+		expireTestHold(t, repo, hold)
+
+		if err := repo.ConfirmHold(hold.ID, time.Minute); err != nil {
+			t.Fatalf("ConfirmHold() error = %v", err)
+		}
+		if status, holdID := seatHolder(t, repo, event.ID, "A1"); status != "booked" || holdID != hold.ID {
+			t.Errorf("seat A1 is %s by hold %q, want booked by %s", status, holdID, hold.ID)
+		}
+	})
+
+	t.Run("seats held by someone else", func(t *testing.T) {
+		hold := createTestHold(t, repo, event.ID, []string{"A2"}, time.Now().Add(10*time.Minute))
+		expireTestHold(t, repo, hold)
+		other := createTestHold(t, repo, event.ID, []string{"A2"}, time.Now().Add(10*time.Minute))
+
+		if err := repo.ConfirmHold(hold.ID, time.Minute); !errors.Is(err, repository.ErrHoldExpired) {
+			t.Fatalf("ConfirmHold() error = %v, want %v", err, repository.ErrHoldExpired)
+		}
+		if status, holdID := seatHolder(t, repo, event.ID, "A2"); status != "held" || holdID != other.ID {
+			t.Errorf("seat A2 is %s by hold %q, want still held by %s", status, holdID, other.ID)
+		}
+	})
+}
+
+func TestConfirmHoldTwice(t *testing.T) {
+	repo := newTestRepository(t)
+	event := createTestEvent(t, repo, 2, []string{"A1", "A2"})
+	hold := createTestHold(t, repo, event.ID, []string{"A1", "A2"}, time.Now().Add(10*time.Minute))
+
+	confirmations := func() int64 {
+		t.Helper()
+		var count int64
+		if err := repo.db.Model(&model.SeatStatusEvent{}).
+			Where("event_id = ? AND reason = ?", event.ID, model.SeatTransitionHoldConfirmed).
+			Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	if err := repo.ConfirmHold(hold.ID, 0); err != nil {
+		t.Fatalf("first ConfirmHold() error = %v", err)
+	}
+	if got := confirmations(); got != 2 {
+		t.Fatalf("%d seat transitions recorded after confirming, want 2", got)
+	}
+
+	if err := repo.ConfirmHold(hold.ID, 0); err != nil {
+		t.Fatalf("second ConfirmHold() error = %v", err)
+	}
+	if got := confirmations(); got != 2 {
+		t.Errorf("%d seat transitions recorded after confirming again, want still 2", got)
+	}
+	for _, seatNumber := range []string{"A1", "A2"} {
+		if status, holdID := seatHolder(t, repo, event.ID, seatNumber); status != "booked" || holdID != hold.ID {
+			t.Errorf("seat %s is %s by hold %q, want booked by %s", seatNumber, status, holdID, hold.ID)
+		}
+	}
+}
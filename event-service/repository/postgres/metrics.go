@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Hold outcomes used as the "outcome" label of the hold duration histogram
+const (
+	holdOutcomeConfirmed = "confirmed"
+	holdOutcomeReleased  = "released"
+	holdOutcomeExpired   = "expired"
+)
+
+// newHoldDurationHistogram creates the histogram of time from hold creation to
+// its terminal event. Buckets span a few seconds to well past the 15 minute
+// hold window.
+func newHoldDurationHistogram() *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "event_hold_duration_seconds",
+		Help:    "Time from hold creation until it was confirmed, released or expired",
+		Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 900, 1200, 1800},
+	}, []string{"outcome"})
+}
+
+// Seat availability queries used as the "operation" label of the seat query histogram
+const (
+	seatQueryCheckAvailability = "check_availability"
+	seatQueryAvailableCount    = "available_count"
+	seatQueryAvailableSeats    = "available_seats"
+)
+
+// newSeatQueryHistogram creates the histogram of seat availability query
+// latency. These joins are what the performance indexes exist for, so the
+// buckets run from 1ms up to about 4s to show both healthy and missing indexes.
+func newSeatQueryHistogram() *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "event_seat_query_duration_seconds",
+		Help:    "Latency of seat availability queries against the database, by operation",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 13),
+	}, []string{"operation"})
+}
+
+// RegisterMetrics exposes the repository's hold lifecycle and seat query
+// metrics to Prometheus
+func (r *PostgresEventRepository) RegisterMetrics(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{r.holdDurations, r.seatQueryDurations} {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// observeSeatQuery records the latency of a seat query started at start and
+// logs it when it exceeds the slow query threshold
+func (r *PostgresEventRepository) observeSeatQuery(operation, eventID string, start time.Time) {
+	elapsed := time.Since(start)
+	r.seatQueryDurations.WithLabelValues(operation).Observe(elapsed.Seconds())
+	if elapsed >= r.slowQueryThreshold {
+		log.Printf("Slow seat query: %s for event %s took %s", operation, eventID, elapsed)
+	}
+}
+
+// observeHoldEnd records how long a hold lasted before reaching its outcome
+func (r *PostgresEventRepository) observeHoldEnd(outcome string, createdAt, endedAt time.Time) {
+	r.holdDurations.WithLabelValues(outcome).Observe(endedAt.Sub(createdAt).Seconds())
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/google/uuid"
+)
+
+func TestPresaleSeatsNeedACodeUntilGeneralSale(t *testing.T) {
+	repo := newTestRepository(t)
+	event := createTestEvent(t, repo, 3, []string{"A1", "A2", "A3"})
+
+	if err := repo.SetPresale(model.SetPresaleRequest{
+		EventID:     event.ID,
+		SeatNumbers: []string{"A1", "A2"},
+		Codes:       []string{"VIP2026"},
+		EndsAt:      time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("SetPresale() error = %v", err)
+	}
+
+	hold := func(seat, code string) error {
+		_, err := repo.CreateHold(model.CreateHoldRequest{
+			ID:          uuid.NewString(),
+			UserID:      "user-" + uuid.NewString(),
+			EventID:     event.ID,
+			SeatNumbers: []string{seat},
+			ExpiresAt:   time.Now().Add(15 * time.Minute),
+			PresaleCode: code,
+		})
+		return err
+	}
+
+	if err := hold("A1", ""); !errors.Is(err, repository.ErrPresaleCodeRequired) {
+		t.Errorf("holding a presale seat without a code: error = %v, want %v", err, repository.ErrPresaleCodeRequired)
+	}
+	if err := hold("A1", "GUESS"); !errors.Is(err, repository.ErrPresaleCodeInvalid) {
+		t.Errorf("holding a presale seat with a wrong code: error = %v, want %v", err, repository.ErrPresaleCodeInvalid)
+	}
+	if err := hold("A3", ""); err != nil {
+		t.Errorf("holding a general seat during the presale: error = %v", err)
+	}
+	if err := hold("A1", "VIP2026"); err != nil {
+		t.Errorf("holding a presale seat with its code: error = %v", err)
+	}
+
+	// General sale starts a moment ago
+	if err := repo.db.Model(&model.Event{}).Where("id = ?", event.ID).
+		Update("presale_ends_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := hold("A2", ""); err != nil {
+		t.Errorf("holding a presale seat after general sale started: error = %v", err)
+	}
+}
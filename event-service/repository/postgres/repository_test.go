@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/shared/startup"
+	"github.com/google/uuid"
+)
+
+// newTestRepository connects to the database in TEST_DATABASE_URL, skipping the
+// test when it isn't set. Tests only touch the events they create.
+func newTestRepository(t *testing.T) *PostgresEventRepository {
+	t.Helper()
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	repo, err := NewEventRepository(databaseURL, "", time.Second, startup.Retry{Attempts: 1})
+	if err != nil {
+		t.Fatalf("NewEventRepository() error = %v", err)
+	}
+	return repo
+}
+
+// createTestEvent creates a ready event a day from now with the given seat
+// labels, or generated seats when labels is empty, and removes it with
+// everything referencing it once the test ends
+func createTestEvent(t *testing.T, repo *PostgresEventRepository, totalSeats int, labels []string) *model.Event {
+	t.Helper()
+	event, err := repo.CreateEvent(model.CreateEventRequest{
+		ID:                uuid.NewString(),
+		Name:              "Test Event",
+		Venue:             "Test Venue",
+		City:              "Test City",
+		Category:          "Concert",
+		EventDate:         time.Now().Add(24 * time.Hour),
+		TotalSeats:        totalSeats,
+		PricePerSeatCents: 2500,
+		Currency:          "USD",
+		CreatedBy:         "organizer-" + uuid.NewString(),
+		SeatLabels:        labels,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		for _, table := range []string{"seat_status_events", "seats", "holds", "presale_codes"} {
+			repo.db.Exec("DELETE FROM "+table+" WHERE event_id = ?", event.ID)
+		}
+		repo.db.Exec("DELETE FROM events WHERE id = ?", event.ID)
+	})
+	return event
+}
+
+func TestGetAvailableSeatsOrdersNumerically(t *testing.T) {
+	repo := newTestRepository(t)
+	event := createTestEvent(t, repo, 7, []string{"A10", "B1", "A2", "AA1", "A1", "Z3", "BOX"})
+
+	seats, err := repo.GetAvailableSeats(event.ID)
+	if err != nil {
+		t.Fatalf("GetAvailableSeats() error = %v", err)
+	}
+
+	want := []string{"A1", "A2", "A10", "B1", "Z3", "AA1", "BOX"}
+	if len(seats) != len(want) {
+		t.Fatalf("GetAvailableSeats() = %v, want %v", seats, want)
+	}
+	for i := range want {
+		if seats[i] != want[i] {
+			t.Fatalf("GetAvailableSeats() = %v, want %v", seats, want)
+		}
+	}
+}
+
+func TestContainsPattern(t *testing.T) {
+	tests := map[string]string{
+		"Boston":      "%Boston%",
+		"100%":        `%100\%%`,
+		"rock_n_roll": `%rock\_n\_roll%`,
+		`C:\music`:    `%C:\\music%`,
+	}
+	for value, want := range tests {
+		if got := containsPattern(value); got != want {
+			t.Errorf("containsPattern(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestListEventsMatchesNameLiterally(t *testing.T) {
+	repo := newTestRepository(t)
+
+	// A run-specific suffix keeps other rows in the database out of the results
+	suffix := " " + uuid.NewString()[:8]
+	names := []string{"Sale 100%" + suffix, "Sale 1000" + suffix, "Sale 100x" + suffix}
+	for _, name := range names {
+		event := createTestEvent(t, repo, 1, nil)
+		if err := repo.db.Model(&model.Event{}).Where("id = ?", event.ID).Update("name", name).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, total, err := repo.ListEvents(model.EventFilter{Name: "100%" + suffix, Limit: 10})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if total != 1 || len(events) != 1 || events[0].Name != names[0] {
+		t.Errorf("ListEvents(name %q) = %d of %d events, want only %q", "100%"+suffix, len(events), total, names[0])
+	}
+}
+
+func TestListEventsPagesStablyOnTies(t *testing.T) {
+	repo := newTestRepository(t)
+
+	// Same name and date for every event, so only the ID tiebreaker orders them
+	name := "Tie " + uuid.NewString()[:8]
+	eventDate := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	var ids []string
+	for i := 0; i < 5; i++ {
+		event := createTestEvent(t, repo, 1, nil)
+		if err := repo.db.Model(&model.Event{}).Where("id = ?", event.ID).
+			Updates(map[string]interface{}{"name": name, "event_date": eventDate}).Error; err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, event.ID)
+	}
+	sort.Strings(ids)
+
+	for _, eventSort := range []string{model.EventSortDate, model.EventSortName} {
+		t.Run(eventSort, func(t *testing.T) {
+			var paged []string
+			for offset := 0; offset < len(ids); offset += 2 {
+				events, _, err := repo.ListEvents(model.EventFilter{Name: name, Sort: eventSort, Limit: 2, Offset: offset})
+				if err != nil {
+					t.Fatalf("ListEvents() error = %v", err)
+				}
+				for _, event := range events {
+					paged = append(paged, event.ID)
+				}
+			}
+			if !reflect.DeepEqual(paged, ids) {
+				t.Errorf("pages listed %v, want every event once in ID order %v", paged, ids)
+			}
+		})
+	}
+}
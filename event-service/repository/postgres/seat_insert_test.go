@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/event-service/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"lock timeout", fmt.Errorf("insert: %w", &pgconn.PgError{Code: "55P03"}), true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"not a Postgres error", errors.New("connection reset"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Errorf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// failSeatInserts makes the next n seat inserts on repo fail with err
+func failSeatInserts(t *testing.T, repo *PostgresEventRepository, n int, err error) {
+	t.Helper()
+	name := "test:fail_seat_inserts"
+	if regErr := repo.db.Callback().Create().Before("gorm:create").Register(name, func(db *gorm.DB) {
+		if db.Statement.Table == "seats" && n > 0 {
+			n--
+			db.AddError(err)
+		}
+	}); regErr != nil {
+		t.Fatal(regErr)
+	}
+	t.Cleanup(func() {
+		repo.db.Callback().Create().Remove(name)
+	})
+}
+
+func TestCreateEventRetriesTransientSeatFailures(t *testing.T) {
+	transient := &pgconn.PgError{Code: "40P01"}
+	tests := []struct {
+		name      string
+		failures  int
+		err       error
+		wantSaved bool
+		wantErr   error
+	}{
+		{"transient failure succeeds on retry", 1, transient, true, nil},
+		{"transient failure persists", seatInsertAttempts, transient, false, repository.ErrSeatCreationFailed},
+		{"permanent failure", 1, &pgconn.PgError{Code: "23505"}, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			failSeatInserts(t, repo, tt.failures, tt.err)
+
+			id := uuid.NewString()
+			t.Cleanup(func() {
+				repo.db.Exec("DELETE FROM seats WHERE event_id = ?", id)
+				repo.db.Exec("DELETE FROM events WHERE id = ?", id)
+			})
+			_, err := repo.CreateEvent(model.CreateEventRequest{
+				ID:                id,
+				Name:              "Test Event",
+				Venue:             "Test Venue",
+				City:              "Test City",
+				Category:          "Concert",
+				EventDate:         time.Now().Add(24 * time.Hour),
+				TotalSeats:        150,
+				PricePerSeatCents: 2500,
+				Currency:          "USD",
+				CreatedBy:         "organizer-" + uuid.NewString(),
+			})
+
+			if tt.wantSaved {
+				if err != nil {
+					t.Fatalf("CreateEvent() error = %v", err)
+				}
+				if count, err := repo.GetAvailableSeatCount(id); err != nil || count != 150 {
+					t.Errorf("GetAvailableSeatCount() = %d, %v, want 150 seats", count, err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("CreateEvent() error = nil, want the seat failure")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateEvent() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := repo.GetEventByID(id); !errors.Is(err, repository.ErrEventNotFound) {
+				t.Errorf("GetEventByID() error = %v, want the event not saved", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"gorm.io/gorm"
+)
+
+func TestWithTransactionRollsBack(t *testing.T) {
+	repo := newTestRepository(t)
+	event := createTestEvent(t, repo, 1, nil)
+	errMidway := errors.New("midway failure")
+
+	tests := []struct {
+		name string
+		fail func() error
+	}{
+		{"error", func() error { return errMidway }},
+		{"panic", func() error { panic("midway panic") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.WithTransaction(func(tx *gorm.DB) error {
+				if err := tx.Model(&model.Event{}).Where("id = ?", event.ID).Update("name", "Renamed").Error; err != nil {
+					return err
+				}
+				return tt.fail()
+			})
+			if err == nil {
+				t.Fatal("WithTransaction() error = nil, want the failure")
+			}
+
+			got, err := repo.GetEventByID(event.ID)
+			if err != nil {
+				t.Fatalf("GetEventByID() error = %v", err)
+			}
+			if got.Name != event.Name {
+				t.Errorf("name = %q after a failed transaction, want %q", got.Name, event.Name)
+			}
+		})
+	}
+
+	if err := repo.WithTransaction(func(tx *gorm.DB) error {
+		return tx.Model(&model.Event{}).Where("id = ?", event.ID).Update("name", "Renamed").Error
+	}); err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+	got, err := repo.GetEventByID(event.ID)
+	if err != nil {
+		t.Fatalf("GetEventByID() error = %v", err)
+	}
+	if got.Name != "Renamed" {
+		t.Errorf("name = %q after a committed transaction, want Renamed", got.Name)
+	}
+}
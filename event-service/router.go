@@ -1,63 +1,200 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
 
+	"github.com/arunvm123/eventbooking/event-service/cache"
 	"github.com/arunvm123/eventbooking/event-service/cache/redis"
 	"github.com/arunvm123/eventbooking/event-service/config"
 	"github.com/arunvm123/eventbooking/event-service/repository/postgres"
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/shared/queue"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func SetupRouter(cfg *config.Config) *gin.Engine {
-	// Initialize repository
-	repo, err := postgres.NewEventRepository(cfg.Database.GetDatabaseURL())
+// inProcessLogGroup consumes the topics whose consumers run out of process
+const inProcessLogGroup = "event-service-log"
+
+// SetupRouter wires the service together. Its background jobs run until ctx
+// is done.
+func SetupRouter(ctx context.Context, cfg *config.Config) *gin.Engine {
+	// Initialize repository (with optional read replica for read-only queries)
+	replicaURL := ""
+	if cfg.HasReadReplica() {
+		replicaURL = cfg.ReadReplica.GetDatabaseURL()
+	}
+	// Dependencies may still be starting, e.g. during ordered Kubernetes startup
+	retry := cfg.Startup.Retry()
+
+	repo, err := postgres.NewEventRepository(cfg.Database.GetDatabaseURL(), replicaURL, cfg.SlowSeatQueryThreshold, retry)
 	if err != nil {
 		log.Fatal("Failed to initialize repository:", err)
 	}
 
+	// Export hold lifecycle and seat query metrics
+	if err := repo.RegisterMetrics(prometheus.DefaultRegisterer); err != nil {
+		log.Fatal("Failed to register metrics:", err)
+	}
+
 	// Initialize cache
-	cache, err := redis.NewRedisCacheRepository(cfg.Redis.GetRedisURL(), cfg.Redis.Password, cfg.Redis.DB)
+	var redisCache *redis.RedisCacheRepository
+	err = retry.Connect("redis", func() (err error) {
+		redisCache, err = redis.NewRedisCacheRepository(&cfg.Redis)
+		return err
+	})
 	if err != nil {
 		log.Fatal("Failed to initialize cache:", err)
 	}
 
+	// Optionally refill hot events' seat caches as soon as holds invalidate them
+	var eventCache cache.CacheRepository = redisCache
+	if cfg.Cache.Warming.Enabled {
+		warmer := NewCacheWarmer(redisCache, repo, cfg.Cache.SeatCacheTTL, cfg.Cache.Warming)
+		if err := warmer.RegisterMetrics(prometheus.DefaultRegisterer); err != nil {
+			log.Fatal("Failed to register cache warming metrics:", err)
+		}
+		go warmer.Run(ctx)
+		eventCache = warmer
+	}
+
+	// Expire stale holds in the background
+	holdCleanup := NewHoldCleanupJob(repo, eventCache, cfg.HoldCleanup.Interval, cfg.HoldCleanup.BatchSize)
+	go holdCleanup.Run(ctx)
+	if cfg.HoldCleanup.ExpiryNotifications {
+		go NewHoldExpiryListener(repo, eventCache).Run(ctx)
+	}
+
+	// Notifications and audit events go through Kafka or, with the in-memory
+	// queue, are logged in-process since their consumers can't reach it.
+	// Without either, notifications are off and audit events are only logged.
+	var mq queue.MessageQueue
+	if cfg.NotificationsEnabled() {
+		mq, err = queue.New(cfg.Queue.Driver, cfg.Kafka.Brokers, queue.StartLatest, cfg.Queue.MemorySize)
+		if err != nil {
+			log.Fatal("Failed to initialize message queue:", err)
+		}
+		if cfg.Queue.Memory() {
+			go queue.LogTopic(ctx, mq, cfg.Kafka.NotificationTopic, inProcessLogGroup)
+			go queue.LogTopic(ctx, mq, cfg.Kafka.AuditTopic, inProcessLogGroup)
+		}
+
+		// Warn holders before their seats are released
+		holdWarnings := NewHoldWarningJob(repo, mq, cfg.Kafka.NotificationTopic,
+			cfg.HoldWarning.LeadTime, cfg.HoldWarning.CheckInterval, cfg.HoldWarning.CheckoutURL)
+		go holdWarnings.Run(ctx)
+	}
+
 	// Initialize JWT service
-	jwtService := NewJWTService(cfg.JWTSecret)
+	jwtService := auth.NewJWTService(cfg.JWTSecret)
+
+	// Initialize user service client for holders' names in hold details
+	userNames := NewUserNameLookup(cfg.UserService, jwtService)
+
+	// Publish event deletions and admin actions to the audit trail
+	auditor := audit.NewRecorder("event-service", mq, cfg.Kafka.AuditTopic)
 
 	// Initialize handlers
-	eventHandler := NewEventHandler(repo, cache)
+	eventHandler := NewEventHandler(cfg, repo, eventCache, jwtService, userNames, auditor)
 
 	// Setup Gin router
 	r := gin.Default()
 
 	// Add middleware
-	r.Use(CORSMiddleware())
-	r.Use(LoggingMiddleware())
+	r.Use(middleware.CORS())
+	r.Use(middleware.Debug())
+	r.Use(middleware.Logging())
+	r.Use(middleware.DefaultJSON())
+
+	// Unknown routes get a JSON error rather than gin's plain text 404
+	r.NoRoute(func(c *gin.Context) {
+		middleware.RespondError(c, http.StatusNotFound, "not_found", "Route not found")
+	})
 
 	// Health check endpoint (no auth required)
 	r.GET("/health", eventHandler.HealthCheck)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Per-user request quotas for authenticated routes
+	rateLimit := RateLimit(redisCache, cfg.RateLimit)
+
+	// Reject writes during maintenance, except internal calls finishing bookings
+	maintenance := middleware.Maintenance(cfg.MaintenanceMode, redisCache, middleware.RespondError)
 
 	// API routes
-	api := r.Group("/api")
-	events := api.Group("/events")
+	registerRoutes := func(api *gin.RouterGroup) {
+		events := api.Group("/events")
+
+		// Public endpoints (no auth required)
+		events.GET("", eventHandler.ListEvents)
+		events.GET("/popular", eventHandler.GetPopularEvents)
+		events.GET("/categories", eventHandler.ListCategories)
+		events.GET("/:id", eventHandler.GetEvent)
+		events.GET("/:id/seat-count", eventHandler.GetSeatCount)
+
+		// Guest checkout: hold seats without an account, then view or release
+		// the hold with the token scoped to it
+		if cfg.GuestHolds.Enabled {
+			events.POST("/:id/hold/guest", rateLimit, maintenance, eventHandler.HoldSeatsAsGuest)
+		}
+		guestHolds := events.Group("/holds", GuestAuthMiddleware(jwtService), RejectDeletedUsers(redisCache), rateLimit, RequireGuestHold(), maintenance)
+		guestHolds.GET("/:holdId", eventHandler.GetHoldDetails)
+		guestHolds.DELETE("/:holdId", eventHandler.ReleaseHold)
+
+		// Protected endpoints (require authentication)
+		protected := events.Group("")
+		protected.Use(AuthMiddleware(jwtService), RejectDeletedUsers(redisCache), rateLimit, maintenance)
 
-	// Public endpoints (no auth required)
-	events.GET("", eventHandler.ListEvents)
-	events.GET("/:id", eventHandler.GetEvent)
+		// Event management (authenticated users only)
+		protected.POST("", eventHandler.CreateEvent)
+		protected.PUT("/:id", eventHandler.UpdateEvent)
+		protected.PATCH("/:id", eventHandler.PatchEvent)
+		protected.DELETE("/:id", eventHandler.DeleteEvent)
+		protected.POST("/:id/duplicate", eventHandler.DuplicateEvent)
 
-	// Protected endpoints (require authentication)
-	protected := events.Group("")
-	protected.Use(AuthMiddleware(jwtService))
+		// Seat operations (authenticated users only)
+		protected.POST("/:id/hold", eventHandler.HoldSeats)
+		protected.DELETE("/:id/holds/mine", eventHandler.ReleaseMyHolds)
+		protected.GET("/:id/holds/:holdId", eventHandler.GetMyHold)
+		protected.POST("/holds/:holdId/confirm", eventHandler.ConfirmHold)
+		protected.POST("/:id/reserve", RequireTrusted(), eventHandler.ReserveSeats)
 
-	// Event management (authenticated users only)
-	protected.POST("", eventHandler.CreateEvent)
+		// Admin endpoints
+		protected.GET("/:id/seat-history", RequireAdmin(), eventHandler.GetSeatHistory)
+		protected.POST("/:id/seats/regenerate", RequireAdmin(), eventHandler.RegenerateSeats)
 
-	// Seat operations (authenticated users only)
-	protected.POST("/:id/hold", eventHandler.HoldSeats)
-	protected.GET("/holds/:holdId", eventHandler.GetHoldDetails)
-	protected.DELETE("/holds/:holdId", eventHandler.ReleaseHold)
-	protected.POST("/holds/:holdId/confirm", eventHandler.ConfirmHold)
+		// Organizer endpoints (event creator or admin)
+		protected.GET("/:id/holds", eventHandler.ListEventHolds)
+		protected.PUT("/:id/presale", eventHandler.SetPresale)
+		protected.DELETE("/:id/presale", eventHandler.ClearPresale)
+
+		// Holds spanning several events, confirmed and released through the
+		// hold endpoints above by their group hold ID
+		holds := api.Group("/holds", AuthMiddleware(jwtService), RejectDeletedUsers(redisCache), rateLimit, maintenance)
+		holds.POST("/multi", eventHandler.HoldSeatsMulti)
+
+		// Called by the user service when a user deletes their account
+		internal := api.Group("/internal", AuthMiddleware(jwtService), RequireService())
+		internal.POST("/users/:userId/anonymize", eventHandler.AnonymizeUserHolds)
+
+		// Operator tools, left writable during maintenance
+		admin := api.Group("/admin", AuthMiddleware(jwtService), rateLimit, RequireAdmin())
+		admin.POST("/events/:id/seats/status", eventHandler.UpdateSeatStatuses)
+		admin.GET("/maintenance", eventHandler.GetMaintenance)
+		admin.PUT("/maintenance", eventHandler.SetMaintenance)
+	}
+	registerRoutes(r.Group(cfg.APIBasePath))
+
+	// Keep serving the unversioned prefix while clients migrate
+	if cfg.APIBasePath != middleware.LegacyAPIBasePath {
+		registerRoutes(r.Group(middleware.LegacyAPIBasePath,
+			middleware.Deprecated(middleware.LegacyAPIBasePath, cfg.APIBasePath)))
+	}
 
 	return r
 }
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/config"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// UserNameLookup fetches holders' display names from the user service. Hold
+// details don't depend on it: any failure just leaves the name empty, and a
+// circuit breaker stops calling a user service that keeps failing.
+type UserNameLookup struct {
+	baseURL    string
+	httpClient *http.Client
+	jwtService *auth.JWTService
+	breaker    *circuitBreaker
+}
+
+// NewUserNameLookup returns nil when no user service URL is configured; the
+// nil lookup finds no names
+func NewUserNameLookup(cfg config.UserServiceConfig, jwtService *auth.JWTService) *UserNameLookup {
+	if cfg.BaseURL == "" {
+		return nil
+	}
+	return &UserNameLookup{
+		baseURL:    cfg.BaseURL + cfg.APIBasePath,
+		httpClient: &http.Client{Timeout: cfg.LookupTimeout},
+		jwtService: jwtService,
+		breaker:    newCircuitBreaker("user service", cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+// Name returns the user's display name, or an empty string if it can't be
+// found right now
+func (l *UserNameLookup) Name(ctx context.Context, userID string) string {
+	if l == nil || !l.breaker.allow() {
+		return ""
+	}
+
+	name, err := l.fetch(ctx, userID)
+	if err != nil {
+		l.breaker.failure()
+		log.Printf("User name lookup for %s failed, continuing without it: %v", userID, err)
+		return ""
+	}
+	l.breaker.success()
+	return name
+}
+
+// fetch asks the user service for the name. Unknown users, e.g. deleted
+// accounts, have no name rather than counting as a failure.
+func (l *UserNameLookup) fetch(ctx context.Context, userID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/internal/users/%s/name", l.baseURL, url.PathEscape(userID)), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	now := time.Now()
+	token, err := l.jwtService.GenerateToken(auth.Claims{
+		UserID: userID,
+		Role:   auth.RoleService,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(5 * time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "event-service",
+			Subject:   "service-auth",
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate service token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call user service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("user service returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Name, nil
+}
+
+// circuitBreaker opens after threshold consecutive failures, rejecting calls
+// for cooldown. After that a single trial call is let through: success closes
+// the breaker again, failure reopens it for another cooldown.
+type circuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(name string, threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{name: name, threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a call may be made now
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if time.Now().Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures >= b.threshold {
+		log.Printf("Calls to %s are succeeding again", b.name)
+	}
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+		log.Printf("%d consecutive calls to %s failed, skipping it for %s", b.failures, b.name, b.cooldown)
+	}
+}
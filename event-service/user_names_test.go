@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/event-service/config"
+	"github.com/arunvm123/eventbooking/event-service/model"
+	"github.com/arunvm123/eventbooking/shared/auth"
+)
+
+// newTestUserNameLookup points a lookup at handler, opening its breaker after
+// two failures
+func newTestUserNameLookup(t *testing.T, handler http.HandlerFunc) *UserNameLookup {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewUserNameLookup(config.UserServiceConfig{
+		BaseURL:          server.URL,
+		APIBasePath:      "/api/v1",
+		LookupTimeout:    100 * time.Millisecond,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Minute,
+	}, auth.NewJWTService("test-secret"))
+}
+
+func TestUserNameLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    string
+	}{
+		{"found", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"name":"Ana"}`))
+		}, "Ana"},
+		{"unknown user", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}, ""},
+		{"server error", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}, ""},
+		{"timeout", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(300 * time.Millisecond)
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := newTestUserNameLookup(t, tt.handler)
+			if got := lookup.Name(context.Background(), "user-1"); got != tt.want {
+				t.Errorf("Name() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserNameLookupBreakerStopsCalls(t *testing.T) {
+	var calls int32
+	lookup := newTestUserNameLookup(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	for i := 0; i < 5; i++ {
+		lookup.Name(context.Background(), "user-1")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("user service called %d times, want 2 before the breaker opens", got)
+	}
+}
+
+func TestHoldDetailsWithoutUserService(t *testing.T) {
+	event := &model.Event{ID: "event-1", EventDate: time.Now().Add(24 * time.Hour), Currency: "USD"}
+	repo := newFakeEventRepository(event)
+	repo.holds = map[string]*model.Hold{"hold-1": {
+		ID:          "hold-1",
+		EventID:     event.ID,
+		UserID:      "user-1",
+		SeatNumbers: []string{"A1"},
+		ExpiresAt:   time.Now().Add(5 * time.Minute),
+	}}
+	lookup := newTestUserNameLookup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	handler := NewEventHandler(&config.Config{}, repo, nil, nil, lookup, nil)
+
+	w := serve("/holds/:holdId", http.MethodGet, "/holds/hold-1", "", "", handler.GetHoldDetails)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+	for _, want := range []string{`"hold_id":"hold-1"`, `"user_id":"user-1"`, `"seats":["A1"]`} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("response %s lacks %s", w.Body, want)
+		}
+	}
+	if strings.Contains(w.Body.String(), "user_name") {
+		t.Errorf("response %s has a user_name although the lookup failed", w.Body)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/segmentio/kafka-go"
+)
+
+// readinessResponse represents the /readyz response body
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// RegisterMetrics exposes the processor's counters to Prometheus
+func (p *NotificationProcessor) RegisterMetrics(registerer prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "notification_worker_messages_processed_total",
+			Help: "Total number of notification messages processed by the worker",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.processedCount))
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "notification_worker_messages_failed_total",
+			Help: "Total number of notification messages that failed processing",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.failedCount))
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "notification_worker_messages_dead_lettered_total",
+			Help: "Total number of notification messages published to the dead-letter topic",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.deadLetteredCount))
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "notification_worker_emails_sent_total",
+			Help: "Total number of emails sent by the worker",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.throttle.sentCount))
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "notification_worker_emails_throttled_total",
+			Help: "Total number of emails delayed by the send rate limit",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.throttle.throttledCount))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "notification_worker_email_send_rate",
+			Help: "Emails sent per second over the last sampling window",
+		}, p.throttle.currentRate),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "notification_worker_messages_in_flight",
+			Help: "Number of notification messages fetched but not yet processed",
+		}, func() float64 {
+			return float64(atomic.LoadInt64(&p.inFlight))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "notification_worker_pool_size",
+			Help: "Configured size of the notification worker pool",
+		}, func() float64 {
+			return float64(len(p.workers))
+		}),
+	}
+
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runHealthServer serves /livez, /readyz and /metrics until the context is cancelled
+func runHealthServer(ctx context.Context, port string, brokers []string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		checkCtx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+		defer cancel()
+
+		response := readinessResponse{Status: "ready", Checks: map[string]string{"kafka": "ok"}}
+		statusCode := http.StatusOK
+		if err := checkKafka(checkCtx, brokers); err != nil {
+			response.Status = "not_ready"
+			response.Checks["kafka"] = err.Error()
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(statusCode)
+		json.NewEncoder(w).Encode(response)
+	})
+	mux.Handle("/metrics", promhttp.Handler())
+
+	server := &http.Server{
+		Addr:              ":" + port,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		log.Printf("Worker health server listening on %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- err
+		}
+		close(errChan)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+// checkKafka succeeds when any broker accepts a connection
+func checkKafka(ctx context.Context, brokers []string) error {
+	var lastErr error
+	for _, broker := range brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		conn.Close()
+		return nil
+	}
+	return fmt.Errorf("no kafka broker reachable: %w", lastErr)
+}
@@ -7,16 +7,15 @@ import (
 	"log"
 	"os"
 	"os/signal"
-	"sync/atomic"
 	"syscall"
 
 	"github.com/arunvm123/eventbooking/notification-service/config"
 	"github.com/arunvm123/eventbooking/notification-service/model"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/segmentio/kafka-go"
 )
 
-var messagesProcessed int64
-
 func main() {
 	fmt.Println("Starting Notification Service Worker")
 
@@ -32,12 +31,36 @@ func main() {
 		}
 	}
 
-	// Setup Kafka consumer
-	consumer := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: cfg.Kafka.Brokers,
-		Topic:   cfg.Kafka.NotificationTopic,
-		GroupID: cfg.Kafka.ConsumerGroup,
-	})
+	// Create missing topics on local/dev clusters
+	if cfg.Kafka.AutoCreateTopics {
+		settings := TopicSettings{
+			Partitions:        cfg.Kafka.TopicPartitions,
+			ReplicationFactor: cfg.Kafka.TopicReplicationFactor,
+		}
+		// Kafka may still be starting, e.g. during ordered Kubernetes startup
+		err := cfg.Startup.Retry().Connect("kafka", func() error {
+			return EnsureTopics(cfg.Kafka.Brokers, []string{cfg.Kafka.NotificationTopic, cfg.Kafka.DLQTopic}, settings)
+		})
+		if err != nil {
+			log.Fatal("Failed to create Kafka topics:", err)
+		}
+	}
+
+	// Notifications come from other processes, which an in-memory queue can't reach
+	if cfg.Queue.Driver != queue.DriverKafka {
+		log.Fatalf("The notification worker needs Kafka, got MESSAGE_QUEUE=%q", cfg.Queue.Driver)
+	}
+
+	// Setup message queue for consuming notifications and dead-lettering
+	// those that exhaust their retries
+	startOffset, err := queue.ParseStartOffset(cfg.Kafka.StartOffset)
+	if err != nil {
+		log.Fatal("Invalid Kafka start offset:", err)
+	}
+	mq := queue.NewKafkaQueue(cfg.Kafka.Brokers, startOffset)
+	defer mq.Close()
+
+	consumer := mq.Consume(cfg.Kafka.NotificationTopic, cfg.Kafka.ConsumerGroup)
 	defer consumer.Close()
 
 	// Graceful shutdown context
@@ -54,63 +77,52 @@ func main() {
 		cancel()
 	}()
 
+	// Create notification processor, throttling emails to the provider's limits
+	throttle := newEmailThrottle(cfg.Email.RateLimit, cfg.Email.RateBurst)
+	processor := NewNotificationProcessor(consumer, mq, cfg.Kafka.DLQTopic, throttle, cfg.Worker.MaxWorkers, cfg.Worker.QueueSize, cfg.Worker.MaxAttempts)
+
+	// Register worker metrics
+	if err := processor.RegisterMetrics(prometheus.DefaultRegisterer); err != nil {
+		log.Fatal("Failed to register worker metrics:", err)
+	}
+
+	// Start health and metrics server alongside the consumer
+	go func() {
+		if err := runHealthServer(ctx, cfg.Worker.HealthPort, cfg.Kafka.Brokers); err != nil {
+			log.Printf("Worker health server error: %v", err)
+		}
+	}()
+
 	// Start processing notifications
 	fmt.Println("Notification processor worker started")
-	if err := processNotifications(ctx, consumer); err != nil && err != context.Canceled {
+	if err := processor.Start(ctx); err != nil && err != context.Canceled {
 		log.Fatal("Worker error:", err)
 	}
 
 	fmt.Println("Worker stopped gracefully")
 }
 
-func processNotifications(ctx context.Context, consumer *kafka.Reader) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			// Read message from Kafka
-			msg, err := consumer.ReadMessage(ctx)
-			if err != nil {
-				log.Printf("Error reading message: %v", err)
-				continue
-			}
-
-			// Process the notification
-			if err := processNotification(msg); err != nil {
-				log.Printf("Error processing notification: %v", err)
-			}
-
-			// Increment counter
-			atomic.AddInt64(&messagesProcessed, 1)
-		}
-	}
-}
-
-func processNotification(msg kafka.Message) error {
+func processNotification(msg kafka.Message, throttle *emailThrottle) error {
 	var notificationReq model.NotificationRequest
 	if err := json.Unmarshal(msg.Value, &notificationReq); err != nil {
-		return fmt.Errorf("failed to unmarshal notification request: %w", err)
+		return &decodeError{err: err}
 	}
 
 	log.Printf("Processing notification: %s for %s", notificationReq.Type, notificationReq.RecipientEmail)
 
 	// Generate email based on notification type
-	var emailTemplate *model.EmailTemplate
-	switch notificationReq.Type {
-	case "booking_confirmed":
-		emailTemplate = notificationReq.GenerateBookingConfirmationEmail()
-	case "booking_failed":
-		emailTemplate = notificationReq.GenerateBookingFailedEmail()
-	default:
+	emailTemplate := notificationReq.GenerateEmail()
+	if emailTemplate == nil {
 		log.Printf("Unknown notification type: %s", notificationReq.Type)
 		return nil
 	}
 
 	// Mock email sending (just log to console)
+	throttle.wait()
 	if err := sendEmailMock(emailTemplate); err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
+	throttle.recordSent()
 
 	log.Printf("Successfully sent %s email to %s for booking %s",
 		notificationReq.Type, notificationReq.RecipientEmail, notificationReq.BookingData.BookingID)
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/arunvm123/eventbooking/notification-service/model"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/segmentio/kafka-go"
+)
+
+// retryBackoff is the delay before the second attempt; it grows linearly per attempt
+const retryBackoff = time.Second
+
+// maxLoggedPayload caps how much of an undecodable message is written to the log
+const maxLoggedPayload = 512
+
+// decodeError means a message's payload isn't a notification request. Retrying
+// can't fix that, so such messages go straight to the DLQ.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return "failed to decode notification request: " + e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// payloadExcerpt quotes the start of a raw message for the log
+func payloadExcerpt(value []byte) string {
+	if len(value) <= maxLoggedPayload {
+		return strconv.Quote(string(value))
+	}
+	return fmt.Sprintf("%s... (%d bytes)", strconv.Quote(string(value[:maxLoggedPayload])), len(value))
+}
+
+// NotificationProcessor consumes notification requests with a bounded pool of
+// workers. Messages for the same recipient are always routed to the same
+// worker so their emails are sent in the order they were produced.
+type NotificationProcessor struct {
+	consumer    queue.Consumer
+	queue       queue.MessageQueue
+	dlqTopic    string
+	throttle    *emailThrottle
+	maxAttempts int
+	workers     []*NotificationWorker
+	offsets     *offsetTracker
+	wg          sync.WaitGroup
+
+	// Metrics
+	processedCount    int64
+	failedCount       int64
+	deadLetteredCount int64
+	inFlight          int64
+}
+
+type NotificationWorker struct {
+	id         int
+	processor  *NotificationProcessor
+	jobChannel chan kafka.Message
+}
+
+// NewNotificationProcessor creates a processor. Emails are sent at the pace
+// allowed by throttle, and notifications that fail maxAttempts times are
+// published to dlqTopic.
+func NewNotificationProcessor(consumer queue.Consumer, queue queue.MessageQueue, dlqTopic string, throttle *emailThrottle, maxWorkers, queueSize, maxAttempts int) *NotificationProcessor {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	processor := &NotificationProcessor{
+		consumer:    consumer,
+		queue:       queue,
+		dlqTopic:    dlqTopic,
+		throttle:    throttle,
+		maxAttempts: maxAttempts,
+		workers:     make([]*NotificationWorker, maxWorkers),
+		offsets:     newOffsetTracker(),
+	}
+
+	// Initialize worker pool
+	for i := 0; i < maxWorkers; i++ {
+		processor.workers[i] = &NotificationWorker{
+			id:         i,
+			processor:  processor,
+			jobChannel: make(chan kafka.Message, queueSize),
+		}
+	}
+
+	return processor
+}
+
+// Start begins processing notification requests from the queue
+func (p *NotificationProcessor) Start(ctx context.Context) error {
+	log.Printf("Starting notification processor with %d workers...", len(p.workers))
+
+	// Start all workers
+	for _, worker := range p.workers {
+		p.wg.Add(1)
+		go worker.start()
+	}
+
+	// Start metrics reporting goroutine
+	go p.reportMetrics(ctx)
+	go p.throttle.sampleRate(ctx)
+
+	// Main message processing loop
+	for {
+		// Fetch without committing; offsets are committed once processing finishes
+		msg, err := p.consumer.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				p.shutdown()
+				return ctx.Err()
+			}
+			log.Printf("Error reading message: %v", err)
+			continue
+		}
+
+		p.offsets.track(msg)
+		atomic.AddInt64(&p.inFlight, 1)
+
+		// Dispatch to the recipient's worker (blocks if its queue is full)
+		worker := p.workers[p.route(msg)]
+		select {
+		case worker.jobChannel <- msg:
+		case <-ctx.Done():
+			// The message was never handed off, so leave its offset uncommitted
+			// and let it be redelivered after restart
+			atomic.AddInt64(&p.inFlight, -1)
+			p.shutdown()
+			return ctx.Err()
+		}
+	}
+}
+
+// route picks the worker for a message, keyed by recipient so per-recipient
+// ordering is preserved. Falls back to the message key if the payload can't be read.
+func (p *NotificationProcessor) route(msg kafka.Message) int {
+	var envelope struct {
+		RecipientEmail string `json:"recipient_email"`
+	}
+
+	key := msg.Key
+	if err := json.Unmarshal(msg.Value, &envelope); err == nil && envelope.RecipientEmail != "" {
+		key = []byte(envelope.RecipientEmail)
+	}
+
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % uint32(len(p.workers)))
+}
+
+// NotificationWorker methods
+func (w *NotificationWorker) start() {
+	defer w.processor.wg.Done()
+
+	for job := range w.jobChannel {
+		if attempts, err := w.processor.process(job); err != nil {
+			log.Printf("Worker %d error processing notification: %v", w.id, err)
+			atomic.AddInt64(&w.processor.failedCount, 1)
+			if err := w.processor.deadLetter(job, err, attempts); err != nil {
+				// Without a commit the partition's offset stays before this
+				// message, so it is redelivered after restart or rebalance
+				log.Printf("Worker %d leaving notification uncommitted: %v", w.id, err)
+				atomic.AddInt64(&w.processor.inFlight, -1)
+				continue
+			}
+		}
+
+		w.processor.commit(job)
+		atomic.AddInt64(&w.processor.processedCount, 1)
+		atomic.AddInt64(&w.processor.inFlight, -1)
+	}
+
+	log.Printf("Worker %d shutting down", w.id)
+}
+
+// process handles a notification, retrying failures up to maxAttempts times,
+// and returns the attempts made. Messages that can't be decoded aren't retried.
+func (p *NotificationProcessor) process(msg kafka.Message) (int, error) {
+	var err error
+	for attempt := 1; attempt <= p.maxAttempts; attempt++ {
+		if err = processNotification(msg, p.throttle); err == nil {
+			return attempt, nil
+		}
+		var decodeErr *decodeError
+		if errors.As(err, &decodeErr) {
+			log.Printf("Undecodable notification at offset %d on partition %d with key %q: %v; payload: %s",
+				msg.Offset, msg.Partition, msg.Key, err, payloadExcerpt(msg.Value))
+			return attempt, err
+		}
+		if attempt < p.maxAttempts {
+			log.Printf("Notification at offset %d on partition %d failed (attempt %d/%d): %v",
+				msg.Offset, msg.Partition, attempt, p.maxAttempts, err)
+			time.Sleep(time.Duration(attempt) * retryBackoff)
+		}
+	}
+	return p.maxAttempts, err
+}
+
+// deadLetter publishes a notification that failed for good to the DLQ topic,
+// recording why it failed in the message headers
+func (p *NotificationProcessor) deadLetter(msg kafka.Message, cause error, attempts int) error {
+	// Use a fresh context so failures are still dead-lettered during shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := p.queue.Produce(ctx, kafka.Message{
+		Topic: p.dlqTopic,
+		Key:   msg.Key,
+		Value: msg.Value,
+		Headers: []kafka.Header{
+			{Key: model.DLQHeaderError, Value: []byte(cause.Error())},
+			{Key: model.DLQHeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+			{Key: model.DLQHeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+			{Key: model.DLQHeaderPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+			{Key: model.DLQHeaderOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dead-letter notification at offset %d on partition %d: %w",
+			msg.Offset, msg.Partition, err)
+	}
+	atomic.AddInt64(&p.deadLetteredCount, 1)
+	return nil
+}
+
+// commit marks a message as finished and commits the highest offset whose
+// predecessors on the same partition have all finished too
+func (p *NotificationProcessor) commit(msg kafka.Message) {
+	p.offsets.complete(msg, func(committable kafka.Message) {
+		// Use a fresh context so in-flight work still commits during shutdown
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := p.consumer.Commit(ctx, committable); err != nil {
+			log.Printf("Error committing offset %d on partition %d: %v",
+				committable.Offset, committable.Partition, err)
+		}
+	})
+}
+
+// shutdown drains the worker queues and waits for in-flight notifications
+func (p *NotificationProcessor) shutdown() {
+	log.Println("Shutting down notification processor workers...")
+
+	for _, worker := range p.workers {
+		close(worker.jobChannel)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Println("All workers finished gracefully")
+	case <-time.After(30 * time.Second):
+		log.Println("Shutdown timeout reached, forcing exit")
+	}
+}
+
+// reportMetrics logs performance metrics
+func (p *NotificationProcessor) reportMetrics(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			log.Printf("Notification Processor Metrics - Processed: %d, Failed: %d, Dead-lettered: %d, In Flight: %d, Send rate: %.2f/s",
+				atomic.LoadInt64(&p.processedCount),
+				atomic.LoadInt64(&p.failedCount),
+				atomic.LoadInt64(&p.deadLetteredCount),
+				atomic.LoadInt64(&p.inFlight),
+				p.throttle.currentRate())
+		}
+	}
+}
+
+// offsetTracker records fetched messages per partition so offsets are only
+// committed once every earlier message on that partition has been processed
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionOffsets
+}
+
+type partitionOffsets struct {
+	pending []kafka.Message
+	done    map[int64]bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[int]*partitionOffsets)}
+}
+
+// track registers a message in fetch order
+func (t *offsetTracker) track(msg kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	partition, ok := t.partitions[msg.Partition]
+	if !ok {
+		partition = &partitionOffsets{done: make(map[int64]bool)}
+		t.partitions[msg.Partition] = partition
+	}
+	partition.pending = append(partition.pending, msg)
+}
+
+// complete marks a message as processed and, if that extends the finished
+// prefix of its partition, invokes commit with the last message in that prefix.
+// commit runs under the lock so commits for a partition never go backwards.
+func (t *offsetTracker) complete(msg kafka.Message, commit func(kafka.Message)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	partition, ok := t.partitions[msg.Partition]
+	if !ok {
+		return
+	}
+	partition.done[msg.Offset] = true
+
+	var committable *kafka.Message
+	for len(partition.pending) > 0 && partition.done[partition.pending[0].Offset] {
+		head := partition.pending[0]
+		delete(partition.done, head.Offset)
+		partition.pending = partition.pending[1:]
+		committable = &head
+	}
+
+	if committable != nil {
+		commit(*committable)
+	}
+}
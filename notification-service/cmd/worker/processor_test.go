@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arunvm123/eventbooking/notification-service/model"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/segmentio/kafka-go"
+)
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func TestGarbageNotificationGoesStraightToDLQ(t *testing.T) {
+	mq := queue.NewMemoryQueue(10)
+	processor := NewNotificationProcessor(nil, mq, "notifications-dlq", nil, 1, 1, 3)
+	dlq := mq.Consume("notifications-dlq", "test")
+
+	garbage := kafka.Message{Partition: 1, Offset: 7, Key: []byte("ana@example.com"), Value: []byte("<html>not json")}
+	attempts, err := processor.process(garbage)
+
+	var decodeErr *decodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("process() error = %v, want a decode error", err)
+	}
+	if attempts != 1 {
+		t.Errorf("process() made %d attempts, want 1: decoding never succeeds on retry", attempts)
+	}
+
+	if err := processor.deadLetter(garbage, err, attempts); err != nil {
+		t.Fatalf("deadLetter() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	dead, err := dlq.Fetch(ctx)
+	if err != nil {
+		t.Fatalf("nothing dead-lettered: %v", err)
+	}
+	if string(dead.Key) != "ana@example.com" || string(dead.Value) != string(garbage.Value) {
+		t.Errorf("dead letter = %q: %q, want the original key and payload", dead.Key, dead.Value)
+	}
+	if cause := header(dead, model.DLQHeaderError); !strings.HasPrefix(cause, "failed to decode notification request") {
+		t.Errorf("%s header = %q, want the decode error", model.DLQHeaderError, cause)
+	}
+	if header(dead, model.DLQHeaderAttempts) != "1" || header(dead, model.DLQHeaderPartition) != "1" || header(dead, model.DLQHeaderOffset) != "7" {
+		t.Errorf("headers = %v, want 1 attempt from partition 1 offset 7", dead.Headers)
+	}
+}
+
+func TestPayloadExcerptTruncates(t *testing.T) {
+	short := []byte("garbage")
+	if got := payloadExcerpt(short); got != `"garbage"` {
+		t.Errorf("payloadExcerpt(%q) = %s", short, got)
+	}
+
+	long := []byte(strings.Repeat("x", maxLoggedPayload+100))
+	got := payloadExcerpt(long)
+	if !strings.HasSuffix(got, "... (612 bytes)") || len(got) > maxLoggedPayload+30 {
+		t.Errorf("payloadExcerpt of %d bytes = %q, want it cut at %d", len(long), got, maxLoggedPayload)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// sendRateWindow is how often the current send rate is sampled
+const sendRateWindow = 5 * time.Second
+
+// emailThrottle caps outgoing emails with a token bucket so bursts don't exceed
+// the SMTP provider's limits. Sends block until a token is available instead of
+// being dropped, and the message offset is only committed after the send, so a
+// throttled message is never lost.
+type emailThrottle struct {
+	limiter *rate.Limiter // nil when sending is unlimited
+
+	sentCount      int64
+	throttledCount int64
+	sendRate       uint64 // float64 bits, emails per second over the last window
+}
+
+// newEmailThrottle allows perSecond emails with bursts of up to burst. A
+// non-positive perSecond disables throttling.
+func newEmailThrottle(perSecond float64, burst int) *emailThrottle {
+	if perSecond <= 0 {
+		return &emailThrottle{}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &emailThrottle{limiter: rate.NewLimiter(rate.Limit(perSecond), burst)}
+}
+
+// wait blocks until an email may be sent. It deliberately ignores shutdown so
+// messages already handed to a worker are still sent while the pool drains.
+func (t *emailThrottle) wait() {
+	if t.limiter == nil {
+		return
+	}
+	if delay := t.limiter.Reserve().Delay(); delay > 0 {
+		atomic.AddInt64(&t.throttledCount, 1)
+		time.Sleep(delay)
+	}
+}
+
+// recordSent counts a successfully sent email
+func (t *emailThrottle) recordSent() {
+	atomic.AddInt64(&t.sentCount, 1)
+}
+
+// currentRate returns the emails per second sent during the last window
+func (t *emailThrottle) currentRate() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&t.sendRate))
+}
+
+// sampleRate updates the current send rate until the context is cancelled
+func (t *emailThrottle) sampleRate(ctx context.Context) {
+	ticker := time.NewTicker(sendRateWindow)
+	defer ticker.Stop()
+
+	last := atomic.LoadInt64(&t.sentCount)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sent := atomic.LoadInt64(&t.sentCount)
+			perSecond := float64(sent-last) / sendRateWindow.Seconds()
+			atomic.StoreUint64(&t.sendRate, math.Float64bits(perSecond))
+			last = sent
+		}
+	}
+}
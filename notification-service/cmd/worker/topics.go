@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// TopicSettings controls how missing topics are created
+type TopicSettings struct {
+	Partitions        int
+	ReplicationFactor int
+}
+
+// EnsureTopics creates any of the given topics that don't exist yet. It is meant
+// for local and development clusters; production topics are provisioned separately.
+func EnsureTopics(brokers []string, topics []string, settings TopicSettings) error {
+	if len(brokers) == 0 {
+		return fmt.Errorf("no kafka brokers configured")
+	}
+
+	conn, err := kafka.Dial("tcp", brokers[0])
+	if err != nil {
+		return fmt.Errorf("failed to connect to kafka: %w", err)
+	}
+	defer conn.Close()
+
+	// Count partitions of the topics that already exist
+	partitions, err := conn.ReadPartitions()
+	if err != nil {
+		return fmt.Errorf("failed to read kafka partitions: %w", err)
+	}
+	existing := make(map[string]int)
+	for _, partition := range partitions {
+		existing[partition.Topic]++
+	}
+
+	var missing []kafka.TopicConfig
+	for _, topic := range topics {
+		if count, ok := existing[topic]; ok {
+			log.Printf("Kafka topic %s already exists with %d partitions", topic, count)
+			continue
+		}
+		missing = append(missing, kafka.TopicConfig{
+			Topic:             topic,
+			NumPartitions:     settings.Partitions,
+			ReplicationFactor: settings.ReplicationFactor,
+		})
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// Topics must be created through the controller broker
+	controller, err := conn.Controller()
+	if err != nil {
+		return fmt.Errorf("failed to find kafka controller: %w", err)
+	}
+	controllerConn, err := kafka.Dial("tcp", net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to kafka controller: %w", err)
+	}
+	defer controllerConn.Close()
+
+	if err := controllerConn.CreateTopics(missing...); err != nil {
+		return fmt.Errorf("failed to create kafka topics: %w", err)
+	}
+
+	for _, topic := range missing {
+		log.Printf("Created kafka topic %s with %d partitions (replication factor %d)",
+			topic.Topic, topic.NumPartitions, topic.ReplicationFactor)
+	}
+
+	return nil
+}
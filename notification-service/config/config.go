@@ -3,20 +3,92 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/arunvm123/eventbooking/shared/startup"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	Port  string `yaml:"port" env:"PORT" env-default:"8084"`
-	Kafka Kafka  `yaml:"kafka"`
-	Email Email  `yaml:"email"`
+	Port             string           `yaml:"port" env:"PORT" env-default:"8084"`
+	JWTSecret        string           `yaml:"jwt_secret" env:"JWT_SECRET"` // Admin endpoints are disabled when empty
+	Kafka            Kafka            `yaml:"kafka"`
+	Queue            Queue            `yaml:"queue"`
+	Email            Email            `yaml:"email"`
+	HealthAggregator HealthAggregator `yaml:"health_aggregator"`
+	Worker           Worker           `yaml:"worker"`
+	DLQ              DLQ              `yaml:"dlq"`
+	Startup          Startup          `yaml:"startup"`
+}
+
+// Startup bounds how long the worker waits at boot for Kafka to accept
+// connections when creating topics, before exiting
+type Startup struct {
+	ConnectAttempts int `yaml:"connect_attempts" env:"STARTUP_CONNECT_ATTEMPTS" env-default:"10"`
+	// Wait after the first failed attempt, doubling per attempt up to the max
+	ConnectBackoffMillis    int `yaml:"connect_backoff_millis" env:"STARTUP_CONNECT_BACKOFF_MILLIS" env-default:"1000"`
+	ConnectMaxBackoffMillis int `yaml:"connect_max_backoff_millis" env:"STARTUP_CONNECT_MAX_BACKOFF_MILLIS" env-default:"15000"`
+}
+
+// Retry returns the connection budget for the shared startup helper
+func (s *Startup) Retry() startup.Retry {
+	return startup.Retry{
+		Attempts:   s.ConnectAttempts,
+		Backoff:    time.Duration(s.ConnectBackoffMillis) * time.Millisecond,
+		MaxBackoff: time.Duration(s.ConnectMaxBackoffMillis) * time.Millisecond,
+	}
+}
+
+// DLQ configures inspection of dead-lettered notifications by the API
+type DLQ struct {
+	// Most recent dead-lettered notifications kept in memory for inspection
+	BufferSize int `yaml:"buffer_size" env:"DLQ_BUFFER_SIZE" env-default:"500"`
+}
+
+type Worker struct {
+	// Number of concurrent notification workers
+	MaxWorkers int `yaml:"max_workers" env:"WORKER_MAX_WORKERS" env-default:"10"`
+
+	// Buffered messages per worker before the consumer blocks
+	QueueSize int `yaml:"queue_size" env:"WORKER_QUEUE_SIZE" env-default:"100"`
+
+	// Port for the worker's /livez, /readyz and /metrics endpoints
+	HealthPort string `yaml:"health_port" env:"WORKER_HEALTH_PORT" env-default:"9084"`
+
+	// Attempts per notification before it is moved to the dead-letter topic
+	MaxAttempts int `yaml:"max_attempts" env:"WORKER_MAX_ATTEMPTS" env-default:"3"`
+}
+
+// HealthAggregator configures the combined /health/all endpoint
+type HealthAggregator struct {
+	// Services to probe, as name=url pairs
+	Services      []string `yaml:"services" env:"HEALTH_AGGREGATOR_SERVICES" env-separator:"," env-default:"user-service=http://user-service:8081/health,event-service=http://event-service:8082/health,booking-service=http://booking-service-api:8083/health,notification-service=http://localhost:8084/health"`
+	TimeoutMillis int      `yaml:"timeout_millis" env:"HEALTH_AGGREGATOR_TIMEOUT_MILLIS" env-default:"2000"`
 }
 
 type Kafka struct {
 	Brokers           []string `yaml:"brokers" env:"KAFKA_BROKERS" env-default:"localhost:9092" env-separator:","`
 	NotificationTopic string   `yaml:"notification_topic" env:"KAFKA_NOTIFICATION_TOPIC" env-default:"notification-requests"`
 	ConsumerGroup     string   `yaml:"consumer_group" env:"KAFKA_CONSUMER_GROUP" env-default:"notification-service"`
+
+	// Where a consumer group with no committed offsets starts: "latest" so a
+	// new group doesn't resend every email still on the topic, or "earliest"
+	StartOffset string `yaml:"start_offset" env:"KAFKA_START_OFFSET" env-default:"latest"`
+
+	// Notifications that still fail after all attempts are published here
+	DLQTopic string `yaml:"dlq_topic" env:"KAFKA_NOTIFICATION_DLQ_TOPIC" env-default:"notification-requests-dlq"`
+
+	// Dev-only: create missing topics at startup. Production topics are provisioned separately.
+	AutoCreateTopics       bool `yaml:"auto_create_topics" env:"KAFKA_AUTO_CREATE_TOPICS" env-default:"false"`
+	TopicPartitions        int  `yaml:"topic_partitions" env:"KAFKA_TOPIC_PARTITIONS" env-default:"3"`
+	TopicReplicationFactor int  `yaml:"topic_replication_factor" env:"KAFKA_TOPIC_REPLICATION_FACTOR" env-default:"1"`
+}
+
+// Queue selects the message queue notifications are consumed from
+type Queue struct {
+	// Only "kafka" is supported by the worker, as notifications are produced by
+	// other services; the in-memory queue serves single-process test setups
+	Driver string `yaml:"driver" env:"MESSAGE_QUEUE" env-default:"kafka"`
 }
 
 type Email struct {
@@ -26,6 +98,10 @@ type Email struct {
 	SMTPPassword string `yaml:"smtp_password" env:"SMTP_PASSWORD" env-default:""`
 	FromEmail    string `yaml:"from_email" env:"FROM_EMAIL" env-default:"noreply@eventbooking.com"`
 	FromName     string `yaml:"from_name" env:"FROM_NAME" env-default:"Event Booking System"`
+
+	// Emails per second allowed by the SMTP provider; 0 disables throttling
+	RateLimit float64 `yaml:"rate_limit" env:"EMAIL_RATE_LIMIT" env-default:"10"`
+	RateBurst int     `yaml:"rate_burst" env:"EMAIL_RATE_BURST" env-default:"10"`
 }
 
 func Initialise(configPath string, useEnv bool) (*Config, error) {
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/arunvm123/eventbooking/notification-service/model"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	defaultDLQListLimit = 50
+
+	// dlqDiscoveryInterval is how often Run retries finding the DLQ topic's partitions
+	dlqDiscoveryInterval = 30 * time.Second
+
+	// dlqReplayedFromHeader marks replayed notifications with their DLQ entry ID
+	dlqReplayedFromHeader = "dlq-replayed-from"
+)
+
+var (
+	errDLQEntryNotFound = errors.New("dlq entry not found")
+	errDLQEntryReplayed = errors.New("dlq entry already replayed")
+)
+
+// DLQBuffer tails the dead-letter topic and keeps the most recent entries in
+// memory so operators can inspect and replay them. Every partition is read from
+// the beginning on startup, so the buffer is rebuilt after a restart; replay
+// markers are not persisted.
+type DLQBuffer struct {
+	brokers  []string
+	topic    string
+	replay   *kafka.Writer
+	capacity int
+
+	mu      sync.RWMutex
+	entries []*model.DLQEntry // oldest first
+	byID    map[string]*model.DLQEntry
+}
+
+// NewDLQBuffer creates a buffer holding up to capacity entries from the DLQ
+// topic. Replayed entries are published with the replay writer.
+func NewDLQBuffer(brokers []string, topic string, replay *kafka.Writer, capacity int) *DLQBuffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &DLQBuffer{
+		brokers:  brokers,
+		topic:    topic,
+		replay:   replay,
+		capacity: capacity,
+		byID:     make(map[string]*model.DLQEntry),
+	}
+}
+
+// Run reads every partition of the DLQ topic until the context is cancelled.
+// No consumer group is used, so each API instance sees the whole topic.
+func (b *DLQBuffer) Run(ctx context.Context) error {
+	// The worker may not have created the topic yet, so keep looking for it
+	partitions, err := b.partitions()
+	for err != nil {
+		log.Printf("DLQ topic %s not readable yet, retrying in %s: %v", b.topic, dlqDiscoveryInterval, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dlqDiscoveryInterval):
+		}
+		partitions, err = b.partitions()
+	}
+
+	var wg sync.WaitGroup
+	for _, partition := range partitions {
+		wg.Add(1)
+		go func(partition int) {
+			defer wg.Done()
+			b.consume(ctx, partition)
+		}(partition)
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+func (b *DLQBuffer) partitions() ([]int, error) {
+	if len(b.brokers) == 0 {
+		return nil, fmt.Errorf("no kafka brokers configured")
+	}
+
+	conn, err := kafka.Dial("tcp", b.brokers[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kafka: %w", err)
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(b.topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partitions of %s: %w", b.topic, err)
+	}
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("topic %s has no partitions", b.topic)
+	}
+
+	ids := make([]int, len(partitions))
+	for i, partition := range partitions {
+		ids[i] = partition.ID
+	}
+	return ids, nil
+}
+
+func (b *DLQBuffer) consume(ctx context.Context, partition int) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   b.brokers,
+		Topic:     b.topic,
+		Partition: partition,
+	})
+	defer reader.Close()
+
+	if err := reader.SetOffset(kafka.FirstOffset); err != nil {
+		log.Printf("Error seeking DLQ partition %d: %v", partition, err)
+		return
+	}
+
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Error reading DLQ partition %d: %v", partition, err)
+			continue
+		}
+		b.add(toDLQEntry(msg))
+	}
+}
+
+// toDLQEntry builds an entry from a dead-lettered message and the headers the
+// worker attached to it
+func toDLQEntry(msg kafka.Message) *model.DLQEntry {
+	entry := &model.DLQEntry{
+		ID:       fmt.Sprintf("%d-%d", msg.Partition, msg.Offset),
+		FailedAt: msg.Time,
+		Key:      msg.Key,
+	}
+
+	for _, header := range msg.Headers {
+		value := string(header.Value)
+		switch header.Key {
+		case model.DLQHeaderError:
+			entry.Error = value
+		case model.DLQHeaderAttempts:
+			entry.Attempts, _ = strconv.Atoi(value)
+		case model.DLQHeaderFailedAt:
+			if failedAt, err := time.Parse(time.RFC3339, value); err == nil {
+				entry.FailedAt = failedAt.UTC()
+			}
+		}
+	}
+
+	var envelope struct {
+		Type           string `json:"type"`
+		RecipientEmail string `json:"recipient_email"`
+	}
+	if json.Valid(msg.Value) {
+		entry.Payload = json.RawMessage(msg.Value)
+		json.Unmarshal(msg.Value, &envelope)
+	} else {
+		// Keep unparseable payloads inspectable as a JSON string
+		entry.Payload, _ = json.Marshal(string(msg.Value))
+	}
+	entry.Type = envelope.Type
+	entry.RecipientEmail = envelope.RecipientEmail
+
+	return entry
+}
+
+// add appends an entry, evicting the oldest once the buffer is full
+func (b *DLQBuffer) add(entry *model.DLQEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) == b.capacity {
+		delete(b.byID, b.entries[0].ID)
+		b.entries = b.entries[1:]
+	}
+	b.entries = append(b.entries, entry)
+	b.byID[entry.ID] = entry
+}
+
+// List returns up to limit entries, most recently failed first
+func (b *DLQBuffer) List(limit int) []model.DLQEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if limit > len(b.entries) {
+		limit = len(b.entries)
+	}
+	entries := make([]model.DLQEntry, 0, limit)
+	for i := len(b.entries) - 1; i >= 0 && len(entries) < limit; i-- {
+		entries = append(entries, *b.entries[i])
+	}
+	return entries
+}
+
+// Len returns the number of buffered entries
+func (b *DLQBuffer) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.entries)
+}
+
+// Replay re-publishes an entry to the main notification topic. Entries can only
+// be replayed once per buffer lifetime so a double click doesn't send two emails.
+func (b *DLQBuffer) Replay(ctx context.Context, id string) (*model.DLQEntry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.byID[id]
+	if !ok {
+		return nil, errDLQEntryNotFound
+	}
+	if entry.ReplayedAt != nil {
+		return nil, errDLQEntryReplayed
+	}
+
+	err := b.replay.WriteMessages(ctx, kafka.Message{
+		Key:     entry.Key,
+		Value:   entry.Payload,
+		Headers: []kafka.Header{{Key: dlqReplayedFromHeader, Value: []byte(entry.ID)}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish notification: %w", err)
+	}
+
+	replayedAt := time.Now().UTC()
+	entry.ReplayedAt = &replayedAt
+	replayed := *entry
+	return &replayed, nil
+}
+
+// ListDLQHandler returns the most recent dead-lettered notifications
+func ListDLQHandler(buffer *DLQBuffer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := defaultDLQListLimit
+		if raw := c.Query("limit"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < 1 {
+				middleware.RespondError(c, http.StatusBadRequest, "invalid_limit", "limit must be a positive integer")
+				return
+			}
+			limit = min(parsed, buffer.capacity)
+		}
+
+		c.JSON(http.StatusOK, model.DLQListResponse{
+			Entries:  buffer.List(limit),
+			Buffered: buffer.Len(),
+			Capacity: buffer.capacity,
+		})
+	}
+}
+
+// ReplayDLQHandler re-publishes one dead-lettered notification to the main topic
+func ReplayDLQHandler(buffer *DLQBuffer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entry, err := buffer.Replay(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			switch {
+			case errors.Is(err, errDLQEntryNotFound):
+				middleware.RespondError(c, http.StatusNotFound, "dlq_entry_not_found", "DLQ entry not found or no longer buffered")
+			case errors.Is(err, errDLQEntryReplayed):
+				middleware.RespondError(c, http.StatusConflict, "dlq_entry_replayed", "DLQ entry has already been replayed")
+			default:
+				log.Printf("Failed to replay DLQ entry %s: %v", c.Param("id"), err)
+				middleware.RespondError(c, http.StatusInternalServerError, "replay_failed", "Failed to replay notification")
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, entry)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arunvm123/eventbooking/notification-service/config"
+	"github.com/arunvm123/eventbooking/notification-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+type healthTarget struct {
+	name string
+	url  string
+}
+
+// parseHealthTargets parses name=url pairs from configuration
+func parseHealthTargets(services []string) []healthTarget {
+	var targets []healthTarget
+	for _, service := range services {
+		parts := strings.SplitN(strings.TrimSpace(service), "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			log.Printf("Ignoring invalid health aggregator service entry: %q", service)
+			continue
+		}
+		targets = append(targets, healthTarget{name: parts[0], url: parts[1]})
+	}
+	return targets
+}
+
+// AggregateHealthHandler concurrently probes every configured service and
+// returns a combined status document
+func AggregateHealthHandler(cfg *config.HealthAggregator) gin.HandlerFunc {
+	targets := parseHealthTargets(cfg.Services)
+	timeout := time.Duration(cfg.TimeoutMillis) * time.Millisecond
+	client := &http.Client{Timeout: timeout}
+
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		results := make([]model.ServiceHealth, len(targets))
+		var wg sync.WaitGroup
+		for i, target := range targets {
+			wg.Add(1)
+			go func(i int, target healthTarget) {
+				defer wg.Done()
+				results[i] = probeService(ctx, client, target)
+			}(i, target)
+		}
+		wg.Wait()
+
+		status := "healthy"
+		for _, result := range results {
+			if result.Status != "healthy" {
+				status = "degraded"
+				break
+			}
+		}
+
+		statusCode := http.StatusOK
+		if status != "healthy" {
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		c.JSON(statusCode, model.AggregateHealthResponse{
+			Status:    status,
+			Timestamp: time.Now().UTC(),
+			Services:  results,
+		})
+	}
+}
+
+// probeService calls a single health endpoint and records status and latency
+func probeService(ctx context.Context, client *http.Client, target healthTarget) (result model.ServiceHealth) {
+	result = model.ServiceHealth{
+		Name:   target.name,
+		URL:    target.url,
+		Status: "unhealthy",
+	}
+
+	start := time.Now()
+	defer func() {
+		result.LatencyMs = time.Since(start).Milliseconds()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.url, nil)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to create request: %v", err)
+		return result
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode == http.StatusOK {
+		result.Status = "healthy"
+	} else {
+		result.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+
+	return result
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,7 +10,10 @@ import (
 
 	"github.com/arunvm123/eventbooking/notification-service/config"
 	"github.com/arunvm123/eventbooking/notification-service/model"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
 )
 
 var messagesProcessed int64
@@ -29,18 +33,55 @@ func main() {
 
 	// Setup Gin router
 	r := gin.Default()
+	r.Use(middleware.Debug())
+	r.Use(middleware.DefaultJSON())
+	r.NoRoute(func(c *gin.Context) {
+		middleware.RespondError(c, http.StatusNotFound, "not_found", "Route not found")
+	})
 
-	// Health check endpoint only
+	// Health check endpoints
 	r.GET("/health", func(c *gin.Context) {
 		response := model.HealthResponse{
 			Status:            "healthy",
 			Service:           "notification-service",
-			Timestamp:         time.Now(),
+			Timestamp:         time.Now().UTC(),
 			MessagesProcessed: atomic.LoadInt64(&messagesProcessed),
 		}
 		c.JSON(http.StatusOK, response)
 	})
 
+	// Combined health of all services for dashboards and on-call
+	r.GET("/health/all", AggregateHealthHandler(&cfg.HealthAggregator))
+
+	// Dead-letter inspection and replay for operators
+	if cfg.JWTSecret != "" {
+		replayWriter := &kafka.Writer{
+			Addr:     kafka.TCP(cfg.Kafka.Brokers...),
+			Topic:    cfg.Kafka.NotificationTopic,
+			Balancer: &kafka.Hash{},
+		}
+		defer replayWriter.Close()
+
+		dlqBuffer := NewDLQBuffer(cfg.Kafka.Brokers, cfg.Kafka.DLQTopic, replayWriter, cfg.DLQ.BufferSize)
+		go func() {
+			if err := dlqBuffer.Run(context.Background()); err != nil {
+				log.Printf("DLQ buffer stopped: %v", err)
+			}
+		}()
+
+		jwtService := auth.NewJWTService(cfg.JWTSecret)
+		admin := r.Group("/api/notifications/admin", AuthMiddleware(jwtService), RequireAdmin())
+		{
+			admin.GET("/dlq", ListDLQHandler(dlqBuffer))
+			admin.POST("/dlq/replay/:id", ReplayDLQHandler(dlqBuffer))
+		}
+
+		// Render emails from sample data to check template changes
+		r.POST("/api/notifications/preview", AuthMiddleware(jwtService), RequireAdmin(), PreviewEmailHandler())
+	} else {
+		log.Println("JWT_SECRET not set, notification admin endpoints are disabled")
+	}
+
 	// Start server
 	fmt.Printf("Starting Notification Service API on port %s\n", cfg.Port)
 	if err := r.Run(":" + cfg.Port); err != nil {
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// roleAdmin is the JWT role allowed to use the admin endpoints
+const roleAdmin = "admin"
+
+// AuthMiddleware validates JWT tokens with the shared middleware
+func AuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
+	return middleware.Auth(jwtService, middleware.RespondError, middleware.DefaultAuthErrorCodes)
+}
+
+// RequireAdmin rejects authenticated callers without the admin role
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(middleware.ContextUserRole) != roleAdmin {
+			middleware.RespondError(c, http.StatusForbidden, "forbidden", "Admin role required")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
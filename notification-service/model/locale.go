@@ -0,0 +1,180 @@
+package model
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultLocale is used for notifications without a locale or with one that
+// has no template bundle
+const DefaultLocale = "en"
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// emailTemplateNames must be defined by every locale's template bundle, each
+// as a <name>_subject and <name>_body template
+var emailTemplateNames = []string{"booking_confirmed", "booking_failed", "hold_expiring"}
+
+// localeFormat describes how a locale writes dates and amounts
+type localeFormat struct {
+	dateLayout   string // event dates
+	expiryLayout string // hold expiry times, which include the zone
+
+	decimalSeparator   string
+	thousandsSeparator string // empty for no grouping
+
+	// placeCurrency places the currency around the formatted number
+	placeCurrency func(number, currency string) string
+}
+
+// symbolAfter writes amounts as "12,50 €", falling back to the ISO code for
+// currencies without a well-known symbol
+func symbolAfter(number, currency string) string {
+	if symbol, ok := currencySymbols[currency]; ok {
+		return number + " " + symbol
+	}
+	return number + " " + currency
+}
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"INR": "₹",
+}
+
+var localeFormats = map[string]localeFormat{
+	"en": {
+		dateLayout:       "2006-01-02 15:04",
+		expiryLayout:     "2006-01-02 15:04 MST",
+		decimalSeparator: ".",
+		// Amounts keep the "$12.50" / "12.50 EUR" style of the original emails
+		placeCurrency: func(number, currency string) string {
+			if currency == "USD" {
+				return "$" + number
+			}
+			return number + " " + currency
+		},
+	},
+	"es": {
+		dateLayout:         "02/01/2006 15:04",
+		expiryLayout:       "02/01/2006 15:04 MST",
+		decimalSeparator:   ",",
+		thousandsSeparator: ".",
+		placeCurrency:      symbolAfter,
+	},
+	"fr": {
+		dateLayout:         "02/01/2006 15:04",
+		expiryLayout:       "02/01/2006 15:04 MST",
+		decimalSeparator:   ",",
+		thousandsSeparator: "\u202f", // narrow no-break space
+		placeCurrency:      symbolAfter,
+	},
+	"de": {
+		dateLayout:         "02.01.2006 15:04",
+		expiryLayout:       "02.01.2006 15:04 MST",
+		decimalSeparator:   ",",
+		thousandsSeparator: ".",
+		placeCurrency:      symbolAfter,
+	},
+}
+
+// localeBundle is a locale's email templates along with its formatting rules
+type localeBundle struct {
+	localeFormat
+	locale    string
+	templates *template.Template
+}
+
+// localeBundles holds a bundle for every locale in localeFormats, parsed from
+// the embedded templates/<locale>.tmpl files at startup
+var localeBundles = loadLocaleBundles()
+
+func loadLocaleBundles() map[string]*localeBundle {
+	bundles := make(map[string]*localeBundle, len(localeFormats))
+	for locale, format := range localeFormats {
+		templates := template.Must(template.ParseFS(templateFiles, "templates/"+locale+".tmpl"))
+		for _, name := range emailTemplateNames {
+			if templates.Lookup(name+"_subject") == nil || templates.Lookup(name+"_body") == nil {
+				panic(fmt.Sprintf("locale %s is missing the %s email template", locale, name))
+			}
+		}
+		bundles[locale] = &localeBundle{localeFormat: format, locale: locale, templates: templates}
+	}
+	return bundles
+}
+
+// bundleFor returns the bundle for a locale such as "fr" or "fr-CA", matching
+// on the language alone and falling back to English
+func bundleFor(locale string) *localeBundle {
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	if bundle, ok := localeBundles[language]; ok {
+		return bundle
+	}
+	return localeBundles[DefaultLocale]
+}
+
+// EmailLocale returns the locale whose templates are used for emails to a
+// recipient with the given locale
+func EmailLocale(locale string) string {
+	return bundleFor(locale).locale
+}
+
+// formatAmount renders an amount in the locale's number format with its currency
+func (b *localeBundle) formatAmount(amount float64, currency string) string {
+	if currency == "" {
+		currency = "USD"
+	}
+
+	number := strconv.FormatFloat(math.Abs(amount), 'f', 2, 64)
+	whole, fraction, _ := strings.Cut(number, ".")
+	if b.thousandsSeparator != "" {
+		for i := len(whole) - 3; i > 0; i -= 3 {
+			whole = whole[:i] + b.thousandsSeparator + whole[i:]
+		}
+	}
+	number = whole + b.decimalSeparator + fraction
+	if amount < 0 {
+		number = "-" + number
+	}
+
+	return b.placeCurrency(number, currency)
+}
+
+// formatDate renders an event date in the locale's layout
+func (b *localeBundle) formatDate(t time.Time) string {
+	return t.Format(b.dateLayout)
+}
+
+// formatExpiry renders a hold expiry time in the locale's layout
+func (b *localeBundle) formatExpiry(t time.Time) string {
+	return t.Format(b.expiryLayout)
+}
+
+// render builds the named email from the locale's templates
+func (b *localeBundle) render(name, to string, data emailData) *EmailTemplate {
+	return &EmailTemplate{
+		To:      to,
+		Subject: b.execute(name+"_subject", data),
+		Body:    b.execute(name+"_body", data),
+	}
+}
+
+func (b *localeBundle) execute(name string, data emailData) string {
+	var buf bytes.Buffer
+	if err := b.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		log.Printf("Failed to render email template %s: %v", name, err)
+	}
+	return buf.String()
+}
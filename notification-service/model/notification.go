@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -16,18 +17,38 @@ type NotificationRequest struct {
 	Type           string                  `json:"type"`
 	RecipientEmail string                  `json:"recipient_email"`
 	BookingData    NotificationBookingData `json:"booking_data"`
+	HoldData       *NotificationHoldData   `json:"hold_data,omitempty"`
 	Timestamp      time.Time               `json:"timestamp"`
+
+	// Locale of the recipient, e.g. "fr" or "de-AT". Emails fall back to
+	// English when it is empty or has no templates.
+	Locale string `json:"locale,omitempty"`
 }
 
 // NotificationBookingData represents booking data for notifications
 type NotificationBookingData struct {
-	BookingID   uuid.UUID `json:"booking_id"`
-	EventName   string    `json:"event_name"`
-	Venue       string    `json:"venue"`
-	EventDate   time.Time `json:"event_date"`
-	Seats       []string  `json:"seats"`
-	TotalAmount float64   `json:"total_amount"`
-	UserName    string    `json:"user_name"`
+	BookingID        uuid.UUID `json:"booking_id"`
+	ConfirmationCode string    `json:"confirmation_code"`
+	EventName        string    `json:"event_name"`
+	Venue            string    `json:"venue"`
+	EventDate        time.Time `json:"event_date"`
+	Seats            []string  `json:"seats"`
+	TotalAmount      float64   `json:"total_amount"`
+	Currency         string    `json:"currency"`
+	UserName         string    `json:"user_name"`
+
+	// Parts of TotalAmount; older messages and bookings without fees or tax
+	// leave them out
+	Subtotal float64 `json:"subtotal,omitempty"`
+	Fees     float64 `json:"fees,omitempty"`
+	Tax      float64 `json:"tax,omitempty"`
+}
+
+// NotificationHoldData represents hold details for expiry warnings (From Event Service)
+type NotificationHoldData struct {
+	HoldID      string    `json:"hold_id"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CheckoutURL string    `json:"checkout_url"`
 }
 
 // ============================================================================
@@ -45,45 +66,87 @@ type EmailTemplate struct {
 // EMAIL GENERATION METHODS
 // ============================================================================
 
+// emailData holds the values available to email templates, already formatted
+// for the recipient's locale
+type emailData struct {
+	UserName         string
+	EventName        string
+	Venue            string
+	EventDate        string
+	Seats            string
+	Amount           string
+	Subtotal         string // Only set when the booking has fees or tax
+	Fees             string
+	Tax              string
+	BookingID        string
+	ConfirmationCode string
+	HoldExpiresAt    string
+	CheckoutURL      string
+}
+
+// emailData formats the request's booking and hold details for the bundle's locale
+func (nr *NotificationRequest) emailData(bundle *localeBundle) emailData {
+	data := emailData{
+		UserName:         nr.BookingData.UserName,
+		EventName:        nr.BookingData.EventName,
+		Venue:            nr.BookingData.Venue,
+		EventDate:        bundle.formatDate(nr.BookingData.EventDate),
+		Seats:            fmt.Sprintf("%v", nr.BookingData.Seats),
+		Amount:           bundle.formatAmount(nr.BookingData.TotalAmount, nr.BookingData.Currency),
+		BookingID:        nr.BookingData.BookingID.String(),
+		ConfirmationCode: nr.BookingData.ConfirmationCode,
+	}
+	if booking := nr.BookingData; booking.Fees != 0 || booking.Tax != 0 {
+		data.Subtotal = bundle.formatAmount(booking.Subtotal, booking.Currency)
+		data.Fees = bundle.formatAmount(booking.Fees, booking.Currency)
+		data.Tax = bundle.formatAmount(booking.Tax, booking.Currency)
+	}
+
+	hold := nr.HoldData
+	if hold == nil {
+		hold = &NotificationHoldData{}
+	}
+	data.HoldExpiresAt = bundle.formatExpiry(hold.ExpiresAt)
+	data.CheckoutURL = hold.CheckoutURL
+
+	return data
+}
+
+// generateEmail renders the named email in the recipient's locale
+func (nr *NotificationRequest) generateEmail(name string) *EmailTemplate {
+	bundle := bundleFor(nr.Locale)
+	return bundle.render(name, nr.RecipientEmail, nr.emailData(bundle))
+}
+
+// GenerateEmail renders the email for the request's type, returning nil for
+// types without templates
+func (nr *NotificationRequest) GenerateEmail() *EmailTemplate {
+	switch nr.Type {
+	case "booking_confirmed":
+		return nr.GenerateBookingConfirmationEmail()
+	case "booking_failed":
+		return nr.GenerateBookingFailedEmail()
+	case "hold_expiring":
+		return nr.GenerateHoldExpiringEmail()
+	default:
+		return nil
+	}
+}
+
 // GenerateBookingConfirmationEmail creates simple email content for booking confirmation
 func (nr *NotificationRequest) GenerateBookingConfirmationEmail() *EmailTemplate {
-	subject := "Booking Confirmed - " + nr.BookingData.EventName
-
-	body := "Dear " + nr.BookingData.UserName + ",\n\n" +
-		"Your booking has been confirmed!\n\n" +
-		"Event: " + nr.BookingData.EventName + "\n" +
-		"Venue: " + nr.BookingData.Venue + "\n" +
-		"Date: " + nr.BookingData.EventDate.Format("2006-01-02 15:04") + "\n" +
-		"Seats: " + fmt.Sprintf("%v", nr.BookingData.Seats) + "\n" +
-		"Amount: $" + fmt.Sprintf("%.2f", nr.BookingData.TotalAmount) + "\n" +
-		"Booking ID: " + nr.BookingData.BookingID.String() + "\n\n" +
-		"Thank you for your booking!\n\n" +
-		"Event Booking System"
-
-	return &EmailTemplate{
-		To:      nr.RecipientEmail,
-		Subject: subject,
-		Body:    body,
-	}
+	return nr.generateEmail("booking_confirmed")
 }
 
 // GenerateBookingFailedEmail creates simple email content for booking failure
 func (nr *NotificationRequest) GenerateBookingFailedEmail() *EmailTemplate {
-	subject := "Booking Failed - " + nr.BookingData.EventName
-
-	body := "Dear " + nr.BookingData.UserName + ",\n\n" +
-		"We're sorry, but your booking could not be completed.\n\n" +
-		"Event: " + nr.BookingData.EventName + "\n" +
-		"Booking ID: " + nr.BookingData.BookingID.String() + "\n\n" +
-		"Any charges will be refunded within 3-5 business days.\n" +
-		"Please try booking again or contact support.\n\n" +
-		"Event Booking System"
-
-	return &EmailTemplate{
-		To:      nr.RecipientEmail,
-		Subject: subject,
-		Body:    body,
-	}
+	return nr.generateEmail("booking_failed")
+}
+
+// GenerateHoldExpiringEmail creates simple email content warning that held seats
+// are about to be released
+func (nr *NotificationRequest) GenerateHoldExpiringEmail() *EmailTemplate {
+	return nr.generateEmail("hold_expiring")
 }
 
 // ============================================================================
@@ -98,8 +161,85 @@ type HealthResponse struct {
 	MessagesProcessed int64     `json:"messages_processed"`
 }
 
-// ErrorResponse represents error response structure
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
+// AggregateHealthResponse represents the combined health of all services
+type AggregateHealthResponse struct {
+	Status    string          `json:"status"`
+	Timestamp time.Time       `json:"timestamp"`
+	Services  []ServiceHealth `json:"services"`
+}
+
+// ServiceHealth represents the health probe result for a single service
+type ServiceHealth struct {
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	Status     string `json:"status"`
+	StatusCode int    `json:"status_code,omitempty"`
+	LatencyMs  int64  `json:"latency_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// EmailPreviewRequest is sample notification data to render an email from
+// without sending it
+type EmailPreviewRequest struct {
+	Type           string                  `json:"type" binding:"required"`
+	RecipientEmail string                  `json:"recipient_email" binding:"omitempty,email"`
+	BookingData    NotificationBookingData `json:"booking_data"`
+	HoldData       *NotificationHoldData   `json:"hold_data"`
+	Locale         string                  `json:"locale"`
+}
+
+// ToNotificationRequest converts the sample to the message the worker would consume
+func (r *EmailPreviewRequest) ToNotificationRequest() NotificationRequest {
+	return NotificationRequest{
+		Type:           r.Type,
+		RecipientEmail: r.RecipientEmail,
+		BookingData:    r.BookingData,
+		HoldData:       r.HoldData,
+		Timestamp:      time.Now().UTC(),
+		Locale:         r.Locale,
+	}
+}
+
+// EmailPreviewResponse is a rendered email. Locale is the template locale
+// used, which is English when the requested one has no templates.
+type EmailPreviewResponse struct {
+	Type    string `json:"type"`
+	Locale  string `json:"locale"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// ============================================================================
+// DEAD-LETTER QUEUE
+// ============================================================================
+
+// Kafka headers set by the worker on dead-lettered notifications
+const (
+	DLQHeaderError     = "dlq-error"
+	DLQHeaderAttempts  = "dlq-attempts"
+	DLQHeaderFailedAt  = "dlq-failed-at"
+	DLQHeaderPartition = "dlq-original-partition"
+	DLQHeaderOffset    = "dlq-original-offset"
+)
+
+// DLQEntry represents a dead-lettered notification held for inspection
+type DLQEntry struct {
+	ID             string          `json:"id"` // <partition>-<offset> on the DLQ topic
+	Type           string          `json:"type"`
+	RecipientEmail string          `json:"recipient_email"`
+	Error          string          `json:"error"`
+	Attempts       int             `json:"attempts"`
+	FailedAt       time.Time       `json:"failed_at"`
+	Payload        json.RawMessage `json:"payload"`
+	ReplayedAt     *time.Time      `json:"replayed_at,omitempty"`
+
+	Key []byte `json:"-"`
+}
+
+// DLQListResponse represents the response for listing dead-lettered notifications
+type DLQListResponse struct {
+	Entries  []DLQEntry `json:"entries"`
+	Buffered int        `json:"buffered"`
+	Capacity int        `json:"capacity"`
 }
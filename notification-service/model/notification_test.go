@@ -0,0 +1,82 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func confirmedBooking(locale string) *NotificationRequest {
+	return &NotificationRequest{
+		Type:           "booking_confirmed",
+		RecipientEmail: "ana@example.com",
+		Locale:         locale,
+		BookingData: NotificationBookingData{
+			ConfirmationCode: "ABC123",
+			EventName:        "Jazz Night",
+			Venue:            "Blue Note",
+			EventDate:        time.Date(2026, 7, 1, 19, 30, 0, 0, time.UTC),
+			Seats:            []string{"A1", "A2"},
+			TotalAmount:      1234.5,
+			Currency:         "EUR",
+			UserName:         "Ana",
+		},
+	}
+}
+
+func TestGenerateEmailLocalized(t *testing.T) {
+	tests := []struct {
+		locale      string
+		wantSubject string
+		wantBody    []string
+	}{
+		{"de", "Buchung bestätigt - Jazz Night", []string{"Datum: 01.07.2026 19:30", "Betrag: 1.234,50 €"}},
+		{"de-AT", "Buchung bestätigt - Jazz Night", []string{"Datum: 01.07.2026 19:30"}},
+		{"fr", "Réservation confirmée - Jazz Night", []string{"Date : 01/07/2026 19:30", "Montant : 1\u202f234,50 €"}},
+		{"es", "Reserva confirmada - Jazz Night", []string{"Fecha: 01/07/2026 19:30", "Importe: 1.234,50 €"}},
+		{"en", "Booking Confirmed - Jazz Night", []string{"2026-07-01 19:30", "1234.50 EUR"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			email := confirmedBooking(tt.locale).GenerateEmail()
+			if email.Subject != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", email.Subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(email.Body, want) {
+					t.Errorf("body lacks %q:\n%s", want, email.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestEmailLocaleFallsBackToEnglish(t *testing.T) {
+	for _, locale := range []string{"", "ja", "pt-BR", "xx"} {
+		if got := EmailLocale(locale); got != DefaultLocale {
+			t.Errorf("EmailLocale(%q) = %q, want %q", locale, got, DefaultLocale)
+		}
+		if subject := confirmedBooking(locale).GenerateEmail().Subject; subject != "Booking Confirmed - Jazz Night" {
+			t.Errorf("subject for %q = %q, want the English one", locale, subject)
+		}
+	}
+}
+
+func TestEmailGreetsCustomerWithoutName(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en", "Dear Customer,"},
+		{"de", "Hallo,"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			req := confirmedBooking(tt.locale)
+			req.BookingData.UserName = ""
+			if body := req.GenerateEmail().Body; !strings.HasPrefix(body, tt.want) {
+				t.Errorf("body starts %q, want %q", strings.SplitN(body, "\n", 2)[0], tt.want)
+			}
+		})
+	}
+}
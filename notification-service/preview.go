@@ -0,0 +1,37 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/arunvm123/eventbooking/notification-service/model"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewEmailHandler renders the email a notification would produce, using
+// the worker's templates, without sending anything. It lets copy changes be
+// checked against sample booking data.
+func PreviewEmailHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req model.EmailPreviewRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+			return
+		}
+
+		notification := req.ToNotificationRequest()
+		email := notification.GenerateEmail()
+		if email == nil {
+			middleware.RespondError(c, http.StatusBadRequest, "validation_failed", "No email template for type "+req.Type)
+			return
+		}
+
+		c.JSON(http.StatusOK, model.EmailPreviewResponse{
+			Type:    req.Type,
+			Locale:  model.EmailLocale(req.Locale),
+			To:      email.To,
+			Subject: email.Subject,
+			Body:    email.Body,
+		})
+	}
+}
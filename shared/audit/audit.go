@@ -0,0 +1,157 @@
+// Package audit publishes a trail of security-relevant actions, such as logins,
+// deletions and refunds, to a message queue topic that is persisted append-only.
+// Publishing never blocks or fails the action being audited: delivery errors
+// are only logged.
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+// DefaultTopic is the topic audit events are published to unless configured
+const DefaultTopic = "audit-events"
+
+// Audited actions
+const (
+	ActionLoginSucceeded  = "user.login_succeeded"
+	ActionLoginFailed     = "user.login_failed"
+	ActionAccountDeleted  = "user.account_deleted"
+	ActionEventDeleted    = "event.deleted"
+	ActionHoldReleased    = "hold.released_by_admin"
+	ActionSeatsUpdated    = "seats.status_updated"
+	ActionPaymentRefunded = "payment.refunded"
+
+	ActionBookingReprocessed = "booking.reprocessed"
+
+	ActionMaintenanceChanged = "maintenance.changed"
+)
+
+// ActorSystem is the actor role of actions taken by a service on its own,
+// e.g. a worker refunding a failed booking
+const ActorSystem = "system"
+
+// Target identifies what an action was taken on, e.g. Target("event", id)
+func Target(kind, id string) string {
+	return kind + "/" + id
+}
+
+// Event is one audited action
+type Event struct {
+	ID         string            `json:"id"`
+	Action     string            `json:"action"`
+	ActorID    string            `json:"actor_id,omitempty"`
+	ActorRole  string            `json:"actor_role,omitempty"`
+	Target     string            `json:"target"`
+	Service    string            `json:"service"`
+	RequestID  string            `json:"request_id,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Details    map[string]string `json:"details,omitempty"`
+}
+
+// Events waiting to be published; more are dropped with a log line
+const pendingEvents = 1000
+
+// publishTimeout bounds each attempt to publish an event
+const publishTimeout = 10 * time.Second
+
+// Recorder publishes a service's audit events. Without a queue it only logs
+// them, so services running without Kafka still leave a trail.
+type Recorder struct {
+	service string
+	mq      queue.MessageQueue
+	topic   string
+	pending chan kafka.Message
+	done    chan struct{}
+}
+
+// NewRecorder creates the recorder for service, publishing to topic on mq, or
+// only logging when mq is nil. Events are published in the background, one at
+// a time and keyed by target, so the actions on one target stay in order.
+func NewRecorder(service string, mq queue.MessageQueue, topic string) *Recorder {
+	r := &Recorder{service: service}
+	if mq == nil {
+		log.Printf("No message queue configured, audit events will only be logged")
+		return r
+	}
+	r.mq = mq
+	r.topic = topic
+	r.pending = make(chan kafka.Message, pendingEvents)
+	r.done = make(chan struct{})
+	go r.publish()
+	return r
+}
+
+// publish sends pending events until Close
+func (r *Recorder) publish() {
+	defer close(r.done)
+	for msg := range r.pending {
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		err := r.mq.Produce(ctx, msg)
+		cancel()
+		if err != nil {
+			log.Printf("Failed to publish audit event %s: %v", msg.Value, err)
+		}
+	}
+}
+
+// Record publishes the event, filling in its ID, service and time
+func (r *Recorder) Record(ctx context.Context, event Event) {
+	event.ID = newEventID()
+	event.Service = r.service
+	event.OccurredAt = time.Now().UTC()
+
+	value, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal audit event %s on %s: %v", event.Action, event.Target, err)
+		return
+	}
+	if r.mq == nil {
+		log.Printf("Audit: %s", value)
+		return
+	}
+	select {
+	case r.pending <- kafka.Message{Topic: r.topic, Key: []byte(event.Target), Value: value}:
+	default:
+		log.Printf("Audit backlog full, dropped event %s", value)
+	}
+}
+
+// RecordRequest publishes an action taken by the authenticated caller of the
+// request, with the request's ID
+func (r *Recorder) RecordRequest(c *gin.Context, action, target string, details map[string]string) {
+	r.Record(c.Request.Context(), Event{
+		Action:    action,
+		ActorID:   c.GetString(middleware.ContextUserID),
+		ActorRole: c.GetString(middleware.ContextUserRole),
+		Target:    target,
+		RequestID: c.GetString(middleware.ContextRequestID),
+		Details:   details,
+	})
+}
+
+// Close flushes pending events. Nothing may be recorded afterwards.
+func (r *Recorder) Close() error {
+	if r.mq == nil {
+		return nil
+	}
+	close(r.pending)
+	<-r.done
+	return nil
+}
+
+// newEventID returns a random ID that makes redelivered events recognizable
+func newEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,63 @@
+// Package auth issues and validates the JWTs shared by all services.
+package auth
+
+import "github.com/golang-jwt/jwt/v5"
+
+// Claims represents the JWT claims issued by the user service and accepted by
+// every service
+type Claims struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	Role   string `json:"role,omitempty"`
+
+	// Locale is the user's preferred language for emails, e.g. "fr"
+	Locale string `json:"locale,omitempty"`
+
+	// HoldID scopes a guest token to the one hold it was issued for
+	HoldID string `json:"hold_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// RoleGuest marks tokens issued to buyers without an account. They are only
+// valid for completing or releasing the hold named in HoldID.
+const RoleGuest = "guest"
+
+// RoleService marks tokens services issue to call each other
+const RoleService = "service"
+
+// IsGuest reports whether the claims belong to a guest token
+func (c *Claims) IsGuest() bool {
+	return c.Role == RoleGuest
+}
+
+// JWTService handles JWT operations
+type JWTService struct {
+	secretKey []byte
+}
+
+func NewJWTService(secretKey string) *JWTService {
+	return &JWTService{secretKey: []byte(secretKey)}
+}
+
+// GenerateToken signs the claims with HS256
+func (j *JWTService) GenerateToken(claims Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(j.secretKey)
+}
+
+// ValidateToken validates a JWT token and returns the claims
+func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		return j.secretKey, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, jwt.ErrInvalidKey
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// JSONContentType is the Content-Type of JSON API responses
+const JSONContentType = "application/json; charset=utf-8"
+
+// DefaultJSON declares response bodies JSON unless the handler set a
+// Content-Type of its own, as SSE streams and metrics do. Without a declared
+// type net/http sniffs one from the body, which some proxies then act on.
+// Responses without a body get no Content-Type.
+func DefaultJSON() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &defaultJSONWriter{ResponseWriter: c.Writer}
+		c.Next()
+	}
+}
+
+// defaultJSONWriter sets the JSON Content-Type before the first body write
+// when none was set
+type defaultJSONWriter struct {
+	gin.ResponseWriter
+}
+
+func (w *defaultJSONWriter) setContentType() {
+	if !w.Written() && w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", JSONContentType)
+	}
+}
+
+func (w *defaultJSONWriter) Write(data []byte) (int, error) {
+	w.setContentType()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *defaultJSONWriter) WriteString(s string) (int, error) {
+	w.setContentType()
+	return w.ResponseWriter.WriteString(s)
+}
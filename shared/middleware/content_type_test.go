@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestResponseContentTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(DefaultJSON())
+	r.NoRoute(func(c *gin.Context) {
+		RespondError(c, http.StatusNotFound, "not_found", "Route not found")
+	})
+	r.GET("/json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	r.GET("/raw", func(c *gin.Context) {
+		c.Writer.WriteString(`{"ok":true}`)
+	})
+	r.GET("/stream", func(c *gin.Context) {
+		c.SSEvent("status", gin.H{"status": "pending"})
+	})
+	r.GET("/empty", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	r.GET("/error", func(c *gin.Context) {
+		RespondError(c, http.StatusBadRequest, "validation_failed", "Invalid request")
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		accept string
+		want   string
+	}{
+		{"JSON", "/json", "", JSONContentType},
+		{"raw write", "/raw", "", JSONContentType},
+		{"SSE keeps its type", "/stream", "", "text/event-stream"},
+		{"no body", "/empty", "", ""},
+		{"error", "/error", "", JSONContentType},
+		{"problem error", "/error", "application/problem+json", ProblemJSONContentType + "; charset=utf-8"},
+		{"unknown route", "/missing", "", JSONContentType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Content-Type"); got != tt.want {
+				t.Errorf("Content-Type = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextRequestID is the context key holding the request ID set by Debug
+const ContextRequestID = "request_id"
+
+// APIVersion is the version of the services' API, reported by Debug
+const APIVersion = "v1"
+
+// Headers used by Debug
+const (
+	DebugHeader        = "X-Debug"
+	RequestIDHeader    = "X-Request-ID"
+	ResponseTimeHeader = "X-Response-Time"
+	APIVersionHeader   = "X-API-Version"
+)
+
+// Debug assigns every request an ID, reusing the client's X-Request-ID when
+// sent, so log lines can be correlated with client reports. When the request
+// carries "X-Debug: true" the response also gets X-Request-ID, X-Response-Time
+// and X-API-Version headers. Response bodies are never changed.
+func Debug() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		c.Set(ContextRequestID, requestID)
+
+		if !strings.EqualFold(c.GetHeader(DebugHeader), "true") {
+			c.Next()
+			return
+		}
+
+		// Headers must be set before the status line is written, which happens
+		// inside the handler, so inject them from a wrapped writer
+		writer := &debugWriter{ResponseWriter: c.Writer}
+		writer.setHeaders = func() {
+			header := writer.Header()
+			header.Set(RequestIDHeader, requestID)
+			header.Set(ResponseTimeHeader, time.Since(start).String())
+			header.Set(APIVersionHeader, APIVersion)
+		}
+		c.Writer = writer
+
+		c.Next()
+
+		// Responses without a body are written by gin after the handlers return
+		writer.inject()
+	}
+}
+
+// debugWriter sets the debug headers just before the response is first written
+type debugWriter struct {
+	gin.ResponseWriter
+	once       sync.Once
+	setHeaders func()
+}
+
+func (w *debugWriter) inject() {
+	if !w.ResponseWriter.Written() {
+		w.once.Do(w.setHeaders)
+	}
+}
+
+func (w *debugWriter) WriteHeaderNow() {
+	w.inject()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *debugWriter) Write(data []byte) (int, error) {
+	w.inject()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *debugWriter) WriteString(s string) (int, error) {
+	w.inject()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// newRequestID returns a random 128-bit hex ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LegacyAPIBasePath is the unversioned prefix routes were served under before
+// API versioning. It stays registered as an alias while clients migrate.
+const LegacyAPIBasePath = "/api"
+
+// NormalizeBasePath strips trailing slashes from a route prefix so paths can be
+// appended to it. The prefix must be absolute; "/" serves routes from the root.
+func NormalizeBasePath(path string) (string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("%q must start with /", path)
+	}
+	return strings.TrimRight(path, "/"), nil
+}
+
+// DeprecationHeader marks responses served from a deprecated route
+const DeprecationHeader = "Deprecation"
+
+// Deprecated marks responses to routes under legacyBasePath as deprecated and
+// links to the same route under basePath, so clients can find where to move.
+func Deprecated(legacyBasePath, basePath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		successor := basePath + strings.TrimPrefix(c.Request.URL.Path, legacyBasePath)
+		c.Header(DeprecationHeader, "true")
+		c.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+		c.Next()
+	}
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemJSONContentType is the media type of RFC 7807 problem documents
+const ProblemJSONContentType = "application/problem+json"
+
+// ErrorResponse represents error response structure
+type ErrorResponse struct {
+	Error   string      `json:"error"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// ProblemDetails represents an RFC 7807 error response (application/problem+json)
+type ProblemDetails struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Detail   string      `json:"detail,omitempty"`
+	Instance string      `json:"instance,omitempty"`
+	Code     string      `json:"code"`
+	Details  interface{} `json:"details,omitempty"`
+}
+
+// RespondError writes an error response in the format requested by the client.
+// Clients sending "Accept: application/problem+json" receive an RFC 7807 problem
+// document; everyone else gets the standard ErrorResponse shape. It is the
+// ErrorResponder every service passes to the middleware here.
+func RespondError(c *gin.Context, status int, code, message string) {
+	RespondErrorWithDetails(c, status, code, message, nil)
+}
+
+// RespondErrorWithDetails writes an error response carrying additional
+// structured details such as field-level validation errors
+func RespondErrorWithDetails(c *gin.Context, status int, code, message string, details interface{}) {
+	if acceptsProblemJSON(c) {
+		c.Header("Content-Type", ProblemJSONContentType+"; charset=utf-8")
+		c.JSON(status, ProblemDetails{
+			Type:     "https://eventbooking.com/problems/" + strings.ReplaceAll(code, "_", "-"),
+			Title:    http.StatusText(status),
+			Status:   status,
+			Detail:   message,
+			Instance: c.Request.URL.Path,
+			Code:     code,
+			Details:  details,
+		})
+		return
+	}
+
+	c.Header("Content-Type", JSONContentType)
+	c.JSON(status, ErrorResponse{
+		Error:   code,
+		Message: message,
+		Details: details,
+	})
+}
+
+// acceptsProblemJSON reports whether the Accept header asks for problem+json
+func acceptsProblemJSON(c *gin.Context) bool {
+	for _, accept := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(accept, ";", 2)[0])
+		if strings.EqualFold(mediaType, ProblemJSONContentType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConcurrencyLimit caps how many requests the wrapped routes serve at once,
+// meant for long-lived connections such as SSE streams. Requests over the cap
+// are rejected immediately with 503 and a Retry-After header rather than
+// queued; the slot is released when the handler returns, i.e. on disconnect.
+// A max of zero or less disables the limit.
+func ConcurrencyLimit(max int, retryAfter time.Duration, respond ErrorResponder) gin.HandlerFunc {
+	if max <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	slots := make(chan struct{}, max)
+	retryAfterSeconds := strconv.Itoa(int(retryAfter.Seconds()))
+
+	return func(c *gin.Context) {
+		select {
+		case slots <- struct{}{}:
+		default:
+			c.Header("Retry-After", retryAfterSeconds)
+			respond(c, http.StatusServiceUnavailable, "too_many_connections", "Too many open connections, please retry later")
+			c.Abort()
+			return
+		}
+		defer func() { <-slots }()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceSwitch is the runtime maintenance mode, shared by all instances
+// of a service, e.g. through Redis
+type MaintenanceSwitch interface {
+	MaintenanceMode() (bool, error)
+}
+
+// Maintenance rejects writes with 503 while maintenance mode is on, either
+// because configuration enables it or because the switch was turned on at
+// runtime. It must run after authentication. GET, HEAD and OPTIONS requests
+// pass, as do POST routes that only read, listed in readOnly by their path
+// below the API prefix, and callers with the service role, so work accepted
+// before maintenance began, e.g. bookings being processed, can finish. A
+// switch that can't be read counts as off.
+func Maintenance(configured bool, maintenance MaintenanceSwitch, respond ErrorResponder, readOnly ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if c.GetString(ContextUserRole) == auth.RoleService {
+			c.Next()
+			return
+		}
+		for _, route := range readOnly {
+			if strings.HasSuffix(c.FullPath(), route) {
+				c.Next()
+				return
+			}
+		}
+
+		on := configured
+		if !on {
+			var err error
+			if on, err = maintenance.MaintenanceMode(); err != nil {
+				log.Printf("Failed to read maintenance mode, allowing request: %v", err)
+			}
+		}
+		if on {
+			respond(c, http.StatusServiceUnavailable, "maintenance_mode",
+				"The service is under maintenance and not accepting changes, please retry later")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,151 @@
+// Package middleware provides the Gin middleware shared by all HTTP services.
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// Context keys set by Auth for use in handlers
+const (
+	ContextUserID     = "user_id"
+	ContextUserEmail  = "user_email"
+	ContextUserRole   = "user_role"
+	ContextUserLocale = "user_locale"
+	ContextClaims     = "token_claims"
+
+	// ContextGuestHoldID is the hold a guest token is scoped to; it is empty
+	// for account holders
+	ContextGuestHoldID = "guest_hold_id"
+)
+
+// ErrorResponder writes an error response in the calling service's format
+type ErrorResponder func(c *gin.Context, status int, code, message string)
+
+// AuthErrorCodes are the error codes returned for each authentication failure
+type AuthErrorCodes struct {
+	MissingHeader string
+	InvalidFormat string
+	InvalidToken  string
+}
+
+// DefaultAuthErrorCodes reports every authentication failure as "unauthorized"
+var DefaultAuthErrorCodes = AuthErrorCodes{
+	MissingHeader: "unauthorized",
+	InvalidFormat: "unauthorized",
+	InvalidToken:  "unauthorized",
+}
+
+// Auth is a Gin middleware for JWT authentication. Failures are written with
+// respond using the given codes, so each service keeps its own error format.
+// Guest tokens are rejected; routes open to guests use AuthAllowGuests.
+func Auth(jwtService *auth.JWTService, respond ErrorResponder, codes AuthErrorCodes) gin.HandlerFunc {
+	return authenticate(jwtService, respond, codes, false)
+}
+
+// AuthAllowGuests is Auth for the routes a guest needs to complete or release
+// their hold. Handlers must check ContextGuestHoldID against the hold in use.
+func AuthAllowGuests(jwtService *auth.JWTService, respond ErrorResponder, codes AuthErrorCodes) gin.HandlerFunc {
+	return authenticate(jwtService, respond, codes, true)
+}
+
+func authenticate(jwtService *auth.JWTService, respond ErrorResponder, codes AuthErrorCodes, allowGuests bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			respond(c, http.StatusUnauthorized, codes.MissingHeader, "Authorization header is required")
+			c.Abort()
+			return
+		}
+
+		// Check if the header starts with "Bearer "
+		tokenParts := strings.Split(authHeader, " ")
+		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+			respond(c, http.StatusUnauthorized, codes.InvalidFormat, "Invalid authorization header format")
+			c.Abort()
+			return
+		}
+
+		claims, err := jwtService.ValidateToken(tokenParts[1])
+		if err != nil {
+			respond(c, http.StatusUnauthorized, codes.InvalidToken, "Invalid or expired token")
+			c.Abort()
+			return
+		}
+
+		if claims.IsGuest() && claims.HoldID == "" {
+			respond(c, http.StatusUnauthorized, codes.InvalidToken, "Invalid or expired token")
+			c.Abort()
+			return
+		}
+		if claims.IsGuest() && !allowGuests {
+			respond(c, http.StatusForbidden, "guest_not_allowed", "Guest tokens can only be used to complete or release their hold")
+			c.Abort()
+			return
+		}
+
+		// Store user information in context for use in handlers
+		c.Set(ContextUserID, claims.UserID)
+		c.Set(ContextUserEmail, claims.Email)
+		c.Set(ContextUserRole, claims.Role)
+		c.Set(ContextUserLocale, claims.Locale)
+		c.Set(ContextClaims, claims)
+		if claims.IsGuest() {
+			c.Set(ContextGuestHoldID, claims.HoldID)
+		}
+
+		// Add X-User-ID header for downstream services
+		c.Header("X-User-ID", claims.UserID)
+
+		c.Next()
+	}
+}
+
+// CORS handles Cross-Origin Resource Sharing
+func CORS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Credentials", "true")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Debug, X-Request-ID")
+		c.Header("Access-Control-Expose-Headers", "X-Request-ID, X-Response-Time, X-API-Version, Deprecation, Link")
+		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Logging logs each request with its status code and latency
+func Logging() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		raw := c.Request.URL.RawQuery
+
+		// Process request
+		c.Next()
+
+		end := time.Now()
+		if raw != "" {
+			path = path + "?" + raw
+		}
+
+		// Simple log format - in production, use structured logging
+		requestID := c.GetString(ContextRequestID)
+		if requestID == "" {
+			requestID = "-"
+		}
+		fmt.Fprintf(gin.DefaultWriter, "[GIN] %s | %s | %s | %s %s | %d | %s\n",
+			end.Format("2006/01/02 - 15:04:05"), requestID, c.ClientIP(), c.Request.Method, path,
+			c.Writer.Status(), end.Sub(start))
+	}
+}
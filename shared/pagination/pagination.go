@@ -0,0 +1,107 @@
+// Package pagination reads the paging parameters of list endpoints and builds
+// the pagination block of their responses, so every service pages alike.
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrInvalidCursor is returned for a cursor that wasn't issued as a next_cursor
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorPrefix marks the offset encoded in a cursor
+const cursorPrefix = "offset:"
+
+// Limits are the page sizes of a list endpoint
+type Limits struct {
+	Default int
+	Max     int
+}
+
+// Clamp applies the default page size to missing or invalid limits and caps it at the maximum
+func (l Limits) Clamp(limit int) int {
+	if limit < 1 {
+		return l.Default
+	}
+	if limit > l.Max {
+		return l.Max
+	}
+	return limit
+}
+
+// Page is the window of results a request asked for
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// FromQuery reads the page from the limit and offset query parameters, or
+// from cursor, which takes precedence over offset. Missing or malformed limits
+// and offsets fall back to the defaults; only a malformed cursor is an error.
+func FromQuery(c *gin.Context, limits Limits) (Page, error) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	page := Page{Limit: limits.Clamp(limit)}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		offset, err := decodeCursor(cursor)
+		if err != nil {
+			return Page{}, err
+		}
+		page.Offset = offset
+		return page, nil
+	}
+
+	page.Offset, _ = strconv.Atoi(c.Query("offset"))
+	if page.Offset < 0 {
+		page.Offset = 0
+	}
+	return page, nil
+}
+
+// Pagination describes a page of results in list responses
+type Pagination struct {
+	Total   int  `json:"total"`
+	Limit   int  `json:"limit"`
+	Offset  int  `json:"offset"`
+	HasMore bool `json:"has_more"`
+
+	// Pass as cursor to fetch the following page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Paginate builds the pagination block for this page out of total results
+func (p Page) Paginate(total int) Pagination {
+	pagination := Pagination{
+		Total:   total,
+		Limit:   p.Limit,
+		Offset:  p.Offset,
+		HasMore: p.Offset+p.Limit < total,
+	}
+	if pagination.HasMore {
+		pagination.NextCursor = encodeCursor(p.Offset + p.Limit)
+	}
+	return pagination
+}
+
+// encodeCursor makes an opaque cursor for an offset. Cursors are positional,
+// so results added or removed between requests can still shift pages.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), cursorPrefix))
+	if err != nil || offset < 0 || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, ErrInvalidCursor
+	}
+	return offset, nil
+}
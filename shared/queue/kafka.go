@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaQueue is the production MessageQueue
+type KafkaQueue struct {
+	brokers     []string
+	startOffset int64
+	writer      *kafka.Writer
+}
+
+// NewKafkaQueue creates a queue on the given brokers whose new consumer groups
+// start at startOffset, kafka.FirstOffset or kafka.LastOffset. Its writer has
+// no fixed topic, and keyed messages always land on the same partition.
+func NewKafkaQueue(brokers []string, startOffset int64) *KafkaQueue {
+	return &KafkaQueue{
+		brokers:     brokers,
+		startOffset: startOffset,
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+func (q *KafkaQueue) Produce(ctx context.Context, msgs ...kafka.Message) error {
+	return q.writer.WriteMessages(ctx, msgs...)
+}
+
+func (q *KafkaQueue) Consume(topic, group string) Consumer {
+	return &kafkaConsumer{reader: kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     q.brokers,
+		Topic:       topic,
+		GroupID:     group,
+		StartOffset: q.startOffset,
+	})}
+}
+
+func (q *KafkaQueue) Close() error {
+	return q.writer.Close()
+}
+
+// kafkaConsumer reads through a consumer group, committing offsets only when asked
+type kafkaConsumer struct {
+	reader *kafka.Reader
+}
+
+func (c *kafkaConsumer) Fetch(ctx context.Context) (kafka.Message, error) {
+	return c.reader.FetchMessage(ctx)
+}
+
+func (c *kafkaConsumer) Commit(ctx context.Context, msgs ...kafka.Message) error {
+	return c.reader.CommitMessages(ctx, msgs...)
+}
+
+func (c *kafkaConsumer) Close() error {
+	return c.reader.Close()
+}
@@ -0,0 +1,32 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// LogTopic consumes topic as group until ctx is done or the queue closes,
+// logging and committing every message. An in-memory queue runs it for topics
+// whose real consumer is another process, such as the notification worker,
+// which can't reach the queue: the messages still show up in the logs and the
+// topic's buffer never fills.
+func LogTopic(ctx context.Context, mq MessageQueue, topic, group string) {
+	consumer := mq.Consume(topic, group)
+	defer consumer.Close()
+
+	log.Printf("Logging messages on %s in-process", topic)
+	for {
+		msg, err := consumer.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, ErrClosed) {
+				log.Printf("Stopped logging %s: %v", topic, err)
+			}
+			return
+		}
+		log.Printf("Message on %s: key=%s value=%s", topic, msg.Key, msg.Value)
+		if err := consumer.Commit(ctx, msg); err != nil {
+			log.Printf("Failed to commit message on %s: %v", topic, err)
+		}
+	}
+}
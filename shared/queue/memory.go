@@ -0,0 +1,119 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// MemoryQueue is a MessageQueue backed by buffered channels, one per topic.
+// Messages only reach consumers in the same process and are lost on exit.
+// Every consumer of a topic competes for its messages whatever their group,
+// so each message is delivered once. Commits are no-ops.
+type MemoryQueue struct {
+	size int
+
+	mu      sync.Mutex
+	topics  map[string]*memoryTopic
+	closed  chan struct{}
+	closing sync.Once
+}
+
+type memoryTopic struct {
+	messages   chan kafka.Message
+	nextOffset int64
+}
+
+// NewMemoryQueue creates a queue buffering up to size messages per topic
+func NewMemoryQueue(size int) *MemoryQueue {
+	return &MemoryQueue{
+		size:   size,
+		topics: make(map[string]*memoryTopic),
+		closed: make(chan struct{}),
+	}
+}
+
+// topic returns the named topic, creating it on first use
+func (q *MemoryQueue) topic(name string) *memoryTopic {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t, ok := q.topics[name]
+	if !ok {
+		t = &memoryTopic{messages: make(chan kafka.Message, q.size)}
+		q.topics[name] = t
+	}
+	return t
+}
+
+// Produce enqueues messages without blocking, failing with ErrFull when a
+// topic's buffer has no room left. Keys and values are copied, since callers
+// may reuse their buffers once Produce returns.
+func (q *MemoryQueue) Produce(ctx context.Context, msgs ...kafka.Message) error {
+	for _, msg := range msgs {
+		select {
+		case <-q.closed:
+			return ErrClosed
+		default:
+		}
+		if msg.Topic == "" {
+			return fmt.Errorf("message has no topic")
+		}
+
+		t := q.topic(msg.Topic)
+
+		// Hold the lock while enqueuing so offsets stay in order
+		q.mu.Lock()
+		msg.Key = append([]byte(nil), msg.Key...)
+		msg.Value = append([]byte(nil), msg.Value...)
+		msg.Offset = t.nextOffset
+		msg.Time = time.Now().UTC()
+		select {
+		case t.messages <- msg:
+			t.nextOffset++
+			q.mu.Unlock()
+		default:
+			q.mu.Unlock()
+			return fmt.Errorf("%w: topic %s", ErrFull, msg.Topic)
+		}
+	}
+	return nil
+}
+
+func (q *MemoryQueue) Consume(topic, group string) Consumer {
+	return &memoryConsumer{queue: q, topic: q.topic(topic)}
+}
+
+// Close stops the queue. Messages still buffered are dropped.
+func (q *MemoryQueue) Close() error {
+	q.closing.Do(func() { close(q.closed) })
+	return nil
+}
+
+type memoryConsumer struct {
+	queue *MemoryQueue
+	topic *memoryTopic
+}
+
+// Fetch blocks until a message arrives, the context ends or the queue closes
+func (c *memoryConsumer) Fetch(ctx context.Context) (kafka.Message, error) {
+	select {
+	case msg := <-c.topic.messages:
+		return msg, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	case <-c.queue.closed:
+		return kafka.Message{}, ErrClosed
+	}
+}
+
+func (c *memoryConsumer) Commit(ctx context.Context, msgs ...kafka.Message) error {
+	return nil
+}
+
+func (c *memoryConsumer) Close() error {
+	return nil
+}
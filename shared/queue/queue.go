@@ -0,0 +1,90 @@
+// Package queue is the messaging seam between the services. Production runs on
+// Kafka; the in-memory implementation lets a single process run end to end
+// without a broker, e.g. in integration tests.
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Drivers selectable through configuration
+const (
+	DriverKafka  = "kafka"
+	DriverMemory = "memory"
+)
+
+// Where a consumer group without committed offsets starts reading a topic.
+// Groups that have committed resume from there regardless.
+const (
+	StartEarliest = "earliest"
+	StartLatest   = "latest"
+)
+
+var (
+	// ErrClosed is returned by Produce and Fetch once the queue has been closed
+	ErrClosed = errors.New("queue closed")
+
+	// ErrFull is returned by the in-memory queue when a topic's buffer is full
+	ErrFull = errors.New("queue full")
+)
+
+// MessageQueue publishes messages and hands out consumers. Messages keep the
+// kafka-go shape so headers, keys and offsets mean the same on every driver.
+type MessageQueue interface {
+	// Produce publishes messages, each to the topic set on it
+	Produce(ctx context.Context, msgs ...kafka.Message) error
+
+	// Consume returns a consumer of topic as part of the given consumer group
+	Consume(topic, group string) Consumer
+
+	Close() error
+}
+
+// Consumer reads messages from one topic. Messages are redelivered after a
+// restart unless committed.
+type Consumer interface {
+	Fetch(ctx context.Context) (kafka.Message, error)
+	Commit(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// New creates the queue for the configured driver. startOffset is where new
+// Kafka consumer groups start, StartEarliest or StartLatest; memorySize is the
+// number of messages buffered per topic by the in-memory driver, which only
+// ever delivers messages produced after a consumer's process started.
+func New(driver string, brokers []string, startOffset string, memorySize int) (MessageQueue, error) {
+	switch driver {
+	case DriverKafka:
+		if len(brokers) == 0 {
+			return nil, fmt.Errorf("no kafka brokers configured")
+		}
+		offset, err := ParseStartOffset(startOffset)
+		if err != nil {
+			return nil, err
+		}
+		return NewKafkaQueue(brokers, offset), nil
+	case DriverMemory:
+		if memorySize < 1 {
+			return nil, fmt.Errorf("memory queue size must be at least 1, got %d", memorySize)
+		}
+		return NewMemoryQueue(memorySize), nil
+	default:
+		return nil, fmt.Errorf("unknown message queue %q, expected %q or %q", driver, DriverKafka, DriverMemory)
+	}
+}
+
+// ParseStartOffset converts StartEarliest or StartLatest to a kafka-go start offset
+func ParseStartOffset(startOffset string) (int64, error) {
+	switch startOffset {
+	case StartEarliest:
+		return kafka.FirstOffset, nil
+	case StartLatest:
+		return kafka.LastOffset, nil
+	default:
+		return 0, fmt.Errorf("unknown start offset %q, expected %q or %q", startOffset, StartEarliest, StartLatest)
+	}
+}
@@ -0,0 +1,45 @@
+// Package startup waits for a service's dependencies at boot. Pods are often
+// started before the database, Redis or Kafka they need is ready; retrying the
+// first connection for a while avoids crash loops while those come up.
+package startup
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Retry is the budget for connecting to a dependency at startup
+type Retry struct {
+	// Attempts is the total number of tries, at least 1
+	Attempts int
+
+	// Backoff is the wait after the first failure; it doubles after each
+	// further failure up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Connect calls connect until it succeeds or the attempts run out, logging
+// each failure. The error returned is the last attempt's.
+func (r Retry) Connect(name string, connect func() error) error {
+	attempts := max(r.Attempts, 1)
+	backoff := r.Backoff
+
+	for attempt := 1; ; attempt++ {
+		err := connect()
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("Connected to %s on attempt %d", name, attempt)
+			}
+			return nil
+		}
+		if attempt == attempts {
+			return fmt.Errorf("%s unavailable after %d attempts: %w", name, attempts, err)
+		}
+
+		log.Printf("Connecting to %s failed (attempt %d/%d), retrying in %s: %v", name, attempt, attempts, backoff, err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, r.MaxBackoff)
+	}
+}
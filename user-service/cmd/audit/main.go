@@ -0,0 +1,101 @@
+// Command audit persists the audit events published by every service to the
+// append-only audit_events table.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/queue"
+	"github.com/arunvm123/eventbooking/user-service/config"
+	"github.com/arunvm123/eventbooking/user-service/model"
+	"github.com/arunvm123/eventbooking/user-service/repository/postgres"
+	"github.com/segmentio/kafka-go"
+)
+
+// storeRetryInterval is the wait before storing an event again after the
+// database refused it; the event isn't committed until it is stored
+const storeRetryInterval = 5 * time.Second
+
+func main() {
+	time.Local = time.UTC
+
+	cfg, err := config.Initialise("config.yaml", false)
+	if err != nil {
+		// If config file fails, try environment variables
+		log.Printf("Config file not found or invalid, using environment variables: %v", err)
+		cfg, err = config.Initialise("", true)
+		if err != nil {
+			log.Fatal("Failed to load configuration:", err)
+		}
+	}
+	if len(cfg.Audit.Brokers) == 0 {
+		log.Fatal("The audit consumer needs KAFKA_BROKERS")
+	}
+
+	repo, err := postgres.NewAuditRepository(cfg.Database.GetDatabaseURL(), cfg.Startup.Retry())
+	if err != nil {
+		log.Fatal("Failed to initialize repository:", err)
+	}
+
+	// A new group starts from the oldest retained event so none are missed
+	mq := queue.NewKafkaQueue(cfg.Audit.Brokers, kafka.FirstOffset)
+	defer mq.Close()
+
+	consumer := mq.Consume(cfg.Audit.Topic, cfg.Audit.ConsumerGroup)
+	defer consumer.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	log.Printf("Persisting audit events from %s", cfg.Audit.Topic)
+	for {
+		msg, err := consumer.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Println("Audit consumer stopped")
+				return
+			}
+			log.Printf("Error reading audit event: %v", err)
+			continue
+		}
+
+		if !store(ctx, repo, msg) {
+			return
+		}
+		if err := consumer.Commit(ctx, msg); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to commit audit event at offset %d: %v", msg.Offset, err)
+		}
+	}
+}
+
+// store persists the message's event, retrying until the database accepts
+// it. Messages that aren't audit events are logged and skipped. Returns false
+// if the consumer is stopping.
+func store(ctx context.Context, repo *postgres.PostgresAuditRepository, msg kafka.Message) bool {
+	var event audit.Event
+	if err := json.Unmarshal(msg.Value, &event); err != nil || event.ID == "" {
+		log.Printf("Skipping malformed audit event at offset %d: %q", msg.Offset, msg.Value)
+		return true
+	}
+
+	for {
+		err := repo.AppendAuditEvent(model.NewAuditEvent(event))
+		if err == nil {
+			return true
+		}
+		log.Printf("Failed to store audit event %s, retrying in %s: %v", event.ID, storeRetryInterval, err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(storeRetryInterval):
+		}
+	}
+}
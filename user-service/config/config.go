@@ -1,6 +1,12 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/shared/startup"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -10,6 +16,66 @@ type Config struct {
 	Port      string         `yaml:"port" env:"PORT"`
 	Database  DatabaseConfig `yaml:"database" env:"DATABASE"`
 	JWTSecret string         `yaml:"jwt_secret" env:"JWT_SECRET"`
+
+	// Prefix for all API routes; the legacy /api prefix is kept as an alias
+	APIBasePath string `yaml:"api_base_path" env:"API_BASE_PATH"`
+
+	PasswordPolicy PasswordPolicyConfig `yaml:"password_policy"`
+
+	// Booking service, asked to anonymize bookings when a user deletes their account
+	BookingService BookingServiceConfig `yaml:"booking_service"`
+
+	// Event service, asked to anonymize holds when a user deletes their account
+	EventService EventServiceConfig `yaml:"event_service"`
+
+	Startup StartupConfig `yaml:"startup"`
+
+	Audit AuditConfig `yaml:"audit"`
+}
+
+// AuditConfig sets where audit events are published and, for the audit
+// consumer, the group it persists them as. Without brokers the API only logs
+// its audit events.
+type AuditConfig struct {
+	Brokers       []string `yaml:"brokers" env:"KAFKA_BROKERS" env-separator:","`
+	Topic         string   `yaml:"topic" env:"AUDIT_TOPIC"`
+	ConsumerGroup string   `yaml:"consumer_group" env:"AUDIT_CONSUMER_GROUP"`
+}
+
+// StartupConfig bounds how long the service waits at boot for Postgres to
+// accept connections before exiting
+type StartupConfig struct {
+	ConnectAttempts   int           `yaml:"connect_attempts" env:"STARTUP_CONNECT_ATTEMPTS"`
+	ConnectBackoff    time.Duration `yaml:"connect_backoff" env:"STARTUP_CONNECT_BACKOFF"`
+	ConnectMaxBackoff time.Duration `yaml:"connect_max_backoff" env:"STARTUP_CONNECT_MAX_BACKOFF"`
+}
+
+// Retry returns the connection budget for the shared startup helper
+func (s *StartupConfig) Retry() startup.Retry {
+	return startup.Retry{Attempts: s.ConnectAttempts, Backoff: s.ConnectBackoff, MaxBackoff: s.ConnectMaxBackoff}
+}
+
+type BookingServiceConfig struct {
+	BaseURL     string `yaml:"base_url" env:"BOOKING_SERVICE_URL"`
+	APIBasePath string `yaml:"api_base_path" env:"BOOKING_SERVICE_API_BASE_PATH"`
+}
+
+type EventServiceConfig struct {
+	BaseURL     string `yaml:"base_url" env:"EVENT_SERVICE_URL"`
+	APIBasePath string `yaml:"api_base_path" env:"EVENT_SERVICE_API_BASE_PATH"`
+}
+
+// PasswordPolicyConfig sets the strength required of new passwords. The rules
+// default to on; set PASSWORD_POLICY_ENABLED=false to accept any password in
+// development.
+type PasswordPolicyConfig struct {
+	Enabled          bool `yaml:"enabled" env:"PASSWORD_POLICY_ENABLED" env-default:"true"`
+	MinLength        int  `yaml:"min_length" env:"PASSWORD_MIN_LENGTH"`
+	RequireMixedCase bool `yaml:"require_mixed_case" env:"PASSWORD_REQUIRE_MIXED_CASE" env-default:"true"`
+	RequireDigit     bool `yaml:"require_digit" env:"PASSWORD_REQUIRE_DIGIT" env-default:"true"`
+	RequireSymbol    bool `yaml:"require_symbol" env:"PASSWORD_REQUIRE_SYMBOL"`
+	// Reject passwords found in the built-in list of common passwords
+	DenyCommon bool `yaml:"deny_common" env:"PASSWORD_DENY_COMMON" env-default:"true"`
 }
 
 type DatabaseConfig struct {
@@ -21,9 +87,9 @@ type DatabaseConfig struct {
 	SSLMode      string `yaml:"ssl_mode" env:"DB_SSL_MODE"`
 }
 
-// GetDatabaseURL constructs the PostgreSQL connection string
+// GetDatabaseURL constructs the PostgreSQL connection string, with sessions in UTC
 func (d *DatabaseConfig) GetDatabaseURL() string {
-	return "postgres://" + d.User + ":" + d.Password + "@" + d.Host + ":" + d.Port + "/" + d.DatabaseName + "?sslmode=" + d.SSLMode
+	return "postgres://" + d.User + ":" + d.Password + "@" + d.Host + ":" + d.Port + "/" + d.DatabaseName + "?sslmode=" + d.SSLMode + "&TimeZone=UTC"
 }
 
 func Initialise(filepath string, env bool) (*Config, error) {
@@ -64,6 +130,54 @@ func Initialise(filepath string, env bool) (*Config, error) {
 	if configuration.JWTSecret == "" {
 		configuration.JWTSecret = "your-secret-key-change-in-production"
 	}
+	if configuration.PasswordPolicy.MinLength == 0 {
+		configuration.PasswordPolicy.MinLength = 8
+	}
+	if configuration.PasswordPolicy.MinLength < 1 {
+		return nil, fmt.Errorf("password min length must be at least 1, got %d", configuration.PasswordPolicy.MinLength)
+	}
+	if configuration.BookingService.BaseURL == "" {
+		configuration.BookingService.BaseURL = "http://localhost:8083"
+	}
+	if configuration.BookingService.APIBasePath == "" {
+		configuration.BookingService.APIBasePath = "/api/v1"
+	}
+	if configuration.BookingService.APIBasePath, err = middleware.NormalizeBasePath(configuration.BookingService.APIBasePath); err != nil {
+		return nil, fmt.Errorf("invalid booking service API base path: %w", err)
+	}
+	if configuration.EventService.BaseURL == "" {
+		configuration.EventService.BaseURL = "http://localhost:8082"
+	}
+	if configuration.EventService.APIBasePath == "" {
+		configuration.EventService.APIBasePath = "/api/v1"
+	}
+	if configuration.EventService.APIBasePath, err = middleware.NormalizeBasePath(configuration.EventService.APIBasePath); err != nil {
+		return nil, fmt.Errorf("invalid event service API base path: %w", err)
+	}
+	if configuration.Audit.Topic == "" {
+		configuration.Audit.Topic = audit.DefaultTopic
+	}
+	if configuration.Audit.ConsumerGroup == "" {
+		configuration.Audit.ConsumerGroup = "audit-log"
+	}
+	if configuration.Startup.ConnectAttempts == 0 {
+		configuration.Startup.ConnectAttempts = 10
+	}
+	if configuration.Startup.ConnectBackoff == 0 {
+		configuration.Startup.ConnectBackoff = time.Second
+	}
+	if configuration.Startup.ConnectMaxBackoff == 0 {
+		configuration.Startup.ConnectMaxBackoff = 15 * time.Second
+	}
+	if configuration.Startup.ConnectAttempts < 0 || configuration.Startup.ConnectBackoff < 0 || configuration.Startup.ConnectMaxBackoff < 0 {
+		return nil, fmt.Errorf("startup connect attempts and backoff must be positive")
+	}
+	if configuration.APIBasePath == "" {
+		configuration.APIBasePath = "/api/v1"
+	}
+	if configuration.APIBasePath, err = middleware.NormalizeBasePath(configuration.APIBasePath); err != nil {
+		return nil, fmt.Errorf("invalid API base path: %w", err)
+	}
 
 	return &configuration, nil
 }
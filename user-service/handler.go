@@ -1,24 +1,40 @@
 package main
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/user-service/config"
 	"github.com/arunvm123/eventbooking/user-service/model"
 	"github.com/arunvm123/eventbooking/user-service/repository"
+	"github.com/arunvm123/eventbooking/user-service/service"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type UserHandler struct {
-	repo       repository.UserRepository
-	jwtService *JWTService
+	cfg            *config.Config
+	repo           repository.UserRepository
+	jwtService     *auth.JWTService
+	bookingService service.BookingService
+	eventService   service.EventService
+	audit          *audit.Recorder
 }
 
-func NewUserHandler(repo repository.UserRepository, jwtService *JWTService) *UserHandler {
+func NewUserHandler(cfg *config.Config, repo repository.UserRepository, jwtService *auth.JWTService, bookingService service.BookingService, eventService service.EventService, auditor *audit.Recorder) *UserHandler {
 	return &UserHandler{
-		repo:       repo,
-		jwtService: jwtService,
+		cfg:            cfg,
+		repo:           repo,
+		jwtService:     jwtService,
+		bookingService: bookingService,
+		eventService:   eventService,
+		audit:          auditor,
 	}
 }
 
@@ -26,10 +42,13 @@ func NewUserHandler(repo repository.UserRepository, jwtService *JWTService) *Use
 func (h *UserHandler) RegisterUser(c *gin.Context) {
 	var req model.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{
-			Error:   "validation_failed",
-			Message: err.Error(),
-		})
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	if errs := passwordPolicyErrors(h.cfg.PasswordPolicy, "password", req.Password); errs != nil {
+		middleware.RespondErrorWithDetails(c, http.StatusBadRequest, "validation_failed",
+			"Password does not meet the password policy", errs)
 		return
 	}
 
@@ -38,17 +57,11 @@ func (h *UserHandler) RegisterUser(c *gin.Context) {
 	// Create user in database
 	user, err := h.repo.CreateUser(createUserParams)
 	if err != nil {
-		if err.Error() == "email already exists" {
-			c.JSON(http.StatusBadRequest, model.ErrorResponse{
-				Error:   "validation_failed",
-				Message: "Email already exists",
-			})
+		if errors.Is(err, repository.ErrEmailAlreadyExists) {
+			middleware.RespondError(c, http.StatusBadRequest, "validation_failed", "Email already exists")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create user",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to create user")
 		return
 	}
 
@@ -64,76 +77,199 @@ func (h *UserHandler) RegisterUser(c *gin.Context) {
 func (h *UserHandler) LoginUser(c *gin.Context) {
 	var req model.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{
-			Error:   "validation_failed",
-			Message: err.Error(),
-		})
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
 		return
 	}
 
 	// Get user by email
 	user, err := h.repo.GetUserByEmail(req.Email)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-			Error:   "authentication_failed",
-			Message: "Invalid email or password",
-		})
+		h.recordLoginFailure(c, audit.Target("email", req.Email), "unknown_email")
+		middleware.RespondError(c, http.StatusUnauthorized, "authentication_failed", "Invalid email or password")
 		return
 	}
 
 	// Validate password
 	if !h.repo.ValidatePassword(user, req.Password) {
-		c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-			Error:   "authentication_failed",
-			Message: "Invalid email or password",
-		})
+		h.recordLoginFailure(c, audit.Target("user", user.ID), "wrong_password")
+		middleware.RespondError(c, http.StatusUnauthorized, "authentication_failed", "Invalid email or password")
 		return
 	}
 
 	// Generate JWT token
-	token, err := h.jwtService.GenerateToken(user)
+	token, err := h.jwtService.GenerateToken(tokenClaims(user))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to generate token",
-		})
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to generate token")
 		return
 	}
 
+	h.audit.Record(c.Request.Context(), audit.Event{
+		Action:    audit.ActionLoginSucceeded,
+		ActorID:   user.ID,
+		ActorRole: user.Role,
+		Target:    audit.Target("user", user.ID),
+		RequestID: c.GetString(middleware.ContextRequestID),
+	})
+
 	// Return login response
 	response := model.LoginResponse{
 		AccessToken: token,
-		ExpiresIn:   3600, // 1 hour in seconds
+		ExpiresIn:   int(tokenLifetime.Seconds()),
 		User:        *user.ToUserResponse(),
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// recordLoginFailure audits a failed login against target, the account or,
+// for unknown emails, the email tried
+func (h *UserHandler) recordLoginFailure(c *gin.Context, target, reason string) {
+	h.audit.Record(c.Request.Context(), audit.Event{
+		Action:    audit.ActionLoginFailed,
+		Target:    target,
+		RequestID: c.GetString(middleware.ContextRequestID),
+		Details:   map[string]string{"reason": reason, "client_ip": c.ClientIP()},
+	})
+}
+
+// GetCurrentUser returns the claims of the token presented in the
+// Authorization header. AuthMiddleware has already rejected invalid or
+// expired tokens with 401 by the time this runs.
+func (h *UserHandler) GetCurrentUser(c *gin.Context) {
+	value, exists := c.Get(middleware.ContextClaims)
+	claims, ok := value.(*auth.Claims)
+	if !exists || !ok {
+		middleware.RespondError(c, http.StatusUnauthorized, "unauthorized", "Invalid or expired token")
+		return
+	}
+
+	response := model.TokenInfoResponse{
+		UserID: claims.UserID,
+		Email:  claims.Email,
+		Role:   claims.Role,
+		Locale: claims.Locale,
+	}
+	if claims.IssuedAt != nil {
+		response.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		response.ExpiresAt = claims.ExpiresAt.Time
+		response.ExpiresIn = int(time.Until(claims.ExpiresAt.Time).Seconds())
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetUserName returns a user's display name to other services, e.g. for
+// greeting them in booking emails
+func (h *UserHandler) GetUserName(c *gin.Context) {
+	user, err := h.repo.GetUserByID(c.Param("userId"))
+	if err != nil {
+		middleware.RespondError(c, http.StatusNotFound, "not_found", "User not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.UserNameResponse{
+		UserID: user.ID,
+		Name:   user.FullName(),
+	})
+}
+
+// GetUsersBatch returns several users to other services in one call, e.g. to
+// show holders' names in listings without a request per user
+func (h *UserHandler) GetUsersBatch(c *gin.Context) {
+	var req model.UserBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	users, err := h.repo.GetUsersByIDs(req.UserIDs)
+	if err != nil {
+		log.Printf("Failed to look up %d users: %v", len(req.UserIDs), err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to look up users")
+		return
+	}
+
+	response := model.UserBatchResponse{Users: make(map[string]*model.UserResponse, len(users))}
+	for i := range users {
+		response.Users[users[i].ID] = users[i].ToUserResponse()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteAccount deletes the authenticated user's account once they confirm
+// their password. Their holds and bookings are anonymized first: the event
+// service erases the email kept for hold expiry warnings, and the booking
+// service replaces the email and name with placeholders but keeps seats,
+// amounts and statuses for reporting. The user record then has its email,
+// name and password erased and is soft-deleted, which blocks logins and the
+// user's existing tokens. If anonymization fails nothing is deleted, so the
+// request can simply be retried.
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	var req model.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.RespondError(c, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	user, err := h.repo.GetUserByID(c.GetString(middleware.ContextUserID))
+	if err != nil {
+		middleware.RespondError(c, http.StatusUnauthorized, "unauthorized", "Invalid or expired token")
+		return
+	}
+	if !h.repo.ValidatePassword(user, req.Password) {
+		middleware.RespondError(c, http.StatusUnauthorized, "authentication_failed", "Invalid password")
+		return
+	}
+
+	anonymizedHolds, err := h.eventService.AnonymizeUserHolds(user.ID)
+	if err != nil {
+		log.Printf("Failed to anonymize holds of user %s: %v", user.ID, err)
+		middleware.RespondError(c, http.StatusBadGateway, "event_service_unavailable", "Failed to anonymize holds, please retry")
+		return
+	}
+
+	anonymized, err := h.bookingService.AnonymizeUserBookings(user.ID)
+	if err != nil {
+		log.Printf("Failed to anonymize bookings of user %s: %v", user.ID, err)
+		middleware.RespondError(c, http.StatusBadGateway, "booking_service_unavailable", "Failed to anonymize bookings, please retry")
+		return
+	}
+
+	if err := h.repo.DeleteUser(user.ID); err != nil {
+		log.Printf("Failed to delete user %s: %v", user.ID, err)
+		middleware.RespondError(c, http.StatusInternalServerError, "internal_error", "Failed to delete account")
+		return
+	}
+
+	log.Printf("Deleted user %s and anonymized %d holds and %d bookings", user.ID, anonymizedHolds, anonymized)
+	h.audit.RecordRequest(c, audit.ActionAccountDeleted, audit.Target("user", user.ID),
+		map[string]string{
+			"anonymized_holds":    strconv.Itoa(anonymizedHolds),
+			"anonymized_bookings": strconv.Itoa(anonymized),
+		})
+	c.Status(http.StatusNoContent)
+}
+
 // HealthCheck handles health check endpoint
 func (h *UserHandler) HealthCheck(c *gin.Context) {
 	// Check database connection
 	sqlDB, err := h.repo.GetDB().DB()
 	if err != nil {
-		c.JSON(http.StatusServiceUnavailable, model.ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Database connection failed",
-		})
+		middleware.RespondError(c, http.StatusServiceUnavailable, "service_unavailable", "Database connection failed")
 		return
 	}
 
 	if err := sqlDB.Ping(); err != nil {
-		c.JSON(http.StatusServiceUnavailable, model.ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Database ping failed",
-		})
+		middleware.RespondError(c, http.StatusServiceUnavailable, "service_unavailable", "Database ping failed")
 		return
 	}
 
 	response := model.HealthResponse{
 		Status:    "healthy",
 		Service:   "user-service",
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -2,11 +2,15 @@ package main
 
 import (
 	"log"
+	"time"
 
 	"github.com/arunvm123/eventbooking/user-service/config"
 )
 
 func main() {
+	// Keep timestamps in UTC regardless of the host's time zone
+	time.Local = time.UTC
+
 	// Initialize configuration
 	// Try to load from config.yaml first, fallback to environment variables
 	cfg, err := config.Initialise("config.yaml", false)
@@ -2,152 +2,63 @@ package main
 
 import (
 	"net/http"
-	"strings"
 	"time"
 
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
 	"github.com/arunvm123/eventbooking/user-service/model"
+	"github.com/arunvm123/eventbooking/user-service/repository"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// JWTClaims represents the JWT claims structure
-type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	jwt.RegisteredClaims
-}
-
-// JWTService handles JWT operations
-type JWTService struct {
-	secretKey string
-}
-
-func NewJWTService(secretKey string) *JWTService {
-	return &JWTService{secretKey: secretKey}
-}
+// tokenLifetime is how long issued access tokens remain valid
+const tokenLifetime = time.Hour
 
-// GenerateToken generates a JWT token for the user
-func (j *JWTService) GenerateToken(user *model.User) (string, error) {
-	claims := JWTClaims{
+// tokenClaims builds the JWT claims issued to the user at login
+func tokenClaims(user *model.User) auth.Claims {
+	now := time.Now()
+	return auth.Claims{
 		UserID: user.ID,
 		Email:  user.Email,
+		Role:   user.Role,
+		Locale: user.Locale,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 1)), // 1 hour expiration
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenLifetime)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.secretKey))
 }
 
-// ValidateToken validates a JWT token and returns the claims
-func (j *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.secretKey), nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
-	}
-
-	return nil, jwt.ErrInvalidKey
+// AuthMiddleware authenticates requests with the shared JWT middleware,
+// reporting failures in this service's error format
+func AuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
+	return middleware.Auth(jwtService, middleware.RespondError, middleware.DefaultAuthErrorCodes)
 }
 
-// AuthMiddleware is a Gin middleware for JWT authentication
-func AuthMiddleware(jwtService *JWTService) gin.HandlerFunc {
+// RequireService rejects callers that aren't another service. It must run
+// after AuthMiddleware.
+func RequireService() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "Authorization header is required",
-			})
-			c.Abort()
-			return
-		}
-
-		// Check if the header starts with "Bearer "
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "Invalid authorization header format",
-			})
-			c.Abort()
-			return
-		}
-
-		tokenString := tokenParts[1]
-		claims, err := jwtService.ValidateToken(tokenString)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, model.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "Invalid or expired token",
-			})
+		if c.GetString(middleware.ContextUserRole) != auth.RoleService {
+			middleware.RespondError(c, http.StatusForbidden, "forbidden", "Service role required")
 			c.Abort()
 			return
 		}
-
-		// Store user information in context for use in handlers
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-
-		// Add X-User-ID header for downstream services (as per architecture)
-		c.Header("X-User-ID", claims.UserID)
-
 		c.Next()
 	}
 }
 
-// CORSMiddleware handles CORS
-func CORSMiddleware() gin.HandlerFunc {
+// RequireActiveUser rejects tokens of users who have since deleted their
+// account. It must run after AuthMiddleware.
+func RequireActiveUser(repo repository.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		if _, err := repo.GetUserByID(c.GetString(middleware.ContextUserID)); err != nil {
+			middleware.RespondError(c, http.StatusUnauthorized, "unauthorized", "Invalid or expired token")
+			c.Abort()
 			return
 		}
-
-		c.Next()
-	}
-}
-
-// LoggingMiddleware logs HTTP requests
-func LoggingMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Start timer
-		start := time.Now()
-		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
-
-		// Process request
 		c.Next()
-
-		// Log format
-		end := time.Now()
-		latency := end.Sub(start)
-
-		clientIP := c.ClientIP()
-		method := c.Request.Method
-		statusCode := c.Writer.Status()
-
-		if raw != "" {
-			path = path + "?" + raw
-		}
-
-		// Simple log format - in production, use structured logging
-		gin.DefaultWriter.Write([]byte("[GIN] " + end.Format("2006/01/02 - 15:04:05") + " | " +
-			clientIP + " | " + method + " " + path + " | " +
-			string(rune(statusCode)) + " | " + latency.String() + "\n"))
 	}
 }
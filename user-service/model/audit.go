@@ -0,0 +1,39 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/arunvm123/eventbooking/shared/audit"
+)
+
+// AuditEvent is an audit event persisted by the audit consumer. The table is
+// append-only: the database rejects updates and deletes.
+type AuditEvent struct {
+	ID         string    `gorm:"type:text;primary_key"`
+	Action     string    `gorm:"type:text;not null;index"`
+	ActorID    string    `gorm:"type:text;index"`
+	ActorRole  string    `gorm:"type:text"`
+	Target     string    `gorm:"type:text;not null;index"`
+	Service    string    `gorm:"type:text;not null"`
+	RequestID  string    `gorm:"type:text"`
+	Details    string    `gorm:"type:jsonb"`
+	OccurredAt time.Time `gorm:"not null;index"`
+	CreatedAt  time.Time // When the consumer stored it
+}
+
+// NewAuditEvent converts a published audit event for storage
+func NewAuditEvent(event audit.Event) *AuditEvent {
+	details, _ := json.Marshal(event.Details)
+	return &AuditEvent{
+		ID:         event.ID,
+		Action:     event.Action,
+		ActorID:    event.ActorID,
+		ActorRole:  event.ActorRole,
+		Target:     event.Target,
+		Service:    event.Service,
+		RequestID:  event.RequestID,
+		Details:    string(details),
+		OccurredAt: event.OccurredAt,
+	}
+}
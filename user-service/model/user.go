@@ -1,13 +1,25 @@
 package model
 
 import (
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // ===============================
 // Database Entities (Internal)
 // ===============================
 
+// DefaultLocale is the locale of users who registered without choosing one
+const DefaultLocale = "en"
+
+// User roles carried in JWT claims. Admins are promoted directly in the database.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 // User represents the user entity in the database
 type User struct {
 	ID           string `gorm:"primary_key;default:gen_random_uuid()"`
@@ -15,8 +27,14 @@ type User struct {
 	PasswordHash string `gorm:"not null"`
 	FirstName    string `gorm:"not null"`
 	LastName     string `gorm:"not null"`
+	Role         string `gorm:"type:varchar(20);not null;default:'user'"`
+	Locale       string `gorm:"type:varchar(16);not null;default:'en'"`
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+
+	// Set when the user deletes their account. Deleted users are hidden from
+	// every query, so they can no longer log in or use existing tokens.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 // ToUserResponse converts database User to API response
@@ -26,10 +44,17 @@ func (u *User) ToUserResponse() *UserResponse {
 		Email:     u.Email,
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
+		Role:      u.Role,
+		Locale:    u.Locale,
 		CreatedAt: u.CreatedAt,
 	}
 }
 
+// FullName joins the user's first and last name
+func (u *User) FullName() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
 // ===============================
 // Repository DTOs (Internal)
 // ===============================
@@ -41,6 +66,7 @@ type CreateUserRequest struct {
 	Password  string // Plain text password (will be hashed in repository)
 	FirstName string
 	LastName  string
+	Locale    string
 }
 
 // ===============================
@@ -50,9 +76,12 @@ type CreateUserRequest struct {
 // RegisterRequest represents the user registration request from API
 type RegisterRequest struct {
 	Email     string `json:"email" binding:"required,email"`
-	Password  string `json:"password" binding:"required,min=8"`
+	Password  string `json:"password" binding:"required"` // Strength is checked against the password policy
 	FirstName string `json:"first_name" binding:"required"`
 	LastName  string `json:"last_name" binding:"required"`
+
+	// Locale selects the language of notification emails, e.g. "fr" or "de-AT"
+	Locale string `json:"locale" binding:"omitempty,bcp47_language_tag"`
 }
 
 // ToCreateUserRequest converts API request to repository request
@@ -62,9 +91,15 @@ func (r *RegisterRequest) ToCreateUserRequest() CreateUserRequest {
 		Password:  r.Password,
 		FirstName: r.FirstName,
 		LastName:  r.LastName,
+		Locale:    r.Locale,
 	}
 }
 
+// DeleteAccountRequest confirms account deletion with the user's password
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
 // LoginRequest represents the user login request
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
@@ -77,6 +112,8 @@ type UserResponse struct {
 	Email     string    `json:"email"`
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
+	Role      string    `json:"role"`
+	Locale    string    `json:"locale"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -92,9 +129,37 @@ type LoginResponse struct {
 	User        UserResponse `json:"user"`
 }
 
-// ErrorResponse represents error responses
-type ErrorResponse struct {
-	Error   string `json:"error"`
+// UserNameResponse is returned to other services looking up a user's display name
+type UserNameResponse struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// UserBatchRequest asks for up to 100 users by ID at once
+type UserBatchRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1,max=100,dive,required"`
+}
+
+// UserBatchResponse maps each found user's ID to the user. IDs of unknown or
+// deleted users are missing from it.
+type UserBatchResponse struct {
+	Users map[string]*UserResponse `json:"users"`
+}
+
+// TokenInfoResponse represents the claims of a validated access token
+type TokenInfoResponse struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Locale    string    `json:"locale,omitempty"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	ExpiresIn int       `json:"expires_in"`
+}
+
+// FieldError describes a validation failure for a single request field
+type FieldError struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
@@ -0,0 +1,70 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/arunvm123/eventbooking/user-service/config"
+	"github.com/arunvm123/eventbooking/user-service/model"
+)
+
+// commonPasswordList holds widely used passwords, one per line, that are
+// rejected regardless of how they score against the other rules
+//
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = func() map[string]bool {
+	passwords := make(map[string]bool)
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		if password := strings.TrimSpace(line); password != "" {
+			passwords[strings.ToLower(password)] = true
+		}
+	}
+	return passwords
+}()
+
+// passwordPolicyErrors checks a new password against the policy and returns
+// one field error per unmet requirement, or nil if it is acceptable
+func passwordPolicyErrors(policy config.PasswordPolicyConfig, field, password string) []model.FieldError {
+	if !policy.Enabled {
+		return nil
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	var errs []model.FieldError
+	unmet := func(requirement string) {
+		errs = append(errs, model.FieldError{Field: field, Message: "password " + requirement})
+	}
+	if len([]rune(password)) < policy.MinLength {
+		unmet(fmt.Sprintf("must be at least %d characters long", policy.MinLength))
+	}
+	if policy.RequireMixedCase && !(hasUpper && hasLower) {
+		unmet("must contain both upper and lower case letters")
+	}
+	if policy.RequireDigit && !hasDigit {
+		unmet("must contain a digit")
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		unmet("must contain a symbol")
+	}
+	if policy.DenyCommon && commonPasswords[strings.ToLower(password)] {
+		unmet("is too common")
+	}
+	return errs
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/arunvm123/eventbooking/user-service/config"
+)
+
+func TestPasswordPolicyErrors(t *testing.T) {
+	strict := config.PasswordPolicyConfig{
+		Enabled:          true,
+		MinLength:        10,
+		RequireMixedCase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		DenyCommon:       true,
+	}
+
+	tests := []struct {
+		name     string
+		policy   config.PasswordPolicyConfig
+		password string
+		want     []string
+	}{
+		{"meets every rule", strict, "Tr0ub4dor&3x", nil},
+		{"too short", strict, "Ab1!efg", []string{"password must be at least 10 characters long"}},
+		{"no upper case", strict, "tr0ub4dor&3x", []string{"password must contain both upper and lower case letters"}},
+		{"no lower case", strict, "TR0UB4DOR&3X", []string{"password must contain both upper and lower case letters"}},
+		{"no digit", strict, "Troubador&xx", []string{"password must contain a digit"}},
+		{"no symbol", strict, "Tr0ub4dor3xx", []string{"password must contain a symbol"}},
+		{"common password", config.PasswordPolicyConfig{Enabled: true, DenyCommon: true}, "password", []string{"password is too common"}},
+		{"common password in another case", config.PasswordPolicyConfig{Enabled: true, DenyCommon: true}, "PassWord", []string{"password is too common"}},
+		{"every rule unmet", strict, "password", []string{
+			"password must be at least 10 characters long",
+			"password must contain both upper and lower case letters",
+			"password must contain a digit",
+			"password must contain a symbol",
+			"password is too common",
+		}},
+		{"policy disabled", config.PasswordPolicyConfig{MinLength: 10, DenyCommon: true}, "password", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, fieldErr := range passwordPolicyErrors(tt.policy, "password", tt.password) {
+				if fieldErr.Field != "password" {
+					t.Errorf("field = %q, want password", fieldErr.Field)
+				}
+				got = append(got, fieldErr.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("passwordPolicyErrors(%q) = %q, want %q", tt.password, got, tt.want)
+			}
+		})
+	}
+}
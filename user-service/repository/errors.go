@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+// Errors returned by UserRepository implementations, matched with errors.Is
+var (
+	ErrEmailAlreadyExists = errors.New("email already exists")
+)
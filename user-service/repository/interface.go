@@ -7,12 +7,24 @@ import (
 
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
-	// CreateUser creates a new user with hashed password
+	// CreateUser creates a new user with hashed password, returning
+	// ErrEmailAlreadyExists when the email is taken
 	CreateUser(req model.CreateUserRequest) (*model.User, error)
 
 	// GetUserByEmail retrieves a user by email
 	GetUserByEmail(email string) (*model.User, error)
 
+	// GetUserByID retrieves a user by ID. Deleted users are not found.
+	GetUserByID(id string) (*model.User, error)
+
+	// GetUsersByIDs retrieves the users with the given IDs in a single query.
+	// Unknown and deleted users are left out.
+	GetUsersByIDs(ids []string) ([]model.User, error)
+
+	// DeleteUser erases the user's personal data and soft-deletes them, keeping
+	// the ID so records that reference it stay consistent
+	DeleteUser(id string) error
+
 	// ValidatePassword checks if the provided password matches the user's password
 	ValidatePassword(user *model.User, password string) bool
 
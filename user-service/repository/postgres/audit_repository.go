@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/arunvm123/eventbooking/shared/startup"
+	"github.com/arunvm123/eventbooking/user-service/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// appendOnlyAuditSQL makes the database refuse to change or remove stored
+// audit events, whoever connects
+const appendOnlyAuditSQL = `
+CREATE OR REPLACE FUNCTION reject_audit_event_change() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events
+	FOR EACH ROW EXECUTE FUNCTION reject_audit_event_change();
+
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+CREATE TRIGGER audit_events_no_truncate BEFORE TRUNCATE ON audit_events
+	FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_event_change();
+`
+
+// PostgresAuditRepository stores the audit trail
+type PostgresAuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository connects to the database, retrying within the retry
+// budget, and creates the append-only audit table
+func NewAuditRepository(databaseURL string, retry startup.Retry) (*PostgresAuditRepository, error) {
+	db, err := open(databaseURL, retry)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.AutoMigrate(&model.AuditEvent{}); err != nil {
+		return nil, err
+	}
+	if err := db.Exec(appendOnlyAuditSQL).Error; err != nil {
+		return nil, fmt.Errorf("failed to make audit events append-only: %w", err)
+	}
+
+	log.Println("Database connected and AuditEvent table migrated successfully")
+
+	return &PostgresAuditRepository{db: db}, nil
+}
+
+// AppendAuditEvent stores an event. Events are delivered at least once, so an
+// event already stored is skipped.
+func (r *PostgresAuditRepository) AppendAuditEvent(event *model.AuditEvent) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(event).Error
+}
@@ -3,19 +3,40 @@ package postgres
 import (
 	"errors"
 	"log"
+	"time"
 
+	"github.com/arunvm123/eventbooking/shared/startup"
 	"github.com/arunvm123/eventbooking/user-service/model"
+	"github.com/arunvm123/eventbooking/user-service/repository"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// utcNow is the clock gorm uses for CreatedAt/UpdatedAt
+func utcNow() time.Time {
+	return time.Now().UTC()
+}
+
+// open connects to the database, which gorm checks with a ping
+func open(databaseURL string, retry startup.Retry) (*gorm.DB, error) {
+	var db *gorm.DB
+	err := retry.Connect("postgres", func() (err error) {
+		// TranslateError maps driver-specific errors (e.g. unique violations) to gorm errors
+		db, err = gorm.Open(postgres.Open(databaseURL), &gorm.Config{TranslateError: true, NowFunc: utcNow})
+		return err
+	})
+	return db, err
+}
+
 type PostgresUserRepository struct {
 	db *gorm.DB
 }
 
-func NewUserRepository(databaseURL string) (*PostgresUserRepository, error) {
-	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
+// NewUserRepository connects to the database, retrying within the retry budget
+// while it isn't accepting connections yet
+func NewUserRepository(databaseURL string, retry startup.Retry) (*PostgresUserRepository, error) {
+	db, err := open(databaseURL, retry)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +56,7 @@ func (r *PostgresUserRepository) CreateUser(req model.CreateUserRequest) (*model
 	// Check if user already exists
 	var existingUser model.User
 	if err := r.db.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
-		return nil, errors.New("email already exists")
+		return nil, repository.ErrEmailAlreadyExists
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
 	}
@@ -46,15 +67,27 @@ func (r *PostgresUserRepository) CreateUser(req model.CreateUserRequest) (*model
 		return nil, err
 	}
 
+	locale := req.Locale
+	if locale == "" {
+		locale = model.DefaultLocale
+	}
+
 	// Create user
 	user := model.User{
 		Email:        req.Email,
 		PasswordHash: string(hashedPassword),
 		FirstName:    req.FirstName,
 		LastName:     req.LastName,
+		Role:         model.RoleUser,
+		Locale:       locale,
 	}
 
 	if err := r.db.Create(&user).Error; err != nil {
+		// A concurrent registration can insert the same email between the check
+		// above and this insert; the unique index catches it here
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, repository.ErrEmailAlreadyExists
+		}
 		return nil, err
 	}
 
@@ -73,6 +106,48 @@ func (r *PostgresUserRepository) GetUserByEmail(email string) (*model.User, erro
 	return &user, nil
 }
 
+// GetUserByID retrieves a user by ID
+func (r *PostgresUserRepository) GetUserByID(id string) (*model.User, error) {
+	var user model.User
+	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUsersByIDs retrieves the users with the given IDs
+func (r *PostgresUserRepository) GetUsersByIDs(ids []string) ([]model.User, error) {
+	var users []model.User
+	if err := r.db.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// DeleteUser replaces the user's email, name and password with placeholders
+// and marks them deleted. The placeholder email frees the address for a new
+// registration.
+func (r *PostgresUserRepository) DeleteUser(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"email":         "deleted-" + id + "@deleted.invalid",
+			"first_name":    "Deleted",
+			"last_name":     "User",
+			"password_hash": "",
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("user not found")
+		}
+		return tx.Where("id = ?", id).Delete(&model.User{}).Error
+	})
+}
+
 // ValidatePassword checks if the provided password matches the user's password
 func (r *PostgresUserRepository) ValidatePassword(user *model.User, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
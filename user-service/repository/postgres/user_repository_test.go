@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/arunvm123/eventbooking/shared/startup"
+	"github.com/arunvm123/eventbooking/user-service/model"
+	"github.com/arunvm123/eventbooking/user-service/repository"
+	"github.com/google/uuid"
+)
+
+// newTestRepository connects to the database in TEST_DATABASE_URL, skipping the
+// test when it isn't set. Tests only touch the users they create.
+func newTestRepository(t *testing.T) *PostgresUserRepository {
+	t.Helper()
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	repo, err := NewUserRepository(databaseURL, startup.Retry{Attempts: 1})
+	if err != nil {
+		t.Fatalf("NewUserRepository() error = %v", err)
+	}
+	return repo
+}
+
+func TestConcurrentRegistrationsWithSameEmail(t *testing.T) {
+	repo := newTestRepository(t)
+	email := uuid.NewString() + "@example.com"
+	t.Cleanup(func() {
+		repo.db.Unscoped().Where("email = ?", email).Delete(&model.User{})
+	})
+
+	const registrations = 10
+	errs := make([]error, registrations)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = repo.CreateUser(model.CreateUserRequest{
+				Email:     email,
+				Password:  "Correct-horse-9",
+				FirstName: "Ana",
+				LastName:  "Silva",
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, repository.ErrEmailAlreadyExists):
+			t.Errorf("CreateUser() error = %v, want %v", err, repository.ErrEmailAlreadyExists)
+		}
+	}
+	if created != 1 {
+		t.Errorf("%d registrations succeeded, want exactly 1", created)
+	}
+}
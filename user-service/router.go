@@ -2,42 +2,90 @@ package main
 
 import (
 	"log"
+	"net/http"
 
+	"github.com/arunvm123/eventbooking/shared/audit"
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/arunvm123/eventbooking/shared/middleware"
+	"github.com/arunvm123/eventbooking/shared/queue"
 	"github.com/arunvm123/eventbooking/user-service/config"
 	"github.com/arunvm123/eventbooking/user-service/repository/postgres"
+	httpservice "github.com/arunvm123/eventbooking/user-service/service/http"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
 )
 
 func SetupRouter(cfg *config.Config) *gin.Engine {
 	// Initialize repository
-	repo, err := postgres.NewUserRepository(cfg.Database.GetDatabaseURL())
+	repo, err := postgres.NewUserRepository(cfg.Database.GetDatabaseURL(), cfg.Startup.Retry())
 	if err != nil {
 		log.Fatal("Failed to initialize repository:", err)
 	}
 
 	// Initialize JWT service
-	jwtService := NewJWTService(cfg.JWTSecret)
+	jwtService := auth.NewJWTService(cfg.JWTSecret)
+
+	// Initialize Booking Service client
+	bookingService := httpservice.NewHTTPBookingService(cfg.BookingService.BaseURL, cfg.BookingService.APIBasePath, cfg.JWTSecret)
+
+	// Initialize Event Service client
+	eventService := httpservice.NewHTTPEventService(cfg.EventService.BaseURL, cfg.EventService.APIBasePath, cfg.JWTSecret)
+
+	// Publish logins and account deletions to the audit trail; without Kafka
+	// they're only logged
+	var auditQueue queue.MessageQueue
+	if len(cfg.Audit.Brokers) > 0 {
+		auditQueue = queue.NewKafkaQueue(cfg.Audit.Brokers, kafka.LastOffset)
+	}
+	auditor := audit.NewRecorder("user-service", auditQueue, cfg.Audit.Topic)
 
 	// Initialize handlers
-	userHandler := NewUserHandler(repo, jwtService)
+	userHandler := NewUserHandler(cfg, repo, jwtService, bookingService, eventService, auditor)
 
 	// Setup Gin router
 	r := gin.Default()
 
 	// Add middleware
-	r.Use(CORSMiddleware())
-	r.Use(LoggingMiddleware())
+	r.Use(middleware.CORS())
+	r.Use(middleware.Debug())
+	r.Use(middleware.Logging())
+	r.Use(middleware.DefaultJSON())
+
+	// Unknown routes get a JSON error rather than gin's plain text 404
+	r.NoRoute(func(c *gin.Context) {
+		middleware.RespondError(c, http.StatusNotFound, "not_found", "Route not found")
+	})
 
 	// Health check endpoint (no auth required)
 	r.GET("/health", userHandler.HealthCheck)
 
 	// API routes
-	api := r.Group("/api")
-	users := api.Group("/users")
+	registerRoutes := func(api *gin.RouterGroup) {
+		users := api.Group("/users")
+
+		// Public endpoints (no auth required)
+		users.POST("/register", userHandler.RegisterUser)
+		users.POST("/login", userHandler.LoginUser)
 
-	// Public endpoints (no auth required)
-	users.POST("/register", userHandler.RegisterUser)
-	users.POST("/login", userHandler.LoginUser)
+		// Protected endpoints
+		protected := users.Group("", AuthMiddleware(jwtService), RequireActiveUser(repo))
+		protected.GET("/me", userHandler.GetCurrentUser)
+		protected.DELETE("/me", userHandler.DeleteAccount)
+
+		// Bulk lookup for services enriching their data with user details
+		users.POST("/batch", AuthMiddleware(jwtService), RequireService(), userHandler.GetUsersBatch)
+
+		// Internal endpoints for other services
+		internal := api.Group("/internal", AuthMiddleware(jwtService), RequireService())
+		internal.GET("/users/:userId/name", userHandler.GetUserName)
+	}
+	registerRoutes(r.Group(cfg.APIBasePath))
+
+	// Keep serving the unversioned prefix while clients migrate
+	if cfg.APIBasePath != middleware.LegacyAPIBasePath {
+		registerRoutes(r.Group(middleware.LegacyAPIBasePath,
+			middleware.Deprecated(middleware.LegacyAPIBasePath, cfg.APIBasePath)))
+	}
 
 	return r
 }
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/arunvm123/eventbooking/shared/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type HTTPBookingService struct {
+	baseURL     string
+	apiBasePath string
+	httpClient  *http.Client
+	jwtService  *auth.JWTService
+}
+
+func NewHTTPBookingService(baseURL, apiBasePath, jwtSecret string) *HTTPBookingService {
+	return &HTTPBookingService{
+		baseURL:     baseURL,
+		apiBasePath: apiBasePath,
+		jwtService:  auth.NewJWTService(jwtSecret),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// serviceToken issues a short-lived token with the service role for the user
+func serviceToken(jwtService *auth.JWTService, userID string) (string, error) {
+	now := time.Now()
+	return jwtService.GenerateToken(auth.Claims{
+		UserID: userID,
+		Role:   auth.RoleService,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(5 * time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "user-service",
+			Subject:   "service-auth",
+		},
+	})
+}
+
+// AnonymizeUserBookings asks the booking service to strip the user's personal
+// data from their bookings
+func (s *HTTPBookingService) AnonymizeUserBookings(userID string) (int, error) {
+	url := fmt.Sprintf("%s%s/internal/users/%s/anonymize", s.baseURL, s.apiBasePath, userID)
+
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	token, err := serviceToken(s.jwtService, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate service token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call booking service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("booking service returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		AnonymizedBookings int `json:"anonymized_bookings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.AnonymizedBookings, nil
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/arunvm123/eventbooking/shared/auth"
+)
+
+type HTTPEventService struct {
+	baseURL     string
+	apiBasePath string
+	httpClient  *http.Client
+	jwtService  *auth.JWTService
+}
+
+func NewHTTPEventService(baseURL, apiBasePath, jwtSecret string) *HTTPEventService {
+	return &HTTPEventService{
+		baseURL:     baseURL,
+		apiBasePath: apiBasePath,
+		jwtService:  auth.NewJWTService(jwtSecret),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// AnonymizeUserHolds asks the event service to erase the user's email from
+// their holds
+func (s *HTTPEventService) AnonymizeUserHolds(userID string) (int, error) {
+	url := fmt.Sprintf("%s%s/internal/users/%s/anonymize", s.baseURL, s.apiBasePath, userID)
+
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	token, err := serviceToken(s.jwtService, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate service token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call event service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("event service returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		AnonymizedHolds int `json:"anonymized_holds"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.AnonymizedHolds, nil
+}
@@ -0,0 +1,15 @@
+package service
+
+// BookingService defines the interface for communicating with the Booking Service
+type BookingService interface {
+	// AnonymizeUserBookings replaces the user's personal data on their bookings
+	// and returns how many bookings were changed
+	AnonymizeUserBookings(userID string) (int, error)
+}
+
+// EventService defines the interface for communicating with the Event Service
+type EventService interface {
+	// AnonymizeUserHolds erases the user's email from their holds and returns
+	// how many holds were changed
+	AnonymizeUserHolds(userID string) (int, error)
+}